@@ -12,14 +12,22 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/internal/info"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/gateway"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/mcp"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/scenario"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/tools"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
 	"k8s.io/klog/v2"
 )
 
+// scenarioTick is how often a running scenario checks for due events.
+const scenarioTick = time.Second
+
 // ValidLogLevels are the accepted log levels.
 var ValidLogLevels = []string{"debug", "info", "warn", "error"}
 
@@ -63,7 +71,18 @@ func main() {
 	// Parse command-line flags
 	var (
 		mode     = flag.String("mode", ModeReadOnly, "Operation mode: read-only or operator")
-		nvmlMode = flag.String("nvml-mode", "mock", "NVML mode: mock or real (requires GPU hardware)")
+		nvmlMode = flag.String("nvml-mode", "mock",
+			"NVML mode: mock, fixture (GPUs from --nvml-fixture), scenario "+
+				"(faults from --nvml-scenario), replay (snapshot from --nvml-replay) "+
+				"or real (requires GPU hardware)")
+		nvmlFixture = flag.String("nvml-fixture", "",
+			"Path to a YAML or JSON GPU fixture file (used with --nvml-mode=fixture)")
+		nvmlScenario = flag.String("nvml-scenario", "",
+			"Path to a YAML or JSON fault scenario file (used with --nvml-mode=scenario)")
+		nvmlReplay = flag.String("nvml-replay", "",
+			"Path to an NVML snapshot to replay (used with --nvml-mode=replay)")
+		nvmlRecord = flag.String("nvml-record", "",
+			"Record NVML results to this snapshot file, saved at startup and on exit")
 		showVer  = flag.Bool("version", false, "Show version information and exit")
 		logLevel = flag.String("log-level", "info", "Log level: debug, info, warn, error")
 
@@ -78,6 +97,48 @@ func main() {
 			"Namespace for GPU agent pods (gateway mode)")
 		routingMode = flag.String("routing-mode", "http",
 			"Gateway routing mode: http (default, direct HTTP) or exec (legacy)")
+		responseCache = flag.Bool("response-cache", true,
+			"Reuse recent aggregated responses and coalesce identical "+
+				"concurrent calls (gateway mode)")
+		callBudget = flag.Duration("call-budget", gateway.DefaultCallBudget,
+			"Default deadline of a fan-out to node agents, retries included; "+
+				"tools accept timeout_seconds to override it (gateway mode)")
+		hedgeDelay = flag.Duration("hedge-delay", 0,
+			"Also try an agent's DNS endpoint when its pod IP has not answered "+
+				"after this delay, 0 disables (gateway mode, http routing)")
+		circuitFailureRate = flag.Float64("circuit-failure-rate", 0.5,
+			"Share of failed requests to a node within the circuit window "+
+				"that opens its circuit (gateway mode)")
+		circuitMinRequests = flag.Int("circuit-min-requests", 3,
+			"Requests to a node within the circuit window before its "+
+				"failure rate is evaluated (gateway mode)")
+		circuitWindow = flag.Duration("circuit-window", time.Minute,
+			"Sliding window over which node failures are counted (gateway mode)")
+		circuitResetTimeout = flag.Duration("circuit-reset-timeout", 30*time.Second,
+			"How long an open circuit waits before sending one probe "+
+				"request (gateway mode)")
+
+		// Process-to-pod mapping
+		procRoot = flag.String("proc-root", "/proc",
+			"Procfs root used to map GPU processes to pods "+
+				"(mount the host /proc here, e.g. /host/proc)")
+
+		// Persistent XID journal
+		xidJournal = flag.String("xid-journal", "",
+			"File to record XID events in as they are logged, so that "+
+				"analyze_xid_errors sees them after the kernel ring buffer "+
+				"has rotated (empty = read the kernel log on every call)")
+		xidJournalMaxAge = flag.Duration("xid-journal-max-age",
+			xid.DefaultJournalMaxAge, "How long the XID journal keeps events")
+		xidJournalMaxSizeMB = flag.Int("xid-journal-max-size-mb",
+			xid.DefaultJournalMaxSize>>20,
+			"Size in MiB above which the XID journal drops its oldest events")
+		xidMetrics = flag.Bool("xid-metrics", true,
+			"Export XID errors from the kernel log as Prometheus metrics "+
+				"on /metrics (HTTP mode only)")
+		xidCatalog = flag.String("xid-catalog", "",
+			"YAML or JSON file overriding and extending the built-in XID "+
+				"and SXid catalog (empty = built-in catalog only)")
 
 		// Oneshot mode for exec-based invocations
 		oneshot = flag.Int("oneshot", 0,
@@ -105,12 +166,41 @@ func main() {
 	}
 
 	// Validate nvml-mode flag (only relevant in non-gateway mode)
-	if !*gatewayMode && *nvmlMode != "mock" && *nvmlMode != "real" {
-		klog.ErrorS(nil, "invalid nvml-mode",
-			"nvmlMode", *nvmlMode, "valid", []string{"mock", "real"})
+	if !*gatewayMode && *nvmlMode != "mock" && *nvmlMode != "fixture" &&
+		*nvmlMode != "scenario" && *nvmlMode != "replay" && *nvmlMode != "real" {
+		klog.ErrorS(nil, "invalid nvml-mode", "nvmlMode", *nvmlMode,
+			"valid", []string{"mock", "fixture", "scenario", "replay", "real"})
 		klog.Flush()
 		os.Exit(1)
 	}
+	if !*gatewayMode && *nvmlMode == "fixture" && *nvmlFixture == "" {
+		klog.ErrorS(nil, "--nvml-fixture is required with --nvml-mode=fixture")
+		klog.Flush()
+		os.Exit(1)
+	}
+	if !*gatewayMode && *nvmlMode == "scenario" && *nvmlScenario == "" {
+		klog.ErrorS(nil, "--nvml-scenario is required with --nvml-mode=scenario")
+		klog.Flush()
+		os.Exit(1)
+	}
+	if !*gatewayMode && *nvmlMode == "replay" && *nvmlReplay == "" {
+		klog.ErrorS(nil, "--nvml-replay is required with --nvml-mode=replay")
+		klog.Flush()
+		os.Exit(1)
+	}
+
+	// Validate the XID catalog overlay up front; it is applied once the
+	// driver version is known
+	var xidCatalogOverlay *xid.CatalogOverlay
+	if !*gatewayMode && *xidCatalog != "" {
+		overlay, err := xid.LoadCatalogOverlay(*xidCatalog)
+		if err != nil {
+			klog.ErrorS(err, "failed to load XID catalog", "catalog", *xidCatalog)
+			klog.Flush()
+			os.Exit(1)
+		}
+		xidCatalogOverlay = overlay
+	}
 
 	// Resolve log level from env var and flag
 	effectiveLogLevel := resolveLogLevel(*logLevel)
@@ -188,15 +278,26 @@ func main() {
 	// Build MCP server config
 	buildInfo := info.GetInfo()
 	mcpCfg := mcp.Config{
-		Mode:        *mode,
-		Version:     buildInfo.Version,
-		GitCommit:   buildInfo.GitCommit,
-		Transport:   transport,
-		HTTPAddr:    httpAddr,
-		GatewayMode: *gatewayMode,
-		Namespace:   *namespace,
-		Oneshot:     *oneshot,
-		RoutingMode: *routingMode,
+		Mode:          *mode,
+		Version:       buildInfo.Version,
+		GitCommit:     buildInfo.GitCommit,
+		Transport:     transport,
+		HTTPAddr:      httpAddr,
+		GatewayMode:   *gatewayMode,
+		Namespace:     *namespace,
+		Oneshot:       *oneshot,
+		RoutingMode:   *routingMode,
+		ResponseCache: *responseCache,
+		CallBudget:    *callBudget,
+		HedgeDelay:    *hedgeDelay,
+		CircuitBreaker: gateway.CircuitBreakerConfig{
+			FailureRate:  *circuitFailureRate,
+			MinRequests:  *circuitMinRequests,
+			Window:       *circuitWindow,
+			ResetTimeout: *circuitResetTimeout,
+		},
+		NodeName: os.Getenv("NODE_NAME"),
+		ProcRoot: *procRoot,
 	}
 
 	if *gatewayMode {
@@ -209,29 +310,134 @@ func main() {
 			klog.Flush()
 			os.Exit(1)
 		}
+		// Serve agent discovery and node metadata from informers; reads
+		// go to the API server until they sync and /readyz waits for them
+		k8sClient.StartCache(ctx)
 		mcpCfg.K8sClient = k8sClient
 	} else {
 		// Regular mode: initialize NVML client
 		var nvmlClient nvml.Interface
-		if *nvmlMode == "real" {
+		switch *nvmlMode {
+		case "real":
 			klog.InfoS("initializing real NVML (requires GPU hardware)")
 			nvmlClient = nvml.NewReal()
-		} else {
+		case "fixture":
+			klog.InfoS("initializing fixture NVML", "fixture", *nvmlFixture)
+			fixtureClient, err := nvml.NewMockFromFixtureFile(*nvmlFixture)
+			if err != nil {
+				klog.ErrorS(err, "failed to load NVML fixture",
+					"fixture", *nvmlFixture)
+				klog.Flush()
+				os.Exit(1)
+			}
+			nvmlClient = fixtureClient
+		case "scenario":
+			klog.InfoS("initializing scenario NVML", "scenario", *nvmlScenario)
+			engine, err := startScenario(ctx, *nvmlScenario)
+			if err != nil {
+				klog.ErrorS(err, "failed to start NVML scenario",
+					"scenario", *nvmlScenario)
+				klog.Flush()
+				os.Exit(1)
+			}
+			defer func() { _ = os.Remove(engine.KmsgPath()) }()
+			nvmlClient = engine.NVML()
+			mcpCfg.KmsgPath = engine.KmsgPath()
+			mcpCfg.KmsgBootTime = engine.StartedAt()
+		case "replay":
+			klog.InfoS("initializing replayed NVML", "snapshot", *nvmlReplay)
+			replayClient, err := nvml.NewReplayFromFile(*nvmlReplay)
+			if err != nil {
+				klog.ErrorS(err, "failed to load NVML snapshot",
+					"snapshot", *nvmlReplay)
+				klog.Flush()
+				os.Exit(1)
+			}
+			nvmlClient = replayClient
+		default:
 			klog.InfoS("initializing mock NVML", "fakeGPUs", 2)
 			nvmlClient = nvml.NewMock(2)
 		}
 
+		var recorder *nvml.Recorder
+		if *nvmlRecord != "" {
+			klog.InfoS("recording NVML results", "snapshot", *nvmlRecord)
+			recorder = nvml.NewRecorder(nvmlClient, *nvmlRecord)
+			nvmlClient = recorder
+		}
+
 		if err := nvmlClient.Init(ctx); err != nil {
 			klog.ErrorS(err, "failed to initialize NVML", "nvmlMode", *nvmlMode)
+			if recorder != nil {
+				// A failing Init is worth reporting too
+				_ = recorder.Save()
+			}
 			klog.Flush()
 			os.Exit(1)
 		}
+
+		// Capture the full state up front so the snapshot is complete even
+		// if the agent is killed before it shuts down
+		if recorder != nil {
+			if err := recorder.Capture(ctx); err != nil {
+				klog.ErrorS(err, "failed to capture NVML state")
+			}
+			if err := recorder.Save(); err != nil {
+				klog.ErrorS(err, "failed to save NVML snapshot",
+					"snapshot", *nvmlRecord)
+			}
+		}
 		defer func() {
 			if err := nvmlClient.Shutdown(ctx); err != nil {
 				klog.ErrorS(err, "failed to shutdown NVML")
 			}
 		}()
 		mcpCfg.NVMLClient = nvmlClient
+
+		if xidCatalogOverlay != nil {
+			applyXIDCatalog(ctx, nvmlClient, xidCatalogOverlay, *xidCatalog)
+		}
+
+		// One kernel log follower feeds the XID journal and metrics
+		var journalCfg xid.JournalConfig
+		if *xidJournal != "" {
+			journalCfg = xid.JournalConfig{
+				Path:    *xidJournal,
+				MaxAge:  *xidJournalMaxAge,
+				MaxSize: int64(*xidJournalMaxSizeMB) << 20,
+			}
+		}
+		var xidRecorder *tools.XIDMetricsRecorder
+		if *xidMetrics && *port > 0 {
+			xidRecorder = tools.NewXIDMetricsRecorder(nvmlClient)
+		}
+		if journalCfg.Path != "" || xidRecorder != nil {
+			follower, err := startXIDFollower(ctx, journalCfg, xidRecorder,
+				mcpCfg.KmsgPath, mcpCfg.KmsgBootTime)
+			if err != nil {
+				// Analysis falls back to reading the kernel log per call
+				klog.ErrorS(err, "failed to follow kernel log for XID events",
+					"journal", journalCfg.Path)
+			} else {
+				if journal := follower.Journal(); journal != nil {
+					defer func() { _ = journal.Close() }()
+				}
+				mcpCfg.XIDFollower = follower
+			}
+		}
+
+		// In-cluster agents use the K8s API to resolve GPU process owners,
+		// and operator mode evicts pods with it. A missing K8s client is
+		// non-fatal; affected tools report the error.
+		if *mode == ModeOperator || mcpCfg.NodeName != "" {
+			k8sClient, err := k8s.NewClient(*namespace)
+			if err != nil {
+				klog.ErrorS(err, "failed to create K8s client, "+
+					"pod mapping and operator tools will be unavailable")
+			} else {
+				mcpCfg.K8sClient = k8sClient
+			}
+		}
 	}
 
 	// Initialize MCP server
@@ -275,3 +481,100 @@ func main() {
 	}
 	klog.InfoS("shutdown complete")
 }
+
+// startScenario loads a fault scenario and plays it in the background
+// until ctx is cancelled. XID events are written to a temporary kernel log
+// that analyze_xid_errors reads instead of /dev/kmsg.
+func startScenario(ctx context.Context, path string) (*scenario.Engine, error) {
+	s, err := scenario.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	kmsg, err := os.CreateTemp("", "k8s-gpu-mcp-server-kmsg-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kernel log: %w", err)
+	}
+	_ = kmsg.Close()
+
+	engine, err := scenario.New(s, kmsg.Name())
+	if err != nil {
+		_ = os.Remove(kmsg.Name())
+		return nil, err
+	}
+
+	go func() {
+		err := engine.Run(ctx, scenarioTick)
+		if err != nil && ctx.Err() == nil {
+			klog.ErrorS(err, "scenario stopped", "scenario", s.Name)
+		}
+	}()
+	klog.InfoS("scenario started", "scenario", s.Name,
+		"events", len(s.Events), "kmsg", engine.KmsgPath())
+	return engine, nil
+}
+
+// applyXIDCatalog makes the built-in XID catalog, with the overlay entries
+// for the driver version of the node applied, the active catalog.
+func applyXIDCatalog(
+	ctx context.Context,
+	nvmlClient nvml.Interface,
+	overlay *xid.CatalogOverlay,
+	path string,
+) {
+	driverVersion, err := nvmlClient.GetDriverVersion(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to get driver version, "+
+			"XID catalog entries with a driver range are skipped")
+	}
+	catalog := xid.NewCatalog(overlay, path, driverVersion)
+	xid.SetCatalog(catalog)
+	klog.InfoS("applied XID catalog overlay", "catalog", path,
+		"version", catalog.OverlayVersion, "driverVersion", driverVersion,
+		"applied", catalog.EntriesApplied, "skipped", catalog.EntriesSkipped)
+}
+
+// startXIDFollower follows the kernel log in the background until ctx is
+// cancelled, recording XID events in the journal configured by cfg, if it
+// has a path, and in the metrics of recorder, if not nil. kmsgPath and
+// bootTime replace /dev/kmsg and the host boot time when set, as for
+// scenarios. It returns the follower once it has opened the kernel log.
+func startXIDFollower(
+	ctx context.Context,
+	cfg xid.JournalConfig,
+	recorder *tools.XIDMetricsRecorder,
+	kmsgPath string,
+	bootTime time.Time,
+) (*xid.Follower, error) {
+	if kmsgPath == "" {
+		kmsgPath = xid.DefaultKmsgPath
+	}
+	if !xid.NewKmsgReaderWithPath(kmsgPath).IsAvailable() {
+		return nil, fmt.Errorf("kernel log %s is not readable", kmsgPath)
+	}
+	parser := xid.NewParserWithKmsgPath(kmsgPath)
+	parser.SetBootTime(bootTime)
+
+	var journal *xid.Journal
+	if cfg.Path != "" {
+		var err error
+		if journal, err = xid.OpenJournal(cfg); err != nil {
+			return nil, err
+		}
+		klog.InfoS("XID journal opened", "journal", cfg.Path,
+			"maxAge", cfg.MaxAge, "maxSize", cfg.MaxSize)
+	}
+
+	follower := xid.NewFollower(parser, journal)
+	if recorder != nil {
+		follower.SetEventHandler(recorder.Record)
+		klog.InfoS("exporting XID errors as metrics")
+	}
+	if err := follower.Start(ctx); err != nil {
+		if journal != nil {
+			_ = journal.Close()
+		}
+		return nil, err
+	}
+	return follower, nil
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultAgentInfoTTL is how long the version and tool list of an
+	// agent pod are reused. A new pod, e.g. after an upgrade, is always
+	// discovered again.
+	DefaultAgentInfoTTL = 10 * time.Minute
+
+	// agentInfoRetry is how long the gateway waits before trying again to
+	// discover an agent that did not answer.
+	agentInfoRetry = 30 * time.Second
+
+	// discoveryTimeout bounds the discovery of one agent, so that a hung
+	// agent does not use up the budget of the call it precedes.
+	discoveryTimeout = 5 * time.Second
+)
+
+// AgentInfo is what an agent reported about itself.
+type AgentInfo struct {
+	// Version is the agent's server version from the initialize response
+	Version string `json:"version"`
+	// Tools are the tools the agent lists
+	Tools []string `json:"tools"`
+	// PodName is the agent pod the information came from
+	PodName string `json:"pod_name"`
+	// DiscoveredAt is when the agent was asked
+	DiscoveredAt time.Time `json:"discovered_at"`
+}
+
+// HasTool reports whether the agent provides a tool.
+func (a *AgentInfo) HasTool(name string) bool {
+	return slices.Contains(a.Tools, name)
+}
+
+// UnsupportedToolError is returned instead of calling an agent that does
+// not provide the requested tool, typically because it runs an older
+// version during a rolling upgrade.
+type UnsupportedToolError struct {
+	Node         string
+	Tool         string
+	AgentVersion string
+}
+
+// Error implements error.
+func (e *UnsupportedToolError) Error() string {
+	return fmt.Sprintf("agent %s on node %s does not provide %s",
+		e.AgentVersion, e.Node, e.Tool)
+}
+
+// AgentCatalog caches the version and tool list of each agent pod. It is
+// safe for concurrent use and may be shared between routers.
+type AgentCatalog struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu     sync.Mutex
+	agents map[string]agentEntry
+}
+
+// agentEntry is the cached discovery result for the agent pod on a node.
+// info is nil when discovery failed.
+type agentEntry struct {
+	podName   string
+	info      *AgentInfo
+	expiresAt time.Time
+}
+
+// NewAgentCatalog creates a catalog keeping agent information for ttl.
+func NewAgentCatalog(ttl time.Duration) *AgentCatalog {
+	if ttl <= 0 {
+		ttl = DefaultAgentInfoTTL
+	}
+	return &AgentCatalog{
+		ttl:    ttl,
+		now:    time.Now,
+		agents: make(map[string]agentEntry),
+	}
+}
+
+// lookup returns the cached information of the agent pod on node. known
+// is false when the agent must be discovered; info is nil when a recent
+// discovery failed.
+func (c *AgentCatalog) lookup(node k8s.GPUNode) (info *AgentInfo, known bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.agents[node.Name]
+	if !ok || entry.podName != node.PodName || !c.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.info, true
+}
+
+// store records a discovery result; a nil info is a failed discovery,
+// retried sooner.
+func (c *AgentCatalog) store(node k8s.GPUNode, info *AgentInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ttl := c.ttl
+	if info == nil {
+		ttl = min(agentInfoRetry, c.ttl)
+	}
+	c.agents[node.Name] = agentEntry{
+		podName:   node.PodName,
+		info:      info,
+		expiresAt: c.now().Add(ttl),
+	}
+}
+
+// Version returns the last known version of the agent on a node, or "".
+func (c *AgentCatalog) Version(nodeName string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.agents[nodeName]; ok && entry.info != nil {
+		return entry.info.Version
+	}
+	return ""
+}
+
+// Snapshot returns the last known information of every discovered agent,
+// by node name.
+func (c *AgentCatalog) Snapshot() map[string]AgentInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make(map[string]AgentInfo, len(c.agents))
+	for node, entry := range c.agents {
+		if entry.info != nil {
+			snapshot[node] = *entry.info
+		}
+	}
+	return snapshot
+}
+
+// agentInfo returns the information of the agent on node, discovering it
+// if needed. It returns nil when the agent could not be asked; callers then
+// proceed as if it provided every tool.
+func (r *Router) agentInfo(ctx context.Context, node k8s.GPUNode, requestID string) *AgentInfo {
+	if info, known := r.agents.lookup(node); known {
+		return info
+	}
+
+	discoverCtx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	info, err := r.discoverAgent(discoverCtx, node)
+	if err != nil {
+		klog.V(2).InfoS("agent discovery failed",
+			"requestID", requestID, "node", node.Name, "pod", node.PodName,
+			"error", err)
+		// Only the agent's failure is remembered, not the caller's
+		if ctx.Err() == nil {
+			r.agents.store(node, nil)
+		}
+		return nil
+	}
+
+	klog.V(2).InfoS("agent discovered",
+		"requestID", requestID, "node", node.Name, "pod", node.PodName,
+		"version", info.Version, "tools", len(info.Tools))
+	r.agents.store(node, info)
+	return info
+}
+
+// discoverAgent asks an agent for its version (initialize) and tools
+// (tools/list), over the routing path of the router.
+func (r *Router) discoverAgent(ctx context.Context, node k8s.GPUNode) (*AgentInfo, error) {
+	endpoint := node.GetAgentHTTPEndpoint()
+	if endpoint == "" {
+		endpoint = node.GetAgentDNSEndpoint()
+	}
+
+	var initResponse, listResponse []byte
+	if r.routingMode == RoutingModeHTTP && endpoint != "" {
+		// Stateless HTTP agents answer each request on its own
+		initRequest, err := BuildHTTPInitializeRequest()
+		if err != nil {
+			return nil, err
+		}
+		listRequest, err := BuildHTTPToolsListRequest()
+		if err != nil {
+			return nil, err
+		}
+		if initResponse, err = r.httpClient.CallMCP(ctx, endpoint, initRequest); err != nil {
+			return nil, err
+		}
+		if listResponse, err = r.httpClient.CallMCP(ctx, endpoint, listRequest); err != nil {
+			return nil, err
+		}
+	} else {
+		request, err := BuildMCPToolsListRequest()
+		if err != nil {
+			return nil, err
+		}
+		response, err := r.k8sClient.ExecInPod(ctx, node.PodName, "agent",
+			bytes.NewReader(request))
+		if err != nil {
+			return nil, err
+		}
+		objects := SplitJSONObjects(response)
+		if len(objects) < 2 {
+			return nil, fmt.Errorf("expected initialize and tools/list responses, got %d",
+				len(objects))
+		}
+		initResponse, listResponse = objects[0], objects[len(objects)-1]
+	}
+
+	info, err := parseAgentInfo(initResponse, listResponse)
+	if err != nil {
+		return nil, err
+	}
+	info.PodName = node.PodName
+	info.DiscoveredAt = time.Now()
+	return info, nil
+}
+
+// parseAgentInfo reads the agent version from an initialize response and
+// its tools from a tools/list response.
+func parseAgentInfo(initResponse, listResponse []byte) (*AgentInfo, error) {
+	var initResult struct {
+		ServerInfo MCPClientInfo `json:"serverInfo"`
+	}
+	if err := decodeMCPResult(initResponse, &initResult); err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+
+	var listResult struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := decodeMCPResult(listResponse, &listResult); err != nil {
+		return nil, fmt.Errorf("tools/list: %w", err)
+	}
+	// An agent always lists its tools; no list means no answer to
+	// tools/list, not an agent without tools
+	if listResult.Tools == nil {
+		return nil, fmt.Errorf("tools/list: response has no tools")
+	}
+
+	info := &AgentInfo{
+		Version: initResult.ServerInfo.Version,
+		Tools:   make([]string, 0, len(listResult.Tools)),
+	}
+	if info.Version == "" {
+		info.Version = "unknown"
+	}
+	for _, tool := range listResult.Tools {
+		info.Tools = append(info.Tools, tool.Name)
+	}
+	slices.Sort(info.Tools)
+	return info, nil
+}
+
+// decodeMCPResult decodes the result of a JSON-RPC response into v.
+func decodeMCPResult(response []byte, v interface{}) error {
+	var mcpResp MCPResponse
+	if err := json.Unmarshal(response, &mcpResp); err != nil {
+		return fmt.Errorf("failed to parse MCP response: %w", err)
+	}
+	if mcpResp.Error != nil {
+		return fmt.Errorf("MCP error %d: %s",
+			mcpResp.Error.Code, mcpResp.Error.Message)
+	}
+	if err := json.Unmarshal(mcpResp.Result, v); err != nil {
+		return fmt.Errorf("failed to parse result: %w", err)
+	}
+	return nil
+}
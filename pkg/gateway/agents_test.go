@@ -0,0 +1,148 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAgentInfo(t *testing.T) {
+	initResponse := `{"jsonrpc":"2.0","id":0,"result":{"protocolVersion":"2025-06-18",` +
+		`"serverInfo":{"name":"k8s-gpu-mcp-server","version":"v0.3.0"}}}`
+	listResponse := `{"jsonrpc":"2.0","id":1,"result":{"tools":[` +
+		`{"name":"get_gpu_inventory"},{"name":"analyze_xid_errors"}]}}`
+
+	tests := []struct {
+		name        string
+		init        string
+		list        string
+		wantVersion string
+		wantTools   []string
+		wantErr     string
+	}{
+		{
+			name:        "version and sorted tools",
+			init:        initResponse,
+			list:        listResponse,
+			wantVersion: "v0.3.0",
+			wantTools:   []string{"analyze_xid_errors", "get_gpu_inventory"},
+		},
+		{
+			name:        "no server version",
+			init:        `{"jsonrpc":"2.0","id":0,"result":{}}`,
+			list:        listResponse,
+			wantVersion: "unknown",
+			wantTools:   []string{"analyze_xid_errors", "get_gpu_inventory"},
+		},
+		{
+			name:    "initialize error",
+			init:    `{"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"nope"}}`,
+			list:    listResponse,
+			wantErr: "initialize: MCP error -32601",
+		},
+		{
+			name:    "not a tools/list response",
+			init:    initResponse,
+			list:    `{"jsonrpc":"2.0","id":1,"result":{"content":[]}}`,
+			wantErr: "response has no tools",
+		},
+		{
+			name:    "malformed",
+			init:    initResponse,
+			list:    `not json`,
+			wantErr: "tools/list: failed to parse MCP response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := parseAgentInfo([]byte(tt.init), []byte(tt.list))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, info.Version)
+			assert.Equal(t, tt.wantTools, info.Tools)
+			assert.True(t, info.HasTool("get_gpu_inventory"))
+			assert.False(t, info.HasTool("get_nvlink_status"))
+		})
+	}
+}
+
+func TestAgentCatalog(t *testing.T) {
+	now := time.Now()
+	catalog := NewAgentCatalog(time.Minute)
+	catalog.now = func() time.Time { return now }
+
+	node := k8s.GPUNode{Name: "node-a", PodName: "gpu-agent-1"}
+	_, known := catalog.lookup(node)
+	assert.False(t, known, "never discovered")
+
+	catalog.store(node, &AgentInfo{Version: "v0.3.0", PodName: node.PodName})
+	info, known := catalog.lookup(node)
+	require.True(t, known)
+	assert.Equal(t, "v0.3.0", info.Version)
+	assert.Equal(t, "v0.3.0", catalog.Version("node-a"))
+	assert.Contains(t, catalog.Snapshot(), "node-a")
+
+	// A new pod on the node, e.g. after an upgrade, is discovered again
+	_, known = catalog.lookup(k8s.GPUNode{Name: "node-a", PodName: "gpu-agent-2"})
+	assert.False(t, known)
+
+	now = now.Add(time.Minute)
+	_, known = catalog.lookup(node)
+	assert.False(t, known, "expired")
+
+	// Failed discoveries are remembered, but retried sooner
+	catalog.store(node, nil)
+	info, known = catalog.lookup(node)
+	assert.True(t, known)
+	assert.Nil(t, info)
+	assert.Empty(t, catalog.Version("node-a"))
+	now = now.Add(agentInfoRetry)
+	_, known = catalog.lookup(node)
+	assert.False(t, known)
+}
+
+func TestRouterRouteToAllNodes_UnsupportedTool(t *testing.T) {
+	cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+	catalog := NewAgentCatalog(time.Hour)
+	router := NewRouter(readyAgentsClient(t, "node-a"),
+		WithCircuitBreaker(cb), WithAgentCatalog(catalog))
+
+	// The agent runs a version without get_nvlink_status
+	catalog.store(k8s.GPUNode{Name: "node-a", PodName: "gpu-agent-node-a"},
+		&AgentInfo{Version: "v0.1.0", Tools: []string{"get_gpu_inventory"}})
+
+	request, err := BuildHTTPToolRequest("get_nvlink_status", nil)
+	require.NoError(t, err)
+
+	results, err := router.RouteToAllNodes(context.Background(), request)
+	assert.Error(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, NodeStatusUnsupported, results[0].Status)
+	assert.Equal(t, "v0.1.0", results[0].AgentVersion)
+	assert.Equal(t,
+		"agent v0.1.0 on node node-a does not provide get_nvlink_status",
+		results[0].Error)
+	assert.Empty(t, unreachedNodes(results))
+
+	// The agent was not contacted and its node is not unhealthy
+	assert.Equal(t, 0, cb.Failures("node-a"))
+	assert.Equal(t, CircuitClosed, cb.State("node-a"))
+
+	_, err = router.RouteToNode(context.Background(), "node-a", request)
+	var unsupported *UnsupportedToolError
+	require.True(t, errors.As(err, &unsupported))
+	assert.Equal(t, "get_nvlink_status", unsupported.Tool)
+}
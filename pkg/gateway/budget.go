@@ -0,0 +1,69 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"fmt"
+	"time"
+)
+
+// ArgTimeoutSeconds is the tool argument overriding the deadline budget of
+// a call. It is consumed by the gateway and not forwarded to agents.
+const ArgTimeoutSeconds = "timeout_seconds"
+
+const (
+	// DefaultCallBudget is how long a fan-out waits for node agents,
+	// retries included, when the call does not set timeout_seconds.
+	DefaultCallBudget = 45 * time.Second
+	// MaxCallBudget keeps a fan-out and its aggregation within the 90s
+	// HTTP write timeout of the gateway.
+	MaxCallBudget = 80 * time.Second
+)
+
+// ParseCallBudget reads the timeout_seconds argument of a tool call. It
+// returns def when the argument is not set.
+func ParseCallBudget(args map[string]interface{}, def time.Duration) (time.Duration, error) {
+	v, ok := args[ArgTimeoutSeconds]
+	if !ok || v == nil {
+		return def, nil
+	}
+	seconds, ok := v.(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a number of seconds", ArgTimeoutSeconds)
+	}
+	budget := time.Duration(seconds * float64(time.Second))
+	if budget <= 0 || budget > MaxCallBudget {
+		return 0, fmt.Errorf("%s must be greater than 0 and at most %.0f",
+			ArgTimeoutSeconds, MaxCallBudget.Seconds())
+	}
+	return budget, nil
+}
+
+// stripBudgetArg returns the arguments without the timeout_seconds argument.
+func stripBudgetArg(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	forwarded := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		if k == ArgTimeoutSeconds {
+			continue
+		}
+		forwarded[k] = v
+	}
+	return forwarded
+}
+
+// unreachedNodes returns the names of the nodes that timed out or were
+// skipped, in result order.
+func unreachedNodes(results []NodeResult) []string {
+	var names []string
+	for _, result := range results {
+		switch result.Status {
+		case NodeStatusTimeout, NodeStatusSkipped:
+			names = append(names, result.NodeName)
+		}
+	}
+	return names
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCallBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "default", args: nil, want: DefaultCallBudget},
+		{
+			name: "unset",
+			args: map[string]interface{}{"timeout_seconds": nil},
+			want: DefaultCallBudget,
+		},
+		{
+			name: "fractional",
+			args: map[string]interface{}{"timeout_seconds": 2.5},
+			want: 2500 * time.Millisecond,
+		},
+		{
+			name: "max",
+			args: map[string]interface{}{"timeout_seconds": 80.0},
+			want: MaxCallBudget,
+		},
+		{
+			name:    "zero",
+			args:    map[string]interface{}{"timeout_seconds": 0.0},
+			wantErr: "timeout_seconds must be greater than 0 and at most 80",
+		},
+		{
+			name:    "above max",
+			args:    map[string]interface{}{"timeout_seconds": 120.0},
+			wantErr: "timeout_seconds must be greater than 0 and at most 80",
+		},
+		{
+			name:    "string",
+			args:    map[string]interface{}{"timeout_seconds": "10"},
+			wantErr: "timeout_seconds must be a number of seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, err := ParseCallBudget(tt.args, DefaultCallBudget)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, budget)
+		})
+	}
+}
+
+func TestStripBudgetArg(t *testing.T) {
+	args := map[string]interface{}{"timeout_seconds": 5.0, "gpu_model": "A100"}
+	assert.Equal(t, map[string]interface{}{"gpu_model": "A100"},
+		stripBudgetArg(args))
+	assert.Nil(t, stripBudgetArg(nil))
+}
+
+func TestUnreachedNodes(t *testing.T) {
+	results := []NodeResult{
+		{NodeName: "a", Status: NodeStatusSuccess},
+		{NodeName: "b", Status: NodeStatusTimeout},
+		{NodeName: "c", Status: NodeStatusError},
+		{NodeName: "d", Status: NodeStatusSkipped},
+	}
+	assert.Equal(t, []string{"b", "d"}, unreachedNodes(results))
+	assert.Nil(t, unreachedNodes(results[:1]))
+}
+
+func TestWithCallBudget(t *testing.T) {
+	assert.Equal(t, DefaultCallBudget, NewRouter(nil).CallBudget())
+	assert.Equal(t, 10*time.Second,
+		NewRouter(nil, WithCallBudget(10*time.Second)).CallBudget())
+	assert.Equal(t, MaxCallBudget,
+		NewRouter(nil, WithCallBudget(5*time.Minute)).CallBudget())
+}
+
+func TestProxyHandler_Handle_CallBudget(t *testing.T) {
+	handler := NewProxyHandler(readyAgentsClient(t, "node-a"), "get_gpu_health",
+		WithCircuitBreaker(openCircuits("node-a")))
+	handler.SetResponseCache(NewResponseCache(DefaultCacheTTLs()))
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"timeout_seconds": 5.0}
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]interface{}
+	text := result.Content[0].(mcp.TextContent).Text
+	require.NoError(t, json.Unmarshal([]byte(text), &response))
+	assert.Equal(t, []interface{}{"node-a"}, response["unreached_nodes"])
+	node := response["nodes"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, NodeStatusSkipped, node["status"])
+
+	// Incomplete responses are not cached
+	result, err = handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	text = result.Content[0].(mcp.TextContent).Text
+	require.NoError(t, json.Unmarshal([]byte(text), &response))
+	assert.Equal(t, false, response["cache"].(map[string]interface{})["hit"])
+
+	request.Params.Arguments = map[string]interface{}{"timeout_seconds": 600.0}
+	result, err = handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
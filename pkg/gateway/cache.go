@@ -0,0 +1,358 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/metrics"
+	"k8s.io/klog/v2"
+)
+
+// Tool arguments that control response caching. They are consumed by the
+// gateway and not forwarded to agents.
+const (
+	ArgMaxAge  = "max_age"
+	ArgNoCache = "no_cache"
+)
+
+// Cache results reported in metrics.
+const (
+	cacheHit    = "hit"
+	cacheMiss   = "miss"
+	cacheShared = "shared"
+)
+
+// DefaultCacheTTLs returns how long the aggregated response of each proxied
+// tool is reused. Tools without an entry, such as list_gpu_processes whose
+// output changes from second to second, are never cached.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"get_gpu_inventory":  30 * time.Second,
+		"get_nvlink_status":  30 * time.Second,
+		"get_gpu_health":     10 * time.Second,
+		"analyze_xid_errors": 10 * time.Second,
+	}
+}
+
+// CacheControl holds the freshness requirements of a single call.
+type CacheControl struct {
+	// NoCache forces a new fan-out to the agents
+	NoCache bool
+	// MaxAge, when set, is the oldest cached response the caller accepts
+	MaxAge *time.Duration
+	// Budget is the deadline budget of the call. Only calls with the same
+	// budget share a fan-out, so that none gets a response cut short by a
+	// shorter budget, or waits longer, than it asked for.
+	Budget time.Duration
+}
+
+// ParseCacheControl reads the max_age (seconds) and no_cache arguments of a
+// tool call.
+func ParseCacheControl(args map[string]interface{}) (CacheControl, error) {
+	var cc CacheControl
+	if v, ok := args[ArgNoCache]; ok && v != nil {
+		noCache, ok := v.(bool)
+		if !ok {
+			return cc, fmt.Errorf("%s must be a boolean", ArgNoCache)
+		}
+		cc.NoCache = noCache
+	}
+	if v, ok := args[ArgMaxAge]; ok && v != nil {
+		seconds, ok := v.(float64)
+		if !ok {
+			return cc, fmt.Errorf("%s must be a number of seconds", ArgMaxAge)
+		}
+		if seconds < 0 {
+			return cc, fmt.Errorf("%s must not be negative", ArgMaxAge)
+		}
+		maxAge := time.Duration(seconds * float64(time.Second))
+		cc.MaxAge = &maxAge
+	}
+	return cc, nil
+}
+
+// accepts reports whether a cached response of the given age may be served.
+func (cc CacheControl) accepts(age time.Duration) bool {
+	if cc.NoCache {
+		return false
+	}
+	return cc.MaxAge == nil || age <= *cc.MaxAge
+}
+
+// CacheInfo describes where a response came from.
+type CacheInfo struct {
+	// Hit is true when the response was served from the cache
+	Hit bool `json:"hit"`
+	// Shared is true when the call joined a fan-out already in flight
+	Shared bool `json:"shared,omitempty"`
+	// FetchedAt is when the agents were queried
+	FetchedAt time.Time `json:"fetched_at"`
+	// AgeSeconds is the age of the data at response time
+	AgeSeconds float64 `json:"age_seconds"`
+}
+
+// ResponseCache stores aggregated gateway responses per tool and arguments,
+// and collapses concurrent identical calls into a single fan-out.
+type ResponseCache struct {
+	ttls map[string]time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	flights map[string]*flight
+}
+
+// cacheEntry is a stored response.
+type cacheEntry struct {
+	value     interface{}
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// flight is a fan-out in progress, shared by every caller waiting for it.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	// progressMu guards the progress callbacks of the waiters, keyed by
+	// join order, and the last progress reported
+	progressMu   sync.Mutex
+	progress     map[int]ProgressFunc
+	nextWaiter   int
+	lastProgress *NodeProgress
+
+	value     interface{}
+	fetchedAt time.Time
+	err       error
+}
+
+// addProgress makes the fan-out report progress to fn, starting with the
+// progress so far, and returns the id that removeProgress takes.
+func (f *flight) addProgress(fn ProgressFunc) int {
+	f.progressMu.Lock()
+	id := f.nextWaiter
+	f.nextWaiter++
+	f.progress[id] = fn
+	last := f.lastProgress
+	f.progressMu.Unlock()
+
+	if last != nil {
+		fn(*last)
+	}
+	return id
+}
+
+// removeProgress stops reporting progress to a waiter that left.
+func (f *flight) removeProgress(id int) {
+	f.progressMu.Lock()
+	defer f.progressMu.Unlock()
+	delete(f.progress, id)
+}
+
+// reportProgress is the ProgressFunc of the fetch: it passes progress on to
+// every waiter.
+func (f *flight) reportProgress(p NodeProgress) {
+	f.progressMu.Lock()
+	f.lastProgress = &p
+	fns := slices.Collect(maps.Values(f.progress))
+	f.progressMu.Unlock()
+
+	for _, fn := range fns {
+		fn(p)
+	}
+}
+
+// NewResponseCache creates a cache with the given per-tool TTLs.
+func NewResponseCache(ttls map[string]time.Duration) *ResponseCache {
+	return &ResponseCache{
+		ttls:    ttls,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+		flights: make(map[string]*flight),
+	}
+}
+
+// Get returns the response for a tool call, from the cache when cc allows
+// it, or by calling fetch. Concurrent misses for the same key and budget
+// wait for one fetch, and each receives its progress on the ProgressFunc
+// of its own ctx. The fetch keeps running while any caller is waiting, and
+// is cancelled once all of them have gone. Tools without a TTL call fetch
+// directly.
+func (c *ResponseCache) Get(
+	ctx context.Context,
+	toolName string,
+	args map[string]interface{},
+	cc CacheControl,
+	fetch func(context.Context) (interface{}, error),
+) (interface{}, *CacheInfo, error) {
+	ttl := c.ttls[toolName]
+	if ttl <= 0 {
+		value, err := fetch(ctx)
+		return value, nil, err
+	}
+
+	key, err := cacheKey(toolName, args)
+	if err != nil {
+		value, err := fetch(ctx)
+		return value, nil, err
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	if entry, ok := c.entries[key]; ok && now.Before(entry.expiresAt) &&
+		cc.accepts(now.Sub(entry.fetchedAt)) {
+		c.mu.Unlock()
+		metrics.RecordCacheRequest(toolName, cacheHit)
+		return entry.value, c.info(true, false, entry.fetchedAt), nil
+	}
+
+	flightKey := fmt.Sprintf("%s\x00%s", key, cc.Budget)
+	f, shared := c.flights[flightKey]
+	if !shared {
+		// Detached from the caller so other waiters are not cut off
+		// when it leaves; cancelled when the last waiter leaves
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{
+			done:     make(chan struct{}),
+			cancel:   cancel,
+			progress: make(map[int]ProgressFunc),
+		}
+		fetchCtx = WithProgress(fetchCtx, f.reportProgress)
+		c.flights[flightKey] = f
+		go c.run(fetchCtx, toolName, key, flightKey, ttl, f, fetch)
+	}
+	f.waiters++
+	c.mu.Unlock()
+
+	waiter := f.addProgress(progressFromContext(ctx))
+	defer f.removeProgress(waiter)
+
+	result := cacheMiss
+	if shared {
+		result = cacheShared
+	}
+	metrics.RecordCacheRequest(toolName, result)
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return nil, nil, f.err
+		}
+		return f.value, c.info(false, shared, f.fetchedAt), nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// A later call starts a new fetch instead of joining one
+			// that is being cancelled
+			f.cancel()
+			if c.flights[flightKey] == f {
+				delete(c.flights, flightKey)
+			}
+		}
+		c.mu.Unlock()
+		return nil, nil, ctx.Err()
+	}
+}
+
+// run performs a fetch and stores its result under key unless it failed.
+func (c *ResponseCache) run(
+	ctx context.Context,
+	toolName, key, flightKey string,
+	ttl time.Duration,
+	f *flight,
+	fetch func(context.Context) (interface{}, error),
+) {
+	defer f.cancel()
+	value, err := fetch(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f.value, f.err, f.fetchedAt = value, err, c.now()
+	if c.flights[flightKey] == f {
+		delete(c.flights, flightKey)
+	}
+	if err == nil && ctx.Err() == nil && cacheable(value) {
+		c.pruneLocked(f.fetchedAt)
+		c.entries[key] = cacheEntry{
+			value:     value,
+			fetchedAt: f.fetchedAt,
+			expiresAt: f.fetchedAt.Add(ttl),
+		}
+		klog.V(4).InfoS("response cached", "tool", toolName, "ttl", ttl)
+	}
+	close(f.done)
+}
+
+// info builds the CacheInfo of a response.
+func (c *ResponseCache) info(hit, shared bool, fetchedAt time.Time) *CacheInfo {
+	return &CacheInfo{
+		Hit:        hit,
+		Shared:     shared,
+		FetchedAt:  fetchedAt.UTC(),
+		AgeSeconds: c.now().Sub(fetchedAt).Seconds(),
+	}
+}
+
+// pruneLocked drops expired entries. c.mu must be held.
+func (c *ResponseCache) pruneLocked(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cacheable reports whether a response is worth storing. Responses where
+// every node failed, or that some nodes did not answer within the deadline
+// budget, are not, so the next call retries the agents.
+func cacheable(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, incomplete := m["unreached_nodes"]
+	return m["status"] != "error" && !incomplete
+}
+
+// cacheKey identifies a call by tool name and normalized arguments. Cache
+// control arguments and unset values are ignored; encoding/json sorts map
+// keys, so argument order does not matter.
+func cacheKey(toolName string, args map[string]interface{}) (string, error) {
+	normalized := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		if v == nil || k == ArgMaxAge || k == ArgNoCache {
+			continue
+		}
+		normalized[k] = v
+	}
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	return toolName + "\x00" + string(data), nil
+}
+
+// stripCacheArgs returns the arguments without the cache control arguments.
+func stripCacheArgs(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	forwarded := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		if k == ArgMaxAge || k == ArgNoCache {
+			continue
+		}
+		forwarded[k] = v
+	}
+	return forwarded
+}
@@ -0,0 +1,485 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCache returns a cache with a 10s TTL for "get_gpu_inventory" and a
+// clock advanced by the returned function.
+func testCache() (*ResponseCache, func(time.Duration)) {
+	cache := NewResponseCache(map[string]time.Duration{
+		"get_gpu_inventory": 10 * time.Second,
+	})
+	var mu sync.Mutex
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	return cache, func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+}
+
+// countingFetch returns a fetch function counting its calls.
+func countingFetch(calls *atomic.Int32) func(context.Context) (interface{}, error) {
+	return func(context.Context) (interface{}, error) {
+		n := calls.Add(1)
+		return map[string]interface{}{"status": "success", "fetch": n}, nil
+	}
+}
+
+func TestParseCacheControl(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		wantNoCache bool
+		wantMaxAge  *time.Duration
+		wantErr     string
+	}{
+		{name: "none", args: map[string]interface{}{"node_name": "a"}},
+		{
+			name:        "no_cache",
+			args:        map[string]interface{}{"no_cache": true},
+			wantNoCache: true,
+		},
+		{
+			name:       "max_age",
+			args:       map[string]interface{}{"max_age": 2.5},
+			wantMaxAge: durationPtr(2500 * time.Millisecond),
+		},
+		{
+			name:    "negative max_age",
+			args:    map[string]interface{}{"max_age": -1.0},
+			wantErr: "max_age must not be negative",
+		},
+		{
+			name:    "string max_age",
+			args:    map[string]interface{}{"max_age": "5"},
+			wantErr: "max_age must be a number of seconds",
+		},
+		{
+			name:    "string no_cache",
+			args:    map[string]interface{}{"no_cache": "yes"},
+			wantErr: "no_cache must be a boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc, err := ParseCacheControl(tt.args)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNoCache, cc.NoCache)
+			assert.Equal(t, tt.wantMaxAge, cc.MaxAge)
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	a, err := cacheKey("get_gpu_health", map[string]interface{}{
+		"node_selector": "zone=a", "gpu_model": "A100",
+		"max_age": 5.0, "no_cache": true, "node_name": nil,
+	})
+	require.NoError(t, err)
+	b, err := cacheKey("get_gpu_health", map[string]interface{}{
+		"gpu_model": "A100", "node_selector": "zone=a",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := cacheKey("get_gpu_inventory", map[string]interface{}{
+		"gpu_model": "A100", "node_selector": "zone=a",
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	d, err := cacheKey("get_gpu_health", map[string]interface{}{
+		"gpu_model": "H100", "node_selector": "zone=a",
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, d)
+}
+
+func TestResponseCache_Get(t *testing.T) {
+	ctx := context.Background()
+	cache, advance := testCache()
+	var calls atomic.Int32
+	fetch := countingFetch(&calls)
+	args := map[string]interface{}{"include_k8s_metadata": true}
+
+	value, info, err := cache.Get(ctx, "get_gpu_inventory", args,
+		CacheControl{}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), value.(map[string]interface{})["fetch"])
+	assert.False(t, info.Hit)
+
+	t.Run("hit within TTL", func(t *testing.T) {
+		advance(4 * time.Second)
+		value, info, err := cache.Get(ctx, "get_gpu_inventory", args,
+			CacheControl{}, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), value.(map[string]interface{})["fetch"])
+		assert.True(t, info.Hit)
+		assert.Equal(t, 4.0, info.AgeSeconds)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("max_age older than entry", func(t *testing.T) {
+		maxAge := 3 * time.Second
+		value, info, err := cache.Get(ctx, "get_gpu_inventory", args,
+			CacheControl{MaxAge: &maxAge}, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), value.(map[string]interface{})["fetch"])
+		assert.False(t, info.Hit)
+		assert.Zero(t, info.AgeSeconds)
+	})
+
+	t.Run("no_cache", func(t *testing.T) {
+		value, _, err := cache.Get(ctx, "get_gpu_inventory", args,
+			CacheControl{NoCache: true}, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), value.(map[string]interface{})["fetch"])
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		advance(10 * time.Second)
+		value, info, err := cache.Get(ctx, "get_gpu_inventory", args,
+			CacheControl{}, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), value.(map[string]interface{})["fetch"])
+		assert.False(t, info.Hit)
+	})
+
+	t.Run("tool without TTL", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, info, err := cache.Get(ctx, "list_gpu_processes", args,
+				CacheControl{}, fetch)
+			require.NoError(t, err)
+			assert.Nil(t, info)
+		}
+		assert.Equal(t, int32(6), calls.Load())
+	})
+}
+
+func TestResponseCache_FailuresNotCached(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := testCache()
+
+	_, _, err := cache.Get(ctx, "get_gpu_inventory", nil, CacheControl{},
+		func(context.Context) (interface{}, error) {
+			return nil, errors.New("failed to route to nodes: boom")
+		})
+	assert.EqualError(t, err, "failed to route to nodes: boom")
+
+	_, _, err = cache.Get(ctx, "get_gpu_inventory", nil, CacheControl{},
+		func(context.Context) (interface{}, error) {
+			return map[string]interface{}{"status": "error"}, nil
+		})
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	_, info, err := cache.Get(ctx, "get_gpu_inventory", nil, CacheControl{},
+		countingFetch(&calls))
+	require.NoError(t, err)
+	assert.False(t, info.Hit)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestResponseCache_CoalescesConcurrentCalls(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := testCache()
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	fetch := func(context.Context) (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return map[string]interface{}{"status": "success"}, nil
+	}
+
+	const callers = 5
+	infos := make(chan *CacheInfo, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, info, err := cache.Get(ctx, "get_gpu_inventory", nil,
+				CacheControl{NoCache: true}, fetch)
+			assert.NoError(t, err)
+			infos <- info
+		}()
+	}
+
+	// Wait until every caller joined the flight
+	require.Eventually(t, func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		for _, f := range cache.flights {
+			return f.waiters == callers
+		}
+		return false
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(infos)
+
+	assert.Equal(t, int32(1), calls.Load())
+	shared := 0
+	for info := range infos {
+		if info.Shared {
+			shared++
+		}
+	}
+	assert.Equal(t, callers-1, shared)
+}
+
+func TestResponseCache_BudgetsDoNotShareFanOut(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := testCache()
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	fetch := func(context.Context) (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return map[string]interface{}{"status": "success"}, nil
+	}
+
+	budgets := []time.Duration{time.Second, 80 * time.Second, 80 * time.Second}
+	infos := make(chan *CacheInfo, len(budgets))
+	var wg sync.WaitGroup
+	for _, budget := range budgets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, info, err := cache.Get(ctx, "get_gpu_inventory", nil,
+				CacheControl{NoCache: true, Budget: budget}, fetch)
+			assert.NoError(t, err)
+			infos <- info
+		}()
+	}
+
+	// A caller with a longer budget does not join the 1s fan-out
+	require.Eventually(t, func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		waiters := 0
+		for _, f := range cache.flights {
+			waiters += f.waiters
+		}
+		return len(cache.flights) == 2 && waiters == len(budgets)
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(infos)
+
+	assert.Equal(t, int32(2), calls.Load())
+	shared := 0
+	for info := range infos {
+		if info.Shared {
+			shared++
+		}
+	}
+	assert.Equal(t, 1, shared)
+}
+
+func TestResponseCache_CancelledWhenAllCallersLeave(t *testing.T) {
+	cache, _ := testCache()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	fetchDone := make(chan error, 1)
+	fetch := func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		fetchDone <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, _, err := cache.Get(ctx, "get_gpu_inventory", nil, CacheControl{}, fetch)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case err := <-fetchDone:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("fetch was not cancelled")
+	}
+}
+
+func TestResponseCache_ProgressReachesEveryCaller(t *testing.T) {
+	cache, _ := testCache()
+
+	step := make(chan NodeProgress)
+	fetch := func(ctx context.Context) (interface{}, error) {
+		report := progressFromContext(ctx)
+		for p := range step {
+			report(p)
+		}
+		return map[string]interface{}{"status": "success"}, nil
+	}
+
+	// caller makes a call that records the progress it receives
+	type caller struct {
+		cancel   context.CancelFunc
+		progress chan NodeProgress
+		done     chan error
+	}
+	call := func() *caller {
+		c := &caller{
+			progress: make(chan NodeProgress, 10),
+			done:     make(chan error, 1),
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		c.cancel = cancel
+		ctx = WithProgress(ctx, func(p NodeProgress) { c.progress <- p })
+		go func() {
+			_, _, err := cache.Get(ctx, "get_gpu_inventory", nil,
+				CacheControl{}, fetch)
+			c.done <- err
+		}()
+		return c
+	}
+	waiters := func(n int) func() bool {
+		return func() bool {
+			cache.mu.Lock()
+			defer cache.mu.Unlock()
+			for _, f := range cache.flights {
+				return f.waiters == n
+			}
+			return false
+		}
+	}
+	received := func(c *caller) NodeProgress {
+		select {
+		case p := <-c.progress:
+			return p
+		case <-time.After(time.Second):
+			t.Fatal("no progress")
+			return NodeProgress{}
+		}
+	}
+
+	first := call()
+	require.Eventually(t, waiters(1), time.Second, time.Millisecond)
+	step <- NodeProgress{Total: 3, Completed: 1}
+	assert.Equal(t, 1, received(first).Completed)
+
+	// A caller joining the fan-out starts with the progress so far
+	second := call()
+	require.Eventually(t, waiters(2), time.Second, time.Millisecond)
+	assert.Equal(t, 1, received(second).Completed)
+
+	step <- NodeProgress{Total: 3, Completed: 2}
+	assert.Equal(t, 2, received(first).Completed)
+	assert.Equal(t, 2, received(second).Completed)
+
+	// Progress goes on to the callers left when the first one leaves
+	first.cancel()
+	assert.ErrorIs(t, <-first.done, context.Canceled)
+	step <- NodeProgress{Total: 3, Completed: 3}
+	assert.Equal(t, 3, received(second).Completed)
+	assert.Empty(t, first.progress)
+
+	close(step)
+	assert.NoError(t, <-second.done)
+	second.cancel()
+}
+
+func TestResponseCache_CallAfterLastCallerLeft(t *testing.T) {
+	cache, _ := testCache()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The cancelled fetch is slow to return, as a fan-out still waiting
+	// for node results is
+	hold := make(chan struct{})
+	defer close(hold)
+	var calls atomic.Int32
+	fetch := func(ctx context.Context) (interface{}, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			<-hold
+			return map[string]interface{}{"status": "error"}, nil
+		}
+		return map[string]interface{}{"status": "success"}, nil
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, _, err := cache.Get(ctx, "get_gpu_inventory", nil, CacheControl{}, fetch)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// A new call does not join the cancelled fetch
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	value, info, err := cache.Get(ctx, "get_gpu_inventory", nil,
+		CacheControl{}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "success", value.(map[string]interface{})["status"])
+	assert.False(t, info.Shared)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestProxyHandler_Handle_ResponseCache(t *testing.T) {
+	handler := NewProxyHandler(targetingClient(t), "get_gpu_health")
+	handler.SetResponseCache(NewResponseCache(DefaultCacheTTLs()))
+
+	call := func(args map[string]interface{}) map[string]interface{} {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := handler.Handle(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response map[string]interface{}
+		text := result.Content[0].(mcp.TextContent).Text
+		require.NoError(t, json.Unmarshal([]byte(text), &response))
+		return response
+	}
+
+	// Every node is filtered out, so the response is a cacheable success
+	first := call(map[string]interface{}{"gpu_model": "H100"})
+	assert.Equal(t, false, first["cache"].(map[string]interface{})["hit"])
+
+	second := call(map[string]interface{}{"gpu_model": "H100", "max_age": 60.0})
+	assert.Equal(t, true, second["cache"].(map[string]interface{})["hit"])
+	assert.Equal(t, first["filtered_nodes"], second["filtered_nodes"])
+
+	fresh := call(map[string]interface{}{"gpu_model": "H100", "no_cache": true})
+	assert.Equal(t, false, fresh["cache"].(map[string]interface{})["hit"])
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"max_age": -5.0}
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
@@ -4,8 +4,11 @@
 package gateway
 
 import (
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/metrics"
 )
 
 // CircuitState represents the state of a circuit breaker.
@@ -20,25 +23,64 @@ const (
 	CircuitHalfOpen
 )
 
+// windowBuckets is the number of buckets the sliding window is split into.
+// Outcomes expire one bucket (Window/windowBuckets) at a time.
+const windowBuckets = 10
+
 // CircuitBreaker tracks node health and prevents requests to failing nodes.
+// A circuit opens when the share of failed requests to a node within a
+// sliding window reaches FailureRate. After ResetTimeout it lets exactly one
+// probe request through; the probe's outcome closes or re-opens it.
 type CircuitBreaker struct {
-	mu            sync.RWMutex
-	failures      map[string]int
-	lastFailure   map[string]time.Time
-	state         map[string]CircuitState
-	threshold     int
-	resetTimeout  time.Duration
-	onStateChange MetricsCallback
+	mu    sync.Mutex
+	nodes map[string]*nodeCircuit
+	cfg   CircuitBreakerConfig
+	now   func() time.Time
+}
+
+// nodeCircuit is the breaker state of one node.
+type nodeCircuit struct {
+	state        CircuitState
+	window       [windowBuckets]windowBucket
+	openedAt     time.Time
+	probing      bool
+	probeStarted time.Time
+
+	lastError   string
+	lastErrorAt time.Time
+	lastSuccess time.Time
+	lastRoute   string
+}
+
+// windowBucket counts the outcomes within one slice of the window.
+type windowBucket struct {
+	epoch     int64
+	successes int
+	failures  int
 }
 
 // MetricsCallback is called when circuit breaker state changes.
 // Parameters: node name, circuit state (0=closed, 1=open, 2=half-open), healthy
 type MetricsCallback func(node string, state int, healthy bool)
 
-// CircuitBreakerConfig configures the circuit breaker behavior.
+// RecordCircuitMetrics is a MetricsCallback exporting circuit state and node
+// health to Prometheus.
+func RecordCircuitMetrics(node string, state int, healthy bool) {
+	metrics.SetCircuitState(node, state)
+	metrics.SetNodeHealth(node, healthy)
+}
+
+// CircuitBreakerConfig configures the circuit breaker behavior. Zero fields
+// take their value from DefaultCircuitBreakerConfig.
 type CircuitBreakerConfig struct {
-	// Threshold is the number of failures before opening the circuit.
-	Threshold int
+	// FailureRate is the share of failed requests (0-1] within Window that
+	// opens the circuit.
+	FailureRate float64
+	// MinRequests is the number of requests within Window needed before
+	// the failure rate is evaluated.
+	MinRequests int
+	// Window is the length of the sliding window.
+	Window time.Duration
 	// ResetTimeout is how long to wait before trying a half-open request.
 	ResetTimeout time.Duration
 	// OnStateChange is called when circuit state changes (optional).
@@ -48,43 +90,69 @@ type CircuitBreakerConfig struct {
 // DefaultCircuitBreakerConfig returns sensible defaults.
 func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
 	return CircuitBreakerConfig{
-		Threshold:    3,
+		FailureRate:  0.5,
+		MinRequests:  3,
+		Window:       60 * time.Second,
 		ResetTimeout: 30 * time.Second,
 	}
 }
 
 // NewCircuitBreaker creates a new circuit breaker.
 func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	defaults := DefaultCircuitBreakerConfig()
+	if cfg.FailureRate <= 0 || cfg.FailureRate > 1 {
+		cfg.FailureRate = defaults.FailureRate
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = defaults.MinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaults.Window
+	}
+	if cfg.ResetTimeout <= 0 {
+		cfg.ResetTimeout = defaults.ResetTimeout
+	}
 	return &CircuitBreaker{
-		failures:      make(map[string]int),
-		lastFailure:   make(map[string]time.Time),
-		state:         make(map[string]CircuitState),
-		threshold:     cfg.Threshold,
-		resetTimeout:  cfg.ResetTimeout,
-		onStateChange: cfg.OnStateChange,
+		nodes: make(map[string]*nodeCircuit),
+		cfg:   cfg,
+		now:   time.Now,
 	}
 }
 
+// Config returns the effective configuration of the breaker.
+func (cb *CircuitBreaker) Config() CircuitBreakerConfig {
+	return cb.cfg
+}
+
 // Allow checks if a request to the given node should be allowed.
-// Returns true if the circuit is closed or half-open.
+// Returns true if the circuit is closed, or if the request is the single
+// probe of a half-open circuit. A caller that was allowed through must
+// report the outcome with RecordSuccess, RecordFailure or Abandon.
 func (cb *CircuitBreaker) Allow(node string) bool {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	state := cb.state[node]
+	n := cb.node(node)
+	now := cb.now()
 
-	switch state {
+	switch n.state {
 	case CircuitOpen:
 		// Check if reset timeout has passed
-		if time.Since(cb.lastFailure[node]) > cb.resetTimeout {
-			cb.state[node] = CircuitHalfOpen
-			cb.notifyStateChange(node, CircuitHalfOpen, false)
-			return true
+		if now.Sub(n.openedAt) < cb.cfg.ResetTimeout {
+			return false
 		}
-		return false
+		n.state = CircuitHalfOpen
+		cb.notifyStateChange(node, CircuitHalfOpen, false)
+		n.probing, n.probeStarted = true, now
+		return true
 
 	case CircuitHalfOpen:
-		// Allow one test request
+		// One probe at a time; a probe that never reported back is
+		// given up after ResetTimeout
+		if n.probing && now.Sub(n.probeStarted) < cb.cfg.ResetTimeout {
+			return false
+		}
+		n.probing, n.probeStarted = true, now
 		return true
 
 	default: // CircuitClosed
@@ -92,61 +160,195 @@ func (cb *CircuitBreaker) Allow(node string) bool {
 	}
 }
 
-// RecordSuccess records a successful request to a node.
-// Resets the failure count and closes the circuit.
-func (cb *CircuitBreaker) RecordSuccess(node string) {
+// RecordSuccess records a successful request to a node and the routing
+// path it took. A success while the circuit is not closed closes it and
+// clears the window.
+func (cb *CircuitBreaker) RecordSuccess(node, route string) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	cb.failures[node] = 0
-	cb.state[node] = CircuitClosed
-	cb.notifyStateChange(node, CircuitClosed, true)
+	n := cb.node(node)
+	now := cb.now()
+	n.lastSuccess, n.lastRoute = now, route
+
+	if n.state != CircuitClosed {
+		n.state = CircuitClosed
+		n.probing = false
+		n.window = [windowBuckets]windowBucket{}
+		cb.notifyStateChange(node, CircuitClosed, true)
+	}
+	cb.bucket(n, now).successes++
 }
 
-// RecordFailure records a failed request to a node.
-// Opens the circuit if threshold is reached.
-func (cb *CircuitBreaker) RecordFailure(node string) {
+// RecordFailure records a failed request to a node, the routing path it
+// took and its error. Opens the circuit when the failure rate within the
+// window reaches the threshold, or when a half-open probe fails.
+func (cb *CircuitBreaker) RecordFailure(node, route string, err error) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	cb.failures[node]++
-	cb.lastFailure[node] = time.Now()
+	n := cb.node(node)
+	now := cb.now()
+	n.lastErrorAt, n.lastRoute = now, route
+	if err != nil {
+		n.lastError = err.Error()
+	}
+	cb.bucket(n, now).failures++
+
+	switch n.state {
+	case CircuitHalfOpen:
+		cb.open(node, n, now)
+	case CircuitClosed:
+		requests, failures := cb.counts(n, now)
+		if requests >= cb.cfg.MinRequests &&
+			float64(failures) >= cb.cfg.FailureRate*float64(requests) {
+			cb.open(node, n, now)
+		}
+	}
+}
+
+// Abandon releases a request allowed through without an outcome, such as
+// one cancelled by the client, so that a half-open circuit can send
+// another probe.
+func (cb *CircuitBreaker) Abandon(node string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 
-	if cb.failures[node] >= cb.threshold {
-		cb.state[node] = CircuitOpen
-		cb.notifyStateChange(node, CircuitOpen, false)
+	if n, ok := cb.nodes[node]; ok && n.state == CircuitHalfOpen {
+		n.probing = false
 	}
 }
 
+// open opens the circuit of a node. cb.mu must be held.
+func (cb *CircuitBreaker) open(node string, n *nodeCircuit, now time.Time) {
+	n.state = CircuitOpen
+	n.openedAt = now
+	n.probing = false
+	cb.notifyStateChange(node, CircuitOpen, false)
+}
+
+// node returns the state of a node, creating it. cb.mu must be held.
+func (cb *CircuitBreaker) node(node string) *nodeCircuit {
+	n, ok := cb.nodes[node]
+	if !ok {
+		n = &nodeCircuit{}
+		cb.nodes[node] = n
+	}
+	return n
+}
+
+// epoch returns the index of the window bucket containing t.
+func (cb *CircuitBreaker) epoch(t time.Time) int64 {
+	width := int64(cb.cfg.Window / windowBuckets)
+	if width <= 0 {
+		width = 1
+	}
+	return t.UnixNano() / width
+}
+
+// bucket returns the bucket of n for now, recycling an expired one.
+// cb.mu must be held.
+func (cb *CircuitBreaker) bucket(n *nodeCircuit, now time.Time) *windowBucket {
+	epoch := cb.epoch(now)
+	b := &n.window[epoch%windowBuckets]
+	if b.epoch != epoch {
+		*b = windowBucket{epoch: epoch}
+	}
+	return b
+}
+
+// counts returns the requests and failures of n within the window.
+// cb.mu must be held.
+func (cb *CircuitBreaker) counts(n *nodeCircuit, now time.Time) (requests, failures int) {
+	epoch := cb.epoch(now)
+	for _, b := range n.window {
+		if epoch-b.epoch < windowBuckets {
+			requests += b.successes + b.failures
+			failures += b.failures
+		}
+	}
+	return requests, failures
+}
+
 // notifyStateChange calls the metrics callback if configured.
 func (cb *CircuitBreaker) notifyStateChange(node string, state CircuitState, healthy bool) {
-	if cb.onStateChange != nil {
-		cb.onStateChange(node, int(state), healthy)
+	if cb.cfg.OnStateChange != nil {
+		cb.cfg.OnStateChange(node, int(state), healthy)
 	}
 }
 
 // State returns the current state for a node.
 func (cb *CircuitBreaker) State(node string) CircuitState {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
-	return cb.state[node]
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if n, ok := cb.nodes[node]; ok {
+		return n.state
+	}
+	return CircuitClosed
 }
 
-// Failures returns the current failure count for a node.
+// Failures returns the number of failed requests to a node within the
+// window.
 func (cb *CircuitBreaker) Failures(node string) int {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
-	return cb.failures[node]
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	n, ok := cb.nodes[node]
+	if !ok {
+		return 0
+	}
+	_, failures := cb.counts(n, cb.now())
+	return failures
 }
 
 // Reset resets the circuit breaker state for a node.
 func (cb *CircuitBreaker) Reset(node string) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
+	delete(cb.nodes, node)
+}
+
+// NodeCircuit is a snapshot of the breaker state of one node.
+type NodeCircuit struct {
+	Node     string
+	State    CircuitState
+	Requests int
+	Failures int
+	// OpenedAt is when the circuit last opened
+	OpenedAt time.Time
+	// LastError is the error of the last failed request
+	LastError     string
+	LastErrorAt   time.Time
+	LastSuccessAt time.Time
+	// LastRoute is the routing path of the last request
+	LastRoute string
+}
 
-	delete(cb.failures, node)
-	delete(cb.lastFailure, node)
-	delete(cb.state, node)
+// Snapshot returns the state of every node the breaker has seen, sorted by
+// node name.
+func (cb *CircuitBreaker) Snapshot() []NodeCircuit {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	snapshot := make([]NodeCircuit, 0, len(cb.nodes))
+	for node, n := range cb.nodes {
+		requests, failures := cb.counts(n, now)
+		snapshot = append(snapshot, NodeCircuit{
+			Node:          node,
+			State:         n.state,
+			Requests:      requests,
+			Failures:      failures,
+			OpenedAt:      n.openedAt,
+			LastError:     n.lastError,
+			LastErrorAt:   n.lastErrorAt,
+			LastSuccessAt: n.lastSuccess,
+			LastRoute:     n.lastRoute,
+		})
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Node < snapshot[j].Node
+	})
+	return snapshot
 }
 
 // String returns a string representation of the circuit state.
@@ -4,12 +4,25 @@
 package gateway
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+var errAgentDown = errors.New("agent down")
+
+// testBreaker returns a breaker on a fake clock advanced by the returned
+// function.
+func testBreaker(cfg CircuitBreakerConfig) (*CircuitBreaker, func(time.Duration)) {
+	cb := NewCircuitBreaker(cfg)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb.now = func() time.Time { return now }
+	return cb, func(d time.Duration) { now = now.Add(d) }
+}
+
 func TestCircuitBreaker_Allow_ClosedCircuit(t *testing.T) {
 	cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
 
@@ -20,33 +33,33 @@ func TestCircuitBreaker_Allow_ClosedCircuit(t *testing.T) {
 
 func TestCircuitBreaker_RecordFailure_OpensCircuit(t *testing.T) {
 	cfg := DefaultCircuitBreakerConfig()
-	cfg.Threshold = 3
+	cfg.MinRequests = 3
 	cb := NewCircuitBreaker(cfg)
 
 	// Record failures below threshold
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.True(t, cb.Allow("node-1"))
 	assert.Equal(t, CircuitClosed, cb.State("node-1"))
 
 	// Record failure at threshold - circuit opens
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.False(t, cb.Allow("node-1"))
 	assert.Equal(t, CircuitOpen, cb.State("node-1"))
 }
 
 func TestCircuitBreaker_RecordSuccess_ClosesCircuit(t *testing.T) {
 	cfg := DefaultCircuitBreakerConfig()
-	cfg.Threshold = 2
+	cfg.MinRequests = 2
 	cb := NewCircuitBreaker(cfg)
 
 	// Open the circuit
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.False(t, cb.Allow("node-1"))
 
 	// Success resets the circuit
-	cb.RecordSuccess("node-1")
+	cb.RecordSuccess("node-1", RoutePodIP)
 	assert.True(t, cb.Allow("node-1"))
 	assert.Equal(t, CircuitClosed, cb.State("node-1"))
 	assert.Equal(t, 0, cb.Failures("node-1"))
@@ -54,14 +67,14 @@ func TestCircuitBreaker_RecordSuccess_ClosesCircuit(t *testing.T) {
 
 func TestCircuitBreaker_HalfOpen_AfterTimeout(t *testing.T) {
 	cfg := CircuitBreakerConfig{
-		Threshold:    2,
+		MinRequests:  2,
 		ResetTimeout: 50 * time.Millisecond,
 	}
 	cb := NewCircuitBreaker(cfg)
 
 	// Open the circuit
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.False(t, cb.Allow("node-1"))
 	assert.Equal(t, CircuitOpen, cb.State("node-1"))
 
@@ -75,30 +88,30 @@ func TestCircuitBreaker_HalfOpen_AfterTimeout(t *testing.T) {
 
 func TestCircuitBreaker_MultipleNodes(t *testing.T) {
 	cfg := DefaultCircuitBreakerConfig()
-	cfg.Threshold = 2
+	cfg.MinRequests = 2
 	cb := NewCircuitBreaker(cfg)
 
 	// Open circuit for node-1
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.False(t, cb.Allow("node-1"))
 
 	// node-2 should still be allowed
 	assert.True(t, cb.Allow("node-2"))
 
 	// Success on node-2
-	cb.RecordSuccess("node-2")
+	cb.RecordSuccess("node-2", RoutePodIP)
 	assert.True(t, cb.Allow("node-2"))
 }
 
 func TestCircuitBreaker_Reset(t *testing.T) {
 	cfg := DefaultCircuitBreakerConfig()
-	cfg.Threshold = 2
+	cfg.MinRequests = 2
 	cb := NewCircuitBreaker(cfg)
 
 	// Open the circuit
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.False(t, cb.Allow("node-1"))
 
 	// Reset should clear state
@@ -117,20 +130,32 @@ func TestCircuitState_String(t *testing.T) {
 func TestDefaultCircuitBreakerConfig(t *testing.T) {
 	cfg := DefaultCircuitBreakerConfig()
 
-	assert.Equal(t, 3, cfg.Threshold)
+	assert.Equal(t, 0.5, cfg.FailureRate)
+	assert.Equal(t, 3, cfg.MinRequests)
+	assert.Equal(t, 60*time.Second, cfg.Window)
+	assert.Equal(t, 30*time.Second, cfg.ResetTimeout)
+}
+
+func TestNewCircuitBreaker_FillsDefaults(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 10, FailureRate: 2})
+
+	cfg := cb.Config()
+	assert.Equal(t, 10, cfg.MinRequests)
+	assert.Equal(t, 0.5, cfg.FailureRate)
+	assert.Equal(t, 60*time.Second, cfg.Window)
 	assert.Equal(t, 30*time.Second, cfg.ResetTimeout)
 }
 
 func TestCircuitBreaker_HalfOpen_FailureReopensCircuit(t *testing.T) {
 	cfg := CircuitBreakerConfig{
-		Threshold:    2,
+		MinRequests:  2,
 		ResetTimeout: 50 * time.Millisecond,
 	}
 	cb := NewCircuitBreaker(cfg)
 
 	// Open the circuit
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.Equal(t, CircuitOpen, cb.State("node-1"))
 
 	// Wait for reset timeout to transition to half-open
@@ -139,20 +164,20 @@ func TestCircuitBreaker_HalfOpen_FailureReopensCircuit(t *testing.T) {
 	assert.Equal(t, CircuitHalfOpen, cb.State("node-1"))
 
 	// Another failure while half-open should re-open the circuit
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.Equal(t, CircuitOpen, cb.State("node-1"))
 }
 
 func TestCircuitBreaker_HalfOpen_SuccessClosesCircuit(t *testing.T) {
 	cfg := CircuitBreakerConfig{
-		Threshold:    2,
+		MinRequests:  2,
 		ResetTimeout: 50 * time.Millisecond,
 	}
 	cb := NewCircuitBreaker(cfg)
 
 	// Open the circuit
-	cb.RecordFailure("node-1")
-	cb.RecordFailure("node-1")
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
 	assert.Equal(t, CircuitOpen, cb.State("node-1"))
 
 	// Wait for reset timeout to transition to half-open
@@ -161,7 +186,96 @@ func TestCircuitBreaker_HalfOpen_SuccessClosesCircuit(t *testing.T) {
 	assert.Equal(t, CircuitHalfOpen, cb.State("node-1"))
 
 	// Success while half-open should close the circuit
-	cb.RecordSuccess("node-1")
+	cb.RecordSuccess("node-1", RoutePodIP)
 	assert.Equal(t, CircuitClosed, cb.State("node-1"))
 	assert.Equal(t, 0, cb.Failures("node-1"))
 }
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	cb, _ := testBreaker(CircuitBreakerConfig{FailureRate: 0.5, MinRequests: 4})
+
+	// 1 failure in 4 requests stays below the rate
+	cb.RecordSuccess("node-1", RoutePodIP)
+	cb.RecordSuccess("node-1", RoutePodIP)
+	cb.RecordSuccess("node-1", RoutePodIP)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	assert.Equal(t, CircuitClosed, cb.State("node-1"))
+
+	// Intermittent failures add up, unlike a consecutive count
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordSuccess("node-1", RoutePodIP)
+	assert.Equal(t, CircuitClosed, cb.State("node-1"))
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	assert.Equal(t, CircuitOpen, cb.State("node-1"))
+}
+
+func TestCircuitBreaker_WindowExpires(t *testing.T) {
+	cb, advance := testBreaker(CircuitBreakerConfig{
+		FailureRate: 0.5,
+		MinRequests: 3,
+		Window:      10 * time.Second,
+	})
+
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	assert.Equal(t, 2, cb.Failures("node-1"))
+
+	// Old failures leave the window and no longer count
+	advance(11 * time.Second)
+	assert.Equal(t, 0, cb.Failures("node-1"))
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	assert.Equal(t, CircuitClosed, cb.State("node-1"))
+}
+
+func TestCircuitBreaker_HalfOpen_SingleProbe(t *testing.T) {
+	cb, advance := testBreaker(CircuitBreakerConfig{
+		MinRequests:  1,
+		ResetTimeout: 10 * time.Second,
+	})
+	cb.RecordFailure("node-1", RoutePodIP, errAgentDown)
+	require.Equal(t, CircuitOpen, cb.State("node-1"))
+
+	advance(11 * time.Second)
+	assert.True(t, cb.Allow("node-1"), "first request is the probe")
+	assert.False(t, cb.Allow("node-1"), "second request waits for the probe")
+
+	// An abandoned probe lets another one through
+	cb.Abandon("node-1")
+	assert.True(t, cb.Allow("node-1"))
+	assert.False(t, cb.Allow("node-1"))
+
+	// A probe that never reports back is given up after ResetTimeout
+	advance(11 * time.Second)
+	assert.True(t, cb.Allow("node-1"))
+
+	cb.RecordSuccess("node-1", RouteDNS)
+	assert.Equal(t, CircuitClosed, cb.State("node-1"))
+	assert.True(t, cb.Allow("node-1"))
+	assert.True(t, cb.Allow("node-1"))
+}
+
+func TestCircuitBreaker_Snapshot(t *testing.T) {
+	cb, advance := testBreaker(DefaultCircuitBreakerConfig())
+	start := cb.now()
+
+	cb.RecordSuccess("node-b", RoutePodIP)
+	advance(time.Second)
+	cb.RecordFailure("node-b", RouteDNS, errAgentDown)
+	cb.RecordFailure("node-a", RouteExec, nil)
+
+	snapshot := cb.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, "node-a", snapshot[0].Node)
+	assert.Empty(t, snapshot[0].LastError)
+
+	b := snapshot[1]
+	assert.Equal(t, "node-b", b.Node)
+	assert.Equal(t, CircuitClosed, b.State)
+	assert.Equal(t, 2, b.Requests)
+	assert.Equal(t, 1, b.Failures)
+	assert.Equal(t, "agent down", b.LastError)
+	assert.Equal(t, start.Add(time.Second), b.LastErrorAt)
+	assert.Equal(t, start, b.LastSuccessAt)
+	assert.Equal(t, RouteDNS, b.LastRoute)
+}
@@ -77,8 +77,23 @@ func BuildMCPRequest(toolName string, arguments interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("toolName is required")
 	}
 
-	// Build initialize request
-	initReq := MCPRequest{
+	// Build tool call request
+	toolReq := MCPRequest{
+		JSONRPC: "2.0",
+		Method:  "tools/call",
+		Params: MCPToolCallParams{
+			Name:      toolName,
+			Arguments: arguments,
+		},
+		ID: 1,
+	}
+
+	return frameAfterInitialize(toolReq)
+}
+
+// initializeRequest returns the initialize request sent by the gateway.
+func initializeRequest() MCPRequest {
+	return MCPRequest{
 		JSONRPC: "2.0",
 		Method:  "initialize",
 		Params: MCPInitializeParams{
@@ -91,40 +106,79 @@ func BuildMCPRequest(toolName string, arguments interface{}) ([]byte, error) {
 		},
 		ID: 0,
 	}
+}
 
-	// Build tool call request
-	toolReq := MCPRequest{
-		JSONRPC: "2.0",
-		Method:  "tools/call",
-		Params: MCPToolCallParams{
-			Name:      toolName,
-			Arguments: arguments,
-		},
-		ID: 1,
-	}
-
+// frameAfterInitialize returns the newline-delimited initialize request
+// followed by req, for stdio agents.
+func frameAfterInitialize(req MCPRequest) ([]byte, error) {
 	// Marshal both requests
-	initBytes, err := json.Marshal(initReq)
+	initBytes, err := json.Marshal(initializeRequest())
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal initialize request: %w", err)
 	}
 
-	toolBytes, err := json.Marshal(toolReq)
+	reqBytes, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal tool request: %w", err)
+		return nil, fmt.Errorf("failed to marshal %s request: %w", req.Method, err)
 	}
 
 	// Concatenate with newlines (stdio protocol requires line-delimited JSON)
-	// Format: <init>\n<tool>\n
+	// Format: <init>\n<request>\n
 	var buf bytes.Buffer
 	buf.Write(initBytes)
 	buf.WriteByte('\n')
-	buf.Write(toolBytes)
+	buf.Write(reqBytes)
 	buf.WriteByte('\n')
 
 	return buf.Bytes(), nil
 }
 
+// BuildMCPToolsListRequest creates a newline-delimited initialize and
+// tools/list request for stdio agents. The initialize response carries the
+// agent version.
+func BuildMCPToolsListRequest() ([]byte, error) {
+	return frameAfterInitialize(MCPRequest{
+		JSONRPC: "2.0",
+		Method:  "tools/list",
+		ID:      1,
+	})
+}
+
+// BuildHTTPInitializeRequest creates an initialize request for HTTP mode
+// agents, used to learn the agent version.
+func BuildHTTPInitializeRequest() ([]byte, error) {
+	return json.Marshal(initializeRequest())
+}
+
+// BuildHTTPToolsListRequest creates a tools/list request for HTTP mode
+// agents.
+func BuildHTTPToolsListRequest() ([]byte, error) {
+	return json.Marshal(MCPRequest{
+		JSONRPC: "2.0",
+		Method:  "tools/list",
+		ID:      1,
+	})
+}
+
+// toolCallName returns the tool named by the tools/call request in an HTTP
+// or stdio framed request, or "" if there is none.
+func toolCallName(request []byte) string {
+	objects := SplitJSONObjects(request)
+	for i := len(objects) - 1; i >= 0; i-- {
+		var req struct {
+			Method string            `json:"method"`
+			Params MCPToolCallParams `json:"params"`
+		}
+		if err := json.Unmarshal(objects[i], &req); err != nil {
+			continue
+		}
+		if req.Method == "tools/call" {
+			return req.Params.Name
+		}
+	}
+	return ""
+}
+
 // ParseStdioResponse extracts the tool result from a multi-line MCP response.
 // The response typically contains:
 //  1. initialize response (ignored)
@@ -414,6 +414,50 @@ func TestBuildHTTPToolRequest_SingleObject(t *testing.T) {
 	}
 }
 
+func TestBuildMCPToolsListRequest(t *testing.T) {
+	data, err := BuildMCPToolsListRequest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	var req MCPRequest
+	if err := json.Unmarshal([]byte(lines[1]), &req); err != nil {
+		t.Fatalf("failed to parse request: %v", err)
+	}
+	if req.Method != "tools/list" {
+		t.Errorf("expected method 'tools/list', got %s", req.Method)
+	}
+}
+
+func TestToolCallName(t *testing.T) {
+	stdio, _ := BuildMCPRequest("get_gpu_health", nil)
+	http, _ := BuildHTTPToolRequest("get_nvlink_status", nil)
+	list, _ := BuildMCPToolsListRequest()
+
+	tests := []struct {
+		name    string
+		request []byte
+		want    string
+	}{
+		{name: "stdio", request: stdio, want: "get_gpu_health"},
+		{name: "http", request: http, want: "get_nvlink_status"},
+		{name: "not a tool call", request: list, want: ""},
+		{name: "empty", request: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toolCallName(tt.request); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestParseHTTPResponse_ValidToolResult(t *testing.T) {
 	response := `{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"{\"status\":\"ok\",\"count\":2}"}]}}`
 
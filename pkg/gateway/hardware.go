@@ -0,0 +1,99 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/tools"
+	"k8s.io/klog/v2"
+)
+
+// describeToolName is the agent tool queried for GPU hardware details.
+const describeToolName = "describe_gpu_node"
+
+// Compile-time interface satisfaction check.
+var _ tools.GPUHardwareSource = (*HardwareProxy)(nil)
+
+// HardwareProxy reads GPU hardware details from the agent running on a
+// node. The gateway has no NVML access, so describe_gpu_node uses it to fill
+// in the driver and GPU sections next to the Kubernetes data it reads itself.
+type HardwareProxy struct {
+	router *Router
+}
+
+// NewHardwareProxy creates a hardware source that routes to node agents.
+func NewHardwareProxy(k8sClient *k8s.Client, opts ...RouterOption) *HardwareProxy {
+	return &HardwareProxy{router: NewRouter(k8sClient, opts...)}
+}
+
+// DescribeGPUHardware calls describe_gpu_node on the agent of nodeName and
+// returns the driver and GPUs it reports.
+func (h *HardwareProxy) DescribeGPUHardware(
+	ctx context.Context,
+	nodeName string,
+) (tools.DriverInfo, []tools.GPUDescription, error) {
+	args := map[string]interface{}{"node_name": nodeName}
+
+	var mcpRequest []byte
+	var err error
+	if h.router.RoutingMode() == RoutingModeHTTP {
+		mcpRequest, err = BuildHTTPToolRequest(describeToolName, args)
+	} else {
+		mcpRequest, err = BuildMCPRequest(describeToolName, args)
+	}
+	if err != nil {
+		return tools.DriverInfo{}, nil,
+			fmt.Errorf("failed to build request: %w", err)
+	}
+
+	response, err := h.router.RouteToNode(ctx, nodeName, mcpRequest)
+	if err != nil {
+		return tools.DriverInfo{}, nil, err
+	}
+
+	driver, gpus, err := decodeHardware(response, h.router.RoutingMode())
+	if err != nil {
+		return tools.DriverInfo{}, nil,
+			fmt.Errorf("invalid agent response from node %s: %w", nodeName, err)
+	}
+
+	klog.V(4).InfoS("agent hardware received", "node", nodeName, "gpus", len(gpus))
+	return driver, gpus, nil
+}
+
+// decodeHardware extracts the driver and GPU sections of an agent's
+// describe_gpu_node response.
+func decodeHardware(
+	response []byte,
+	mode RoutingMode,
+) (tools.DriverInfo, []tools.GPUDescription, error) {
+	var data interface{}
+	var err error
+	if mode == RoutingModeHTTP {
+		data, err = ParseHTTPResponse(response)
+	} else {
+		data, err = ParseStdioResponse(response)
+	}
+	if err != nil {
+		return tools.DriverInfo{}, nil, err
+	}
+	if _, ok := data.(map[string]interface{}); !ok {
+		return tools.DriverInfo{}, nil, fmt.Errorf("unexpected result %T", data)
+	}
+
+	// Round-trip through JSON to decode into the tool's types
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return tools.DriverInfo{}, nil, err
+	}
+	var description tools.GPUNodeDescription
+	if err := json.Unmarshal(raw, &description); err != nil {
+		return tools.DriverInfo{}, nil, err
+	}
+	return description.Driver, description.GPUs, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDecodeHardware(t *testing.T) {
+	description := map[string]interface{}{
+		"status": "partial",
+		"node":   map[string]interface{}{"name": "gpu-node-1"},
+		"driver": map[string]interface{}{
+			"version":      "575.57.08",
+			"cuda_version": "12.9",
+		},
+		"gpus": []interface{}{
+			map[string]interface{}{
+				"index": 0, "name": "NVIDIA A100-SXM4-80GB", "uuid": "GPU-0",
+				"health_score": 95, "temperature": 45, "utilization": 80,
+				"memory_used_percent": 50,
+			},
+		},
+		"pods": []interface{}{},
+	}
+
+	t.Run("stdio response", func(t *testing.T) {
+		driver, gpus, err := decodeHardware(
+			toolTextResponse(t, description), RoutingModeExec)
+		require.NoError(t, err)
+		assert.Equal(t, "575.57.08", driver.Version)
+		assert.Equal(t, "12.9", driver.CudaVersion)
+		require.Len(t, gpus, 1)
+		assert.Equal(t, "GPU-0", gpus[0].UUID)
+		assert.Equal(t, 95, gpus[0].HealthScore)
+		assert.Equal(t, uint32(45), gpus[0].Temperature)
+	})
+
+	t.Run("tool error", func(t *testing.T) {
+		response := []byte(`{"jsonrpc":"2.0","id":1,"result":{"isError":true,` +
+			`"content":[{"type":"text","text":"NVML not initialized"}]}}`)
+		_, _, err := decodeHardware(response, RoutingModeHTTP)
+		assert.ErrorContains(t, err, "NVML not initialized")
+	})
+
+	t.Run("non-object result", func(t *testing.T) {
+		response := []byte(`{"jsonrpc":"2.0","id":1,"result":{` +
+			`"content":[{"type":"text","text":"plain text"}]}}`)
+		_, _, err := decodeHardware(response, RoutingModeHTTP)
+		assert.ErrorContains(t, err, "unexpected result")
+	})
+}
+
+func TestHardwareProxy_AgentUnavailable(t *testing.T) {
+	//nolint:staticcheck // NewSimpleClientset is used for testing without apply config
+	clientset := fake.NewSimpleClientset()
+	k8sClient := k8s.NewClientWithConfig(clientset, nil, "gpu-diagnostics")
+	proxy := NewHardwareProxy(k8sClient)
+
+	_, _, err := proxy.DescribeGPUHardware(context.Background(), "missing-node")
+	assert.ErrorContains(t, err, "node not found")
+}
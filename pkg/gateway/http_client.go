@@ -6,6 +6,7 @@ package gateway
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,6 +18,16 @@ import (
 // DefaultAgentHTTPPort is the default port agents listen on in HTTP mode.
 const DefaultAgentHTTPPort = 8080
 
+// minAttemptTimeout is the shortest share of the deadline budget an attempt
+// is cut off at. A shorter budget goes to the attempt whole: a healthy
+// agent may need that long, so timing out says nothing about it.
+const minAttemptTimeout = time.Second
+
+// errAttemptTimeout marks an attempt cut off at its share of the deadline
+// budget while budget was left, i.e. an agent that did not answer in time.
+var errAttemptTimeout = errors.New(
+	"agent did not answer within its share of the deadline budget")
+
 // AgentHTTPClient handles HTTP communication with agent pods.
 type AgentHTTPClient struct {
 	client      *http.Client
@@ -56,6 +67,10 @@ func NewAgentHTTPClient() *AgentHTTPClient {
 
 // CallMCP sends an MCP request to an agent pod and returns the response.
 // The endpoint should be the full URL (e.g., "http://10.0.0.5:8080").
+// When ctx has a deadline, the time left is split evenly between the
+// remaining attempts so that one hung attempt cannot use up the budget.
+// If the budget runs out after an attempt was cut off at its share, the
+// error wraps errAttemptTimeout as well as the ctx error.
 func (c *AgentHTTPClient) CallMCP(
 	ctx context.Context,
 	endpoint string,
@@ -63,7 +78,7 @@ func (c *AgentHTTPClient) CallMCP(
 ) ([]byte, error) {
 	url := endpoint + "/mcp"
 
-	var lastErr error
+	var lastErr, timedOut error
 	for attempt := 0; attempt <= c.retryPolicy.MaxRetries; attempt++ {
 		if attempt > 0 {
 			delay := c.calculateBackoff(attempt)
@@ -72,20 +87,24 @@ func (c *AgentHTTPClient) CallMCP(
 
 			select {
 			case <-ctx.Done():
-				return nil, ctx.Err()
+				return nil, contextError(ctx, timedOut)
 			case <-time.After(delay):
 			}
 		}
 
-		response, err := c.doRequest(ctx, url, request)
+		response, err := c.doAttempt(ctx, url, request,
+			c.retryPolicy.MaxRetries+1-attempt)
 		if err == nil {
 			return response, nil
 		}
 		lastErr = err
+		if errors.Is(err, errAttemptTimeout) {
+			timedOut = err
+		}
 
 		// Don't retry on context cancellation
 		if ctx.Err() != nil {
-			return nil, ctx.Err()
+			return nil, contextError(ctx, timedOut)
 		}
 	}
 
@@ -93,6 +112,43 @@ func (c *AgentHTTPClient) CallMCP(
 		c.retryPolicy.MaxRetries+1, lastErr)
 }
 
+// contextError returns the error of a done ctx, wrapping the error of an
+// attempt cut off at its share of the budget, if any.
+func contextError(ctx context.Context, timedOut error) error {
+	if timedOut != nil {
+		return fmt.Errorf("%w after %w", ctx.Err(), timedOut)
+	}
+	return ctx.Err()
+}
+
+// doAttempt performs one attempt out of attemptsLeft, bounded by its share
+// of the time left before the ctx deadline, unless that share is below
+// minAttemptTimeout. An attempt cut off at its share returns an error
+// wrapping errAttemptTimeout.
+func (c *AgentHTTPClient) doAttempt(
+	ctx context.Context,
+	url string,
+	body []byte,
+	attemptsLeft int,
+) ([]byte, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok || attemptsLeft <= 1 {
+		return c.doRequest(ctx, url, body)
+	}
+	share := time.Until(deadline) / time.Duration(attemptsLeft)
+	if share < minAttemptTimeout {
+		return c.doRequest(ctx, url, body)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, share)
+	defer cancel()
+	response, err := c.doRequest(attemptCtx, url, body)
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+		return nil, fmt.Errorf("%w: %w", errAttemptTimeout, err)
+	}
+	return response, err
+}
+
 // doRequest performs a single HTTP request.
 func (c *AgentHTTPClient) doRequest(
 	ctx context.Context,
@@ -7,6 +7,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -76,6 +77,85 @@ func TestAgentHTTPClient_CallMCP_ContextCancellation(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAgentHTTPClient_CallMCP_SplitsDeadlineAcrossAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				// First attempt hangs until the test ends
+				<-release
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"success":true}`))
+		}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewAgentHTTPClient()
+	client.retryPolicy.BaseDelay = 1 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := client.CallMCP(ctx, server.URL, []byte(`{}`))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(resp), "success")
+	assert.Equal(t, int32(2), attempts.Load())
+	// 4 attempts share the budget, so the hung one is cut at ~1.25s
+	assert.Less(t, time.Since(start), 2500*time.Millisecond)
+}
+
+func TestAgentHTTPClient_CallMCP_ShortBudgetNotSplit(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"success":true}`))
+		}))
+	defer server.Close()
+
+	client := NewAgentHTTPClient()
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	// A quarter of the budget would cut the attempt off; it gets it whole
+	resp, err := client.CallMCP(ctx, server.URL, []byte(`{}`))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(resp), "success")
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestAgentHTTPClient_CallMCP_AttemptTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewAgentHTTPClient()
+	client.retryPolicy.MaxRetries = 1
+	client.retryPolicy.BaseDelay = 1 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := client.CallMCP(ctx, server.URL, []byte(`{}`))
+
+	// The first attempt was cut off at its share; the budget ran out on
+	// the second
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, errAttemptTimeout)
+}
+
 func TestAgentHTTPClient_CallMCP_AllRetriesFail(t *testing.T) {
 	server := httptest.NewServer(
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
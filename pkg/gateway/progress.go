@@ -0,0 +1,86 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+)
+
+// methodProgress is the MCP progress notification method.
+const methodProgress = "notifications/progress"
+
+// NodeProgress is the state of a fan-out, reported after each node result.
+type NodeProgress struct {
+	// Total is the number of nodes contacted
+	Total int
+	// Completed is the number of nodes that answered successfully
+	Completed int
+	// Failed is the number of nodes that failed or were skipped
+	Failed int
+}
+
+// Remaining returns the number of nodes still pending.
+func (p NodeProgress) Remaining() int {
+	return p.Total - p.Completed - p.Failed
+}
+
+// ProgressFunc receives fan-out progress. It is called from the goroutine
+// collecting results, so it must not block for long.
+type ProgressFunc func(NodeProgress)
+
+// progressKeyType is the context key type for progress callbacks.
+type progressKeyType struct{}
+
+var progressKey = progressKeyType{}
+
+// WithProgress returns a context whose fan-outs report progress to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey, fn)
+}
+
+// progressFromContext returns the progress callback of ctx, or a no-op.
+func progressFromContext(ctx context.Context) ProgressFunc {
+	if fn, ok := ctx.Value(progressKey).(ProgressFunc); ok && fn != nil {
+		return fn
+	}
+	return func(NodeProgress) {}
+}
+
+// clientProgress returns a ProgressFunc that sends MCP progress
+// notifications for request, or nil if the client did not send a progress
+// token. Notifications stop once ctx is done.
+func clientProgress(ctx context.Context, request mcp.CallToolRequest) ProgressFunc {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return nil
+	}
+	token := request.Params.Meta.ProgressToken
+
+	return func(p NodeProgress) {
+		if ctx.Err() != nil {
+			return
+		}
+		err := mcpServer.SendNotificationToClient(ctx, methodProgress,
+			map[string]any{
+				"progressToken": token,
+				"progress":      p.Completed + p.Failed,
+				"total":         p.Total,
+				"message": fmt.Sprintf(
+					"%d of %d nodes completed, %d failed, %d remaining",
+					p.Completed, p.Total, p.Failed, p.Remaining()),
+			})
+		if err != nil {
+			klog.V(4).InfoS("failed to send progress notification",
+				"error", err)
+		}
+	}
+}
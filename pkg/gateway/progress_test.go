@@ -0,0 +1,65 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is an MCP client session buffering notifications.
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) SessionID() string { return "test-session" }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestProxyHandler_Handle_ProgressNotifications(t *testing.T) {
+	nodes := []string{"node-a", "node-b"}
+	handler := NewProxyHandler(readyAgentsClient(t, nodes...), "get_gpu_health",
+		WithCircuitBreaker(openCircuits(nodes...)))
+
+	mcpServer := server.NewMCPServer("test", "v0.0.0")
+	mcpServer.AddTool(mcp.NewTool("get_gpu_health"), handler.Handle)
+
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := mcpServer.WithContext(context.Background(), session)
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{` +
+		`"name":"get_gpu_health","_meta":{"progressToken":"tok-1"}}}`
+	mcpServer.HandleMessage(ctx, []byte(request))
+	close(session.notifications)
+
+	var messages []string
+	for n := range session.notifications {
+		assert.Equal(t, methodProgress, n.Method)
+		fields := n.Params.AdditionalFields
+		assert.Equal(t, "tok-1", fields["progressToken"])
+		assert.Equal(t, 2, fields["total"])
+		messages = append(messages, fields["message"].(string))
+	}
+	assert.Equal(t, []string{
+		"0 of 2 nodes completed, 0 failed, 2 remaining",
+		"0 of 2 nodes completed, 1 failed, 1 remaining",
+		"0 of 2 nodes completed, 2 failed, 0 remaining",
+	}, messages)
+}
+
+func TestClientProgress_NoToken(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "v0.0.0")
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 1)}
+	ctx := mcpServer.WithContext(context.Background(), session)
+
+	require.Nil(t, clientProgress(ctx, mcp.CallToolRequest{}))
+}
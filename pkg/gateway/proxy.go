@@ -7,10 +7,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/tools"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -20,6 +24,7 @@ import (
 type ProxyHandler struct {
 	router   *Router
 	toolName string
+	cache    *ResponseCache
 }
 
 // NewProxyHandler creates a handler that proxies a specific tool to agents.
@@ -34,7 +39,21 @@ func NewProxyHandler(
 	}
 }
 
-// Handle proxies the tool call to all node agents and aggregates results.
+// SetResponseCache makes the handler reuse recent aggregated responses
+// from cache, which may be shared between handlers.
+func (p *ProxyHandler) SetResponseCache(cache *ResponseCache) {
+	p.cache = cache
+}
+
+// Handle proxies the tool call to the node agents and aggregates results.
+// The node_name, node_selector and gpu_model arguments restrict the call to
+// matching nodes; the nodes they exclude are listed in filtered_nodes.
+// With a response cache, max_age and no_cache bound the age of the data
+// and the response reports it under "cache". Clients that send a progress
+// token receive a progress notification as each node answers.
+// timeout_seconds overrides the deadline budget of the fan-out; nodes that
+// have not answered when it runs out are reported as timed out or skipped
+// and listed in unreached_nodes.
 func (p *ProxyHandler) Handle(
 	ctx context.Context,
 	request mcp.CallToolRequest,
@@ -59,16 +78,32 @@ func (p *ProxyHandler) Handle(
 		}
 	}
 
+	// Targeting, cache control and budget arguments are not forwarded to
+	// agents
+	target, err := ParseNodeTarget(request.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	cacheControl, err := ParseCacheControl(request.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	budget, err := ParseCallBudget(request.GetArguments(), p.router.CallBudget())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	cacheControl.Budget = budget
+	callArgs := stripBudgetArg(stripCacheArgs(request.GetArguments()))
+	args := stripTargetArgs(callArgs)
+
 	var mcpRequest []byte
-	var err error
 
 	if p.router.RoutingMode() == RoutingModeHTTP {
 		// HTTP mode: Build single tool call request (no init needed)
-		mcpRequest, err = BuildHTTPToolRequest(
-			p.toolName, request.GetArguments())
+		mcpRequest, err = BuildHTTPToolRequest(p.toolName, args)
 	} else {
 		// Exec mode: Build init + tool framing for oneshot agents
-		mcpRequest, err = BuildMCPRequest(p.toolName, request.GetArguments())
+		mcpRequest, err = BuildMCPRequest(p.toolName, args)
 	}
 
 	if err != nil {
@@ -77,15 +112,59 @@ func (p *ProxyHandler) Handle(
 			fmt.Sprintf("failed to build request: %v", err)), nil
 	}
 
-	// Route to all nodes
-	results, err := p.router.RouteToAllNodes(ctx, mcpRequest)
+	// Report per-node progress if the client sent a progress token
+	if progress := clientProgress(ctx, request); progress != nil {
+		ctx = WithProgress(ctx, progress)
+	}
+
+	fetch := func(ctx context.Context) (interface{}, error) {
+		// Only the agent calls are bounded by the budget, so that the
+		// results gathered so far can still be aggregated
+		routeCtx, cancel := context.WithTimeout(ctx, budget)
+		defer cancel()
+
+		// Route to the targeted nodes (all nodes without targeting arguments)
+		// When every node failed, the per-node statuses are still reported
+		results, filtered, err := p.router.RouteToTargetNodes(routeCtx, target, mcpRequest)
+		if err != nil && len(results) == 0 {
+			return nil, fmt.Errorf("failed to route to nodes: %w", err)
+		}
+
+		// Aggregate results (parsing differs by mode)
+		aggregated := p.aggregateResults(ctx, results, includeK8sMetadata)
+		if m, ok := aggregated.(map[string]interface{}); ok {
+			if target != nil {
+				m["target"] = target.String()
+				m["filtered_nodes"] = filtered
+			}
+			if unreached := unreachedNodes(results); len(unreached) > 0 {
+				m["unreached_nodes"] = unreached
+			}
+		}
+		klog.V(2).InfoS("proxy_tool fan-out completed",
+			"tool", p.toolName, "nodeCount", len(results),
+			"correlationID", correlationID)
+		return aggregated, nil
+	}
+
+	var aggregated interface{}
+	var cacheInfo *CacheInfo
+	if p.cache != nil {
+		aggregated, cacheInfo, err = p.cache.Get(ctx, p.toolName, callArgs,
+			cacheControl, fetch)
+	} else {
+		aggregated, err = fetch(ctx)
+	}
 	if err != nil {
-		return mcp.NewToolResultError(
-			fmt.Sprintf("failed to route to nodes: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Aggregate results (parsing differs by mode)
-	aggregated := p.aggregateResults(ctx, results, includeK8sMetadata)
+	if m, ok := aggregated.(map[string]interface{}); ok && cacheInfo != nil {
+		// Cached maps are shared; annotate a copy
+		annotated := maps.Clone(m)
+		annotated["cache"] = cacheInfo
+		aggregated = annotated
+	}
 
 	jsonBytes, err := json.MarshalIndent(aggregated, "", "  ")
 	if err != nil {
@@ -94,7 +173,8 @@ func (p *ProxyHandler) Handle(
 	}
 
 	klog.InfoS("proxy_tool completed",
-		"tool", p.toolName, "nodeCount", len(results), "correlationID", correlationID)
+		"tool", p.toolName, "cacheHit", cacheInfo != nil && cacheInfo.Hit,
+		"correlationID", correlationID)
 
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
@@ -110,6 +190,21 @@ func (p *ProxyHandler) aggregateResults(
 		return p.aggregateGPUInventory(ctx, results, includeK8sMetadata)
 	}
 
+	// get_nvlink_status - report degraded links across the cluster
+	if p.toolName == "get_nvlink_status" {
+		return p.aggregateNVLinkStatus(results)
+	}
+
+	// get_gpu_health - roll up scores and issues across the cluster
+	if p.toolName == "get_gpu_health" {
+		return p.aggregateGPUHealth(results)
+	}
+
+	// analyze_xid_errors - rank affected nodes and GPUs, correlate codes
+	if p.toolName == "analyze_xid_errors" {
+		return p.aggregateXIDErrors(results)
+	}
+
 	// Default aggregation for other tools
 	return p.aggregateDefault(results)
 }
@@ -133,10 +228,12 @@ func (p *ProxyHandler) aggregateDefault(results []NodeResult) interface{} {
 		}
 
 		if result.Error != "" {
+			nodeData["status"] = result.failureStatus()
 			nodeData["error"] = result.Error
 			errorCount++
 		} else {
 			parsed := parseToolResponse(result.Response)
+			nodeData["status"] = "ready"
 			nodeData["data"] = parsed
 			successCount++
 		}
@@ -167,18 +264,24 @@ func (p *ProxyHandler) aggregateGPUInventory(
 	totalGPUs := 0
 	readyNodes := 0
 	gpuTypes := make(map[string]bool)
+	agentVersions := make(map[string]int)
 	nodes := make([]interface{}, 0, len(results))
 
 	// Track cluster-level GPU resources
 	var clusterCapacity, clusterAllocatable, clusterAllocated int64
+	clusterMIG := make(map[string]*MIGResourceInfo)
 
 	for _, result := range results {
 		nodeData := map[string]interface{}{
 			"name": result.NodeName,
 		}
+		if result.AgentVersion != "" {
+			nodeData["agent_version"] = result.AgentVersion
+			agentVersions[result.AgentVersion]++
+		}
 
 		if result.Error != "" {
-			nodeData["status"] = "error"
+			nodeData["status"] = result.failureStatus()
 			nodeData["error"] = result.Error
 		} else {
 			nodeData["status"] = "ready"
@@ -231,6 +334,16 @@ func (p *ProxyHandler) aggregateGPUInventory(
 						clusterCapacity += metadata.GPUResources.Capacity
 						clusterAllocatable += metadata.GPUResources.Allocatable
 						clusterAllocated += metadata.GPUResources.Allocated
+						for profile, mig := range metadata.GPUResources.MIG {
+							total, ok := clusterMIG[profile]
+							if !ok {
+								total = &MIGResourceInfo{}
+								clusterMIG[profile] = total
+							}
+							total.Capacity += mig.Capacity
+							total.Allocatable += mig.Allocatable
+							total.Allocated += mig.Allocated
+						}
 					}
 				}
 			}
@@ -254,12 +367,22 @@ func (p *ProxyHandler) aggregateGPUInventory(
 		"gpu_types":   types,
 	}
 
+	// Agents of different versions during a rolling upgrade may report
+	// different fields
+	if len(agentVersions) > 0 {
+		clusterSummary["agent_versions"] = agentVersions
+		clusterSummary["version_skew"] = len(agentVersions) > 1
+	}
+
 	// Add GPU resource counts if K8s metadata was included
 	if includeK8sMetadata && p.router.k8sClient != nil {
 		clusterSummary["gpus_capacity"] = clusterCapacity
 		clusterSummary["gpus_allocatable"] = clusterAllocatable
 		clusterSummary["gpus_allocated"] = clusterAllocated
 		clusterSummary["gpus_available"] = clusterAllocatable - clusterAllocated
+		if len(clusterMIG) > 0 {
+			clusterSummary["mig_devices_by_profile"] = clusterMIG
+		}
 	}
 
 	return map[string]interface{}{
@@ -269,8 +392,728 @@ func (p *ProxyHandler) aggregateGPUInventory(
 	}
 }
 
+// aggregateNVLinkStatus creates a cluster-wide NVLink report. Per-node link
+// lists are dropped; only links flagged as down or degraded by the agents
+// are collected, tagged with their node and GPU.
+func (p *ProxyHandler) aggregateNVLinkStatus(results []NodeResult) interface{} {
+	var totalLinks, activeLinks, nodesWithDegraded int
+	readyNodes := 0
+	degradedLinks := make([]interface{}, 0)
+	nodes := make([]interface{}, 0, len(results))
+
+	for _, result := range results {
+		nodeData := map[string]interface{}{
+			"name": result.NodeName,
+		}
+
+		if result.Error != "" {
+			nodeData["status"] = result.failureStatus()
+			nodeData["error"] = result.Error
+			nodes = append(nodes, nodeData)
+			continue
+		}
+
+		nodeData["status"] = "ready"
+		readyNodes++
+
+		report, ok := parseToolResponse(result.Response).(map[string]interface{})
+		if !ok {
+			nodes = append(nodes, nodeData)
+			continue
+		}
+
+		nodeDegraded := 0
+		gpus, _ := report["gpus"].([]interface{})
+		for _, g := range gpus {
+			gpu, ok := g.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			links, _ := gpu["links"].([]interface{})
+			for _, l := range links {
+				link, ok := l.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				status, _ := link["status"].(string)
+				if status != "down" && status != "degraded" {
+					continue
+				}
+				entry := map[string]interface{}{
+					"node":      result.NodeName,
+					"gpu_index": gpu["index"],
+					"gpu_uuid":  gpu["uuid"],
+				}
+				for k, v := range link {
+					entry[k] = v
+				}
+				degradedLinks = append(degradedLinks, entry)
+				nodeDegraded++
+			}
+		}
+
+		if v, ok := report["link_count"].(float64); ok {
+			totalLinks += int(v)
+			nodeData["link_count"] = int(v)
+		}
+		if v, ok := report["active_links"].(float64); ok {
+			activeLinks += int(v)
+			nodeData["active_links"] = int(v)
+		}
+		nodeData["degraded_links"] = nodeDegraded
+		if nodeDegraded > 0 {
+			nodesWithDegraded++
+		}
+
+		nodes = append(nodes, nodeData)
+	}
+
+	status := "success"
+	if readyNodes == 0 && len(results) > 0 {
+		status = "error"
+	} else if readyNodes < len(results) {
+		status = "partial"
+	}
+
+	return map[string]interface{}{
+		"status": status,
+		"cluster_summary": map[string]interface{}{
+			"total_nodes":               len(results),
+			"ready_nodes":               readyNodes,
+			"total_links":               totalLinks,
+			"active_links":              activeLinks,
+			"degraded_links":            len(degradedLinks),
+			"nodes_with_degraded_links": nodesWithDegraded,
+		},
+		"degraded_links": degradedLinks,
+		"nodes":          nodes,
+	}
+}
+
+// maxWorstGPUs caps the worst_gpus list of the cluster health roll-up.
+const maxWorstGPUs = 10
+
+// healthStatusRank orders GPU health statuses from best to worst, matching
+// the "worst GPU" precedence used by the agents.
+var healthStatusRank = map[string]int{
+	"healthy":  0,
+	"warning":  1,
+	"degraded": 2,
+	"critical": 3,
+}
+
+// issueSeverityRank orders health issue severities from least to most
+// severe.
+var issueSeverityRank = map[string]int{
+	"warning":  1,
+	"critical": 2,
+}
+
+// aggregateGPUHealth creates a cluster-wide health roll-up. Per-GPU metrics
+// are dropped; the report lists the unhealthy GPUs with the lowest scores,
+// every issue grouped by component and tagged with its node and GPU, and
+// nodes ordered worst first, so the top problems come first.
+func (p *ProxyHandler) aggregateGPUHealth(results []NodeResult) interface{} {
+	readyNodes, totalGPUs := 0, 0
+	overallStatus, overallScore := "unknown", -1
+	gpusByStatus := map[string]int{
+		"healthy": 0, "warning": 0, "degraded": 0, "critical": 0,
+	}
+	nodesByStatus := map[string]int{
+		"healthy": 0, "warning": 0, "degraded": 0, "critical": 0,
+	}
+	worstGPUs := make([]map[string]interface{}, 0)
+	issuesByComponent := make(map[string][]map[string]interface{})
+	nodes := make([]map[string]interface{}, 0, len(results))
+
+	for _, result := range results {
+		nodeData := map[string]interface{}{
+			"name": result.NodeName,
+		}
+
+		if result.Error != "" {
+			nodeData["status"] = result.failureStatus()
+			nodeData["error"] = result.Error
+			nodes = append(nodes, nodeData)
+			continue
+		}
+
+		nodeData["status"] = "ready"
+		readyNodes++
+
+		report, ok := parseToolResponse(result.Response).(map[string]interface{})
+		if !ok {
+			nodes = append(nodes, nodeData)
+			continue
+		}
+
+		nodeStatus, _ := report["status"].(string)
+		nodeData["health_status"] = nodeStatus
+		if _, ok := nodesByStatus[nodeStatus]; ok {
+			nodesByStatus[nodeStatus]++
+			if healthStatusRank[nodeStatus] > healthStatusRank[overallStatus] ||
+				overallStatus == "unknown" {
+				overallStatus = nodeStatus
+			}
+		}
+		if v, ok := report["overall_score"].(float64); ok {
+			nodeData["overall_score"] = int(v)
+			if overallScore < 0 || int(v) < overallScore {
+				overallScore = int(v)
+			}
+		}
+		if v, ok := report["device_count"].(float64); ok {
+			nodeData["device_count"] = int(v)
+		}
+		if v, ok := report["recommendation"].(string); ok && v != "" {
+			nodeData["recommendation"] = v
+		}
+
+		gpus, _ := report["gpus"].([]interface{})
+		nodeIssues := 0
+		for _, g := range gpus {
+			gpu, ok := g.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			totalGPUs++
+
+			status, _ := gpu["status"].(string)
+			if _, ok := gpusByStatus[status]; ok {
+				gpusByStatus[status]++
+			}
+
+			issues, _ := gpu["issues"].([]interface{})
+			for _, i := range issues {
+				issue, ok := i.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				component, _ := issue["component"].(string)
+				entry := map[string]interface{}{
+					"node":      result.NodeName,
+					"gpu_index": gpu["index"],
+					"gpu_uuid":  gpu["uuid"],
+				}
+				for _, k := range []string{"severity", "message", "suggestion"} {
+					if v, ok := issue[k]; ok {
+						entry[k] = v
+					}
+				}
+				issuesByComponent[component] = append(
+					issuesByComponent[component], entry)
+				nodeIssues++
+			}
+
+			if status != "" && status != "healthy" {
+				worstGPUs = append(worstGPUs, map[string]interface{}{
+					"node":         result.NodeName,
+					"index":        gpu["index"],
+					"uuid":         gpu["uuid"],
+					"name":         gpu["name"],
+					"status":       status,
+					"health_score": gpu["health_score"],
+					"issue_count":  len(issues),
+				})
+			}
+		}
+		nodeData["issue_count"] = nodeIssues
+
+		nodes = append(nodes, nodeData)
+	}
+
+	// Lowest score first; ties broken by status, then node and index
+	sort.SliceStable(worstGPUs, func(i, j int) bool {
+		a, b := worstGPUs[i], worstGPUs[j]
+		as, _ := a["health_score"].(float64)
+		bs, _ := b["health_score"].(float64)
+		if as != bs {
+			return as < bs
+		}
+		ar := healthStatusRank[a["status"].(string)]
+		br := healthStatusRank[b["status"].(string)]
+		if ar != br {
+			return ar > br
+		}
+		return a["node"].(string) < b["node"].(string)
+	})
+	if len(worstGPUs) > maxWorstGPUs {
+		worstGPUs = worstGPUs[:maxWorstGPUs]
+	}
+
+	components := make([]map[string]interface{}, 0, len(issuesByComponent))
+	for component, issues := range issuesByComponent {
+		sort.SliceStable(issues, func(i, j int) bool {
+			ai, _ := issues[i]["severity"].(string)
+			bi, _ := issues[j]["severity"].(string)
+			return issueSeverityRank[ai] > issueSeverityRank[bi]
+		})
+		critical := 0
+		for _, issue := range issues {
+			if issue["severity"] == "critical" {
+				critical++
+			}
+		}
+		components = append(components, map[string]interface{}{
+			"component":      component,
+			"issue_count":    len(issues),
+			"critical_count": critical,
+			"issues":         issues,
+		})
+	}
+	// Components with the most critical issues first
+	sort.Slice(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		if a["critical_count"] != b["critical_count"] {
+			return a["critical_count"].(int) > b["critical_count"].(int)
+		}
+		if a["issue_count"] != b["issue_count"] {
+			return a["issue_count"].(int) > b["issue_count"].(int)
+		}
+		return a["component"].(string) < b["component"].(string)
+	})
+
+	// Failed nodes first, then the lowest scores
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		_, aFailed := a["error"]
+		_, bFailed := b["error"]
+		if aFailed != bFailed {
+			return aFailed
+		}
+		as, aok := a["overall_score"].(int)
+		bs, bok := b["overall_score"].(int)
+		if aok && bok && as != bs {
+			return as < bs
+		}
+		return a["name"].(string) < b["name"].(string)
+	})
+
+	status := "success"
+	if readyNodes == 0 && len(results) > 0 {
+		status = "error"
+	} else if readyNodes < len(results) {
+		status = "partial"
+	}
+
+	clusterSummary := map[string]interface{}{
+		"total_nodes":     len(results),
+		"ready_nodes":     readyNodes,
+		"total_gpus":      totalGPUs,
+		"overall_status":  overallStatus,
+		"gpus_by_status":  gpusByStatus,
+		"nodes_by_status": nodesByStatus,
+	}
+	if overallScore >= 0 {
+		clusterSummary["overall_score"] = overallScore
+	}
+
+	return map[string]interface{}{
+		"status":              status,
+		"cluster_summary":     clusterSummary,
+		"worst_gpus":          worstGPUs,
+		"issues_by_component": components,
+		"nodes":               nodes,
+	}
+}
+
+// An XID code seen on at least widespreadXIDMinNodes nodes and
+// widespreadXIDMinFraction of the reporting nodes is flagged as widespread:
+// the same error on many nodes at once usually means a driver or firmware
+// bug rather than a bad GPU.
+const (
+	widespreadXIDMinNodes    = 3
+	widespreadXIDMinFraction = 0.25
+)
+
+// maxRecommendedNodes caps the nodes named in the fleet recommendation.
+const maxRecommendedNodes = 5
+
+// xidCounts counts XID errors by severity.
+type xidCounts struct {
+	ErrorCount int `json:"error_count"`
+	Fatal      int `json:"fatal"`
+	Critical   int `json:"critical"`
+	Warning    int `json:"warning"`
+	Info       int `json:"info"`
+}
+
+// add counts an error of the given severity.
+func (c *xidCounts) add(severity string) {
+	c.ErrorCount++
+	switch severity {
+	case "fatal":
+		c.Fatal++
+	case "critical":
+		c.Critical++
+	case "warning":
+		c.Warning++
+	case "info":
+		c.Info++
+	}
+}
+
+// worseThan ranks counts by fatal, then critical, then total errors.
+func (c *xidCounts) worseThan(o *xidCounts) bool {
+	if c.Fatal != o.Fatal {
+		return c.Fatal > o.Fatal
+	}
+	if c.Critical != o.Critical {
+		return c.Critical > o.Critical
+	}
+	return c.ErrorCount > o.ErrorCount
+}
+
+// xidCodeKey identifies an error across the cluster: XIDs and SXids by
+// code, NVRM events by ID.
+type xidCodeKey struct {
+	kind  string
+	code  int
+	event string
+}
+
+// xidCodeSummary aggregates one XID code across the cluster, or one SXid
+// code or NVRM event.
+type xidCodeSummary struct {
+	Kind       string   `json:"kind"`
+	XID        int      `json:"xid"`
+	Event      string   `json:"event,omitempty"`
+	Name       string   `json:"name"`
+	Severity   string   `json:"severity"`
+	Count      int      `json:"count"`
+	NodeCount  int      `json:"node_count"`
+	Nodes      []string `json:"nodes"`
+	Widespread bool     `json:"widespread"`
+}
+
+// label names the code in recommendations.
+func (s *xidCodeSummary) label() string {
+	switch s.Kind {
+	case "sxid":
+		return fmt.Sprintf("SXid %d (%s)", s.XID, s.Name)
+	case "nvrm":
+		return s.Name
+	default:
+		return fmt.Sprintf("XID %d (%s)", s.XID, s.Name)
+	}
+}
+
+// xidNodeSummary ranks a node by its XID errors. Counts include SXid and
+// NVRM events, listed apart from the XID codes.
+type xidNodeSummary struct {
+	Node string `json:"node"`
+	xidCounts
+	XIDs   []int    `json:"xids"`
+	SXids  []int    `json:"sxids,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+// xidGPUSummary ranks a GPU by its XID errors and NVRM events. UUID is
+// empty when the agent could not match the PCI bus ID to a GPU (e.g., it
+// fell off the bus).
+type xidGPUSummary struct {
+	Node     string `json:"node"`
+	GPUIndex int    `json:"gpu_index"`
+	GPUUUID  string `json:"gpu_uuid"`
+	PCIBusID string `json:"pci_bus_id"`
+	xidCounts
+	XIDs   []int    `json:"xids"`
+	Events []string `json:"events,omitempty"`
+}
+
+// aggregateXIDErrors creates a cluster-wide XID report: totals by code and
+// severity, nodes and GPUs ranked by fatal and critical errors, XID codes
+// appearing on many nodes, and a fleet recommendation. Individual errors
+// are dropped; call the tool with node_name for the full list of a node.
+func (p *ProxyHandler) aggregateXIDErrors(results []NodeResult) interface{} {
+	readyNodes := 0
+	var total xidCounts
+	codes := make(map[xidCodeKey]*xidCodeSummary)
+	codeNodes := make(map[xidCodeKey]map[string]bool)
+	nodeSummaries := make([]*xidNodeSummary, 0)
+	gpuSummaries := make(map[string]*xidGPUSummary)
+	nodes := make([]interface{}, 0, len(results))
+
+	for _, result := range results {
+		nodeData := map[string]interface{}{
+			"name": result.NodeName,
+		}
+
+		if result.Error != "" {
+			nodeData["status"] = result.failureStatus()
+			nodeData["error"] = result.Error
+			nodes = append(nodes, nodeData)
+			continue
+		}
+
+		nodeData["status"] = "ready"
+		readyNodes++
+
+		report, ok := parseToolResponse(result.Response).(map[string]interface{})
+		if !ok {
+			nodes = append(nodes, nodeData)
+			continue
+		}
+		if v, ok := report["status"].(string); ok {
+			nodeData["xid_status"] = v
+		}
+
+		xidErrors, _ := report["errors"].([]interface{})
+		nodeData["error_count"] = len(xidErrors)
+		// A limit returns fewer errors than the node has
+		if v, ok := report["error_count"].(float64); ok && int(v) > len(xidErrors) {
+			nodeData["error_count"] = int(v)
+			nodeData["truncated"] = true
+		}
+		nodes = append(nodes, nodeData)
+		if len(xidErrors) == 0 {
+			continue
+		}
+
+		node := &xidNodeSummary{Node: result.NodeName, XIDs: []int{}}
+		for _, e := range xidErrors {
+			xidErr, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			code, ok := xidErr["xid"].(float64)
+			if !ok {
+				continue
+			}
+			// Agents before SXid and NVRM support report XIDs only
+			kind, _ := xidErr["kind"].(string)
+			if kind == "" {
+				kind = "xid"
+			}
+			event, _ := xidErr["event"].(string)
+			key := xidCodeKey{kind: kind, code: int(code), event: event}
+			severity, _ := xidErr["severity"].(string)
+
+			total.add(severity)
+			node.add(severity)
+			switch kind {
+			case "sxid":
+				node.SXids = appendUnique(node.SXids, key.code)
+			case "nvrm":
+				node.Events = appendUnique(node.Events, event)
+			default:
+				node.XIDs = appendUnique(node.XIDs, key.code)
+			}
+
+			summary, ok := codes[key]
+			if !ok {
+				name, _ := xidErr["name"].(string)
+				summary = &xidCodeSummary{
+					Kind:     kind,
+					XID:      key.code,
+					Event:    event,
+					Name:     name,
+					Severity: severity,
+				}
+				codes[key] = summary
+				codeNodes[key] = make(map[string]bool)
+			}
+			summary.Count++
+			codeNodes[key][result.NodeName] = true
+
+			// SXids are logged for NVSwitches, not GPUs
+			if kind == "sxid" {
+				continue
+			}
+			uuid, _ := xidErr["gpu_uuid"].(string)
+			busID, _ := xidErr["pci_bus_id"].(string)
+			gpuKey := result.NodeName + "/" + uuid
+			if uuid == "" {
+				gpuKey = result.NodeName + "/" + busID
+			}
+			gpu, ok := gpuSummaries[gpuKey]
+			if !ok {
+				index, _ := xidErr["gpu_index"].(float64)
+				gpu = &xidGPUSummary{
+					Node:     result.NodeName,
+					GPUIndex: int(index),
+					GPUUUID:  uuid,
+					PCIBusID: busID,
+					XIDs:     []int{},
+				}
+				gpuSummaries[gpuKey] = gpu
+			}
+			gpu.add(severity)
+			if kind == "nvrm" {
+				gpu.Events = appendUnique(gpu.Events, event)
+			} else {
+				gpu.XIDs = appendUnique(gpu.XIDs, key.code)
+			}
+		}
+
+		slices.Sort(node.XIDs)
+		slices.Sort(node.SXids)
+		slices.Sort(node.Events)
+		nodeSummaries = append(nodeSummaries, node)
+	}
+
+	// Codes by severity, then by how often they occur
+	byCode := make([]*xidCodeSummary, 0, len(codes))
+	widespread := make([]*xidCodeSummary, 0)
+	for key, summary := range codes {
+		for node := range codeNodes[key] {
+			summary.Nodes = append(summary.Nodes, node)
+		}
+		sort.Strings(summary.Nodes)
+		summary.NodeCount = len(summary.Nodes)
+		summary.Widespread = summary.NodeCount >= widespreadXIDMinNodes &&
+			float64(summary.NodeCount) >=
+				widespreadXIDMinFraction*float64(readyNodes)
+		byCode = append(byCode, summary)
+	}
+	sort.Slice(byCode, func(i, j int) bool {
+		a, b := byCode[i], byCode[j]
+		// Unknown severities rank -1, after info
+		if ra, rb := xid.SeverityRank(a.Severity), xid.SeverityRank(b.Severity); ra != rb {
+			return ra > rb
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind
+		}
+		if a.XID != b.XID {
+			return a.XID < b.XID
+		}
+		return a.Event < b.Event
+	})
+	for _, summary := range byCode {
+		if summary.Widespread {
+			widespread = append(widespread, summary)
+		}
+	}
+
+	sort.SliceStable(nodeSummaries, func(i, j int) bool {
+		a, b := nodeSummaries[i], nodeSummaries[j]
+		if a.worseThan(&b.xidCounts) || b.worseThan(&a.xidCounts) {
+			return a.worseThan(&b.xidCounts)
+		}
+		return a.Node < b.Node
+	})
+
+	gpus := make([]*xidGPUSummary, 0, len(gpuSummaries))
+	for _, gpu := range gpuSummaries {
+		slices.Sort(gpu.XIDs)
+		slices.Sort(gpu.Events)
+		gpus = append(gpus, gpu)
+	}
+	sort.Slice(gpus, func(i, j int) bool {
+		a, b := gpus[i], gpus[j]
+		if a.worseThan(&b.xidCounts) || b.worseThan(&a.xidCounts) {
+			return a.worseThan(&b.xidCounts)
+		}
+		if a.Node != b.Node {
+			return a.Node < b.Node
+		}
+		return a.PCIBusID < b.PCIBusID
+	})
+
+	status := "success"
+	if readyNodes == 0 && len(results) > 0 {
+		status = "error"
+	} else if readyNodes < len(results) {
+		status = "partial"
+	}
+
+	return map[string]interface{}{
+		"status": status,
+		"cluster_summary": map[string]interface{}{
+			"total_nodes":    len(results),
+			"ready_nodes":    readyNodes,
+			"affected_nodes": len(nodeSummaries),
+			"affected_gpus":  len(gpus),
+			"total_errors":   total.ErrorCount,
+			"by_severity": map[string]int{
+				"fatal":    total.Fatal,
+				"critical": total.Critical,
+				"warning":  total.Warning,
+				"info":     total.Info,
+			},
+		},
+		"by_code":         byCode,
+		"widespread_xids": widespread,
+		"affected_nodes":  nodeSummaries,
+		"affected_gpus":   gpus,
+		"recommendation": xidFleetRecommendation(
+			readyNodes, &total, widespread, nodeSummaries),
+		"nodes": nodes,
+	}
+}
+
+// appendUnique appends v to s unless s already holds it.
+func appendUnique[T comparable](s []T, v T) []T {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// xidFleetRecommendation summarizes what to do about the XID errors in the
+// cluster, starting with widespread codes, which call for a driver
+// investigation rather than draining nodes.
+func xidFleetRecommendation(
+	readyNodes int,
+	total *xidCounts,
+	widespread []*xidCodeSummary,
+	nodes []*xidNodeSummary,
+) string {
+	if total.ErrorCount == 0 {
+		return fmt.Sprintf("No XID errors detected on %d node(s).", readyNodes)
+	}
+
+	var recommendations []string
+	for _, code := range widespread {
+		recommendations = append(recommendations, fmt.Sprintf(
+			"%s appears on %d of %d nodes. The same error on "+
+				"many nodes usually points to a driver or firmware issue "+
+				"rather than faulty GPUs: compare driver versions and check "+
+				"NVIDIA release notes before draining nodes.",
+			code.label(), code.NodeCount, readyNodes))
+	}
+
+	// Name the worst nodes; nodes are already ranked
+	worst := func(match func(*xidNodeSummary) bool) []string {
+		var names []string
+		for _, node := range nodes {
+			if match(node) && len(names) < maxRecommendedNodes {
+				names = append(names, node.Node)
+			}
+		}
+		return names
+	}
+
+	if total.Fatal > 0 {
+		names := worst(func(n *xidNodeSummary) bool { return n.Fatal > 0 })
+		recommendations = append(recommendations, fmt.Sprintf(
+			"URGENT: %d fatal error(s). Drain and investigate: %s.",
+			total.Fatal, strings.Join(names, ", ")))
+	}
+	if total.Critical > 0 {
+		names := worst(func(n *xidNodeSummary) bool { return n.Critical > 0 })
+		recommendations = append(recommendations, fmt.Sprintf(
+			"%d critical error(s). Consider GPU reset or replacement on: %s.",
+			total.Critical, strings.Join(names, ", ")))
+	}
+	if total.Fatal == 0 && total.Critical == 0 {
+		recommendations = append(recommendations, fmt.Sprintf(
+			"%d warning/info error(s) on %d node(s). Monitor for frequency "+
+				"and review application logs.",
+			total.ErrorCount, len(nodes)))
+	}
+
+	return strings.Join(recommendations, " ")
+}
+
 // flattenGPUInfo simplifies GPU info for cluster view.
-// Returns a flattened GPU info map with proper nil handling.
+// Returns a flattened GPU info map with proper nil handling. Fields that
+// could not be read, e.g. from an agent reporting an older shape, are
+// listed in missing_fields rather than silently dropped.
 func flattenGPUInfo(dev map[string]interface{}) map[string]interface{} {
 	if dev == nil {
 		return map[string]interface{}{"error": "nil device data"}
@@ -310,6 +1153,32 @@ func flattenGPUInfo(dev map[string]interface{}) map[string]interface{} {
 		}
 	}
 
+	var missing []string
+	for _, field := range []string{
+		"memory_total_gb", "temperature_c", "utilization_percent",
+	} {
+		if _, ok := gpu[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		gpu["missing_fields"] = missing
+	}
+
+	// Flatten MIG to mode and instance profiles
+	if mig, ok := dev["mig"].(map[string]interface{}); ok && mig != nil {
+		gpu["mig_enabled"] = mig["enabled"]
+		if devices, ok := mig["devices"].([]interface{}); ok {
+			profiles := make([]interface{}, 0, len(devices))
+			for _, d := range devices {
+				if md, ok := d.(map[string]interface{}); ok {
+					profiles = append(profiles, md["profile"])
+				}
+			}
+			gpu["mig_profiles"] = profiles
+		}
+	}
+
 	return gpu
 }
 
@@ -325,6 +1194,16 @@ type GPUResourceInfo struct {
 	Capacity    int64 `json:"capacity"`
 	Allocatable int64 `json:"allocatable"`
 	Allocated   int64 `json:"allocated"`
+	// MIG holds the nvidia.com/mig-* resources advertised with the "mixed"
+	// MIG strategy keyed by profile (e.g., 1g.5gb)
+	MIG map[string]*MIGResourceInfo `json:"mig,omitempty"`
+}
+
+// MIGResourceInfo contains the capacity and allocation of a MIG profile.
+type MIGResourceInfo struct {
+	Capacity    int64 `json:"capacity"`
+	Allocatable int64 `json:"allocatable"`
+	Allocated   int64 `json:"allocated"`
 }
 
 // getNodeK8sMetadata fetches K8s node information.
@@ -348,22 +1227,48 @@ func (p *ProxyHandler) getNodeK8sMetadata(
 
 	// Get GPU resource info
 	gpuResources := &GPUResourceInfo{}
-	if qty, ok := node.Status.Capacity[corev1.ResourceName("nvidia.com/gpu")]; ok {
+	if qty, ok := node.Status.Capacity[corev1.ResourceName(k8s.GPUResourceName)]; ok {
 		gpuResources.Capacity = qty.Value()
 	}
-	if qty, ok := node.Status.Allocatable[corev1.ResourceName("nvidia.com/gpu")]; ok {
+	if qty, ok := node.Status.Allocatable[corev1.ResourceName(k8s.GPUResourceName)]; ok {
 		gpuResources.Allocatable = qty.Value()
 	}
+	allocatableMIG := tools.MIGResourceCounts(node.Status.Allocatable)
+	for profile, capacity := range tools.MIGResourceCounts(node.Status.Capacity) {
+		if gpuResources.MIG == nil {
+			gpuResources.MIG = make(map[string]*MIGResourceInfo)
+		}
+		gpuResources.MIG[profile] = &MIGResourceInfo{
+			Capacity:    capacity,
+			Allocatable: allocatableMIG[profile],
+		}
+	}
 
 	// Get accurate GPU allocation from pods
-	allocated, err := p.getNodeGPUAllocation(ctx, nodeName)
+	allocated, allocatedMIG, err := p.getNodeGPUAllocation(ctx, nodeName)
 	if err != nil {
 		klog.V(4).InfoS("failed to get GPU allocation, using fallback",
 			"node", nodeName, "error", err)
 		// Fall back to capacity - allocatable
 		allocated = gpuResources.Capacity - gpuResources.Allocatable
+		allocatedMIG = make(map[string]int64, len(gpuResources.MIG))
+		for profile, mig := range gpuResources.MIG {
+			allocatedMIG[profile] = mig.Capacity - mig.Allocatable
+		}
 	}
 	gpuResources.Allocated = allocated
+	for profile, count := range allocatedMIG {
+		mig, ok := gpuResources.MIG[profile]
+		if !ok {
+			// A profile the node no longer advertises
+			if gpuResources.MIG == nil {
+				gpuResources.MIG = make(map[string]*MIGResourceInfo)
+			}
+			mig = &MIGResourceInfo{}
+			gpuResources.MIG[profile] = mig
+		}
+		mig.Allocated = count
+	}
 
 	return &NodeK8sMetadata{
 		Labels:       labels,
@@ -372,22 +1277,22 @@ func (p *ProxyHandler) getNodeK8sMetadata(
 	}, nil
 }
 
-// getNodeGPUAllocation returns the number of GPUs allocated on a node.
-// Queries pods across all namespaces for accurate cluster-wide allocation counts.
-// Requires ClusterRole with pods: list permission (granted to gateway by default).
+// getNodeGPUAllocation returns the number of GPUs allocated on a node, and
+// the number of MIG devices allocated keyed by profile. Counts GPU pods
+// across all namespaces, served from the client cache once synced. Requires
+// ClusterRole with pods: list/watch permission (granted to gateway by
+// default).
 func (p *ProxyHandler) getNodeGPUAllocation(
 	ctx context.Context,
 	nodeName string,
-) (int64, error) {
-	// List pods on this node across all namespaces for accurate allocation count
-	pods, err := p.router.k8sClient.ListPodsAllNamespaces(ctx,
-		"", // all labels
-		fmt.Sprintf("spec.nodeName=%s", nodeName))
+) (int64, map[string]int64, error) {
+	pods, err := p.router.k8sClient.ListGPUPodsOnNode(ctx, "", nodeName)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	var totalAllocated int64
+	var migAllocated map[string]int64
 	for _, pod := range pods {
 		// Skip completed/failed pods
 		if pod.Status.Phase == corev1.PodSucceeded ||
@@ -397,13 +1302,19 @@ func (p *ProxyHandler) getNodeGPUAllocation(
 
 		for _, container := range pod.Spec.Containers {
 			if req, ok := container.Resources.Requests[corev1.ResourceName(
-				"nvidia.com/gpu")]; ok {
+				k8s.GPUResourceName)]; ok {
 				totalAllocated += req.Value()
 			}
+			for profile, count := range tools.ContainerMIGDevices(&container) {
+				if migAllocated == nil {
+					migAllocated = make(map[string]int64)
+				}
+				migAllocated[profile] += count
+			}
 		}
 	}
 
-	return totalAllocated, nil
+	return totalAllocated, migAllocated, nil
 }
 
 // filterGPULabels returns labels relevant to GPU operations.
@@ -6,9 +6,11 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -374,6 +376,571 @@ func TestAggregateGPUInventory_Empty(t *testing.T) {
 	assert.Len(t, gpuTypes, 0)
 }
 
+func TestAggregateGPUInventory_VersionSkew(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "get_gpu_inventory",
+		router:   &Router{},
+	}
+
+	current := map[string]interface{}{
+		"devices": []interface{}{map[string]interface{}{
+			"name":        "A100",
+			"memory":      map[string]interface{}{"total_bytes": 42949672960},
+			"temperature": map[string]interface{}{"current_celsius": 40},
+			"utilization": map[string]interface{}{"gpu_percent": 10},
+		}},
+	}
+	// An older agent reports memory in a shape the gateway cannot read
+	older := map[string]interface{}{
+		"devices": []interface{}{map[string]interface{}{
+			"name":         "A100",
+			"memory_bytes": 42949672960,
+			"temperature":  map[string]interface{}{"current_celsius": 41},
+			"utilization":  map[string]interface{}{"gpu_percent": 12},
+		}},
+	}
+
+	results := []NodeResult{
+		{NodeName: "node1", AgentVersion: "v0.3.0",
+			Response: toolTextResponse(t, current)},
+		{NodeName: "node2", AgentVersion: "v0.3.0",
+			Response: toolTextResponse(t, current)},
+		{NodeName: "node3", AgentVersion: "v0.2.0",
+			Response: toolTextResponse(t, older)},
+		{NodeName: "node4", AgentVersion: "v0.1.0",
+			Status: NodeStatusUnsupported,
+			Error:  "agent v0.1.0 on node node4 does not provide get_gpu_inventory"},
+	}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	summary := aggMap["cluster_summary"].(map[string]interface{})
+	assert.Equal(t, map[string]int{"v0.3.0": 2, "v0.2.0": 1, "v0.1.0": 1},
+		summary["agent_versions"])
+	assert.Equal(t, true, summary["version_skew"])
+
+	nodes := aggMap["nodes"].([]interface{})
+	node1 := nodes[0].(map[string]interface{})
+	assert.Equal(t, "v0.3.0", node1["agent_version"])
+	assert.NotContains(t, node1["gpus"].([]interface{})[0], "missing_fields")
+
+	node3 := nodes[2].(map[string]interface{})
+	assert.Equal(t, []string{"memory_total_gb"},
+		node3["gpus"].([]interface{})[0].(map[string]interface{})["missing_fields"])
+
+	node4 := nodes[3].(map[string]interface{})
+	assert.Equal(t, NodeStatusUnsupported, node4["status"])
+}
+
+// toolTextResponse wraps a tool result payload in the stdio MCP framing
+// returned by an agent.
+func toolTextResponse(t *testing.T, payload interface{}) []byte {
+	t.Helper()
+
+	text, err := json.Marshal(payload)
+	require.NoError(t, err)
+	result, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"result": map[string]interface{}{
+			"content": []interface{}{
+				map[string]interface{}{"type": "text", "text": string(text)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return append([]byte(`{"jsonrpc":"2.0","id":0,"result":{}}`), result...)
+}
+
+func TestAggregateNVLinkStatus(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "get_nvlink_status",
+		router:   &Router{},
+	}
+
+	healthy := map[string]interface{}{
+		"status":       "success",
+		"link_count":   24,
+		"active_links": 24,
+		"gpus": []interface{}{
+			map[string]interface{}{
+				"index": 0, "uuid": "GPU-aaa", "status": "healthy",
+				"links": []interface{}{
+					map[string]interface{}{"link": 0, "status": "healthy"},
+				},
+			},
+		},
+	}
+	degraded := map[string]interface{}{
+		"status":       "success",
+		"link_count":   24,
+		"active_links": 23,
+		"gpus": []interface{}{
+			map[string]interface{}{
+				"index": 1, "uuid": "GPU-bbb", "status": "degraded",
+				"links": []interface{}{
+					map[string]interface{}{"link": 0, "status": "healthy"},
+					map[string]interface{}{
+						"link": 3, "state": "down", "status": "down",
+						"issues": []interface{}{"link is down"},
+					},
+					map[string]interface{}{
+						"link": 7, "state": "up", "status": "degraded",
+						"recovery_errors": 4,
+					},
+				},
+			},
+		},
+	}
+
+	results := []NodeResult{
+		{NodeName: "node1", PodName: "pod1",
+			Response: toolTextResponse(t, healthy)},
+		{NodeName: "node2", PodName: "pod2",
+			Response: toolTextResponse(t, degraded)},
+		{NodeName: "node3", PodName: "pod3", Error: "connection refused"},
+	}
+
+	aggregated := handler.aggregateResults(context.Background(), results, false)
+	aggMap := aggregated.(map[string]interface{})
+
+	assert.Equal(t, "partial", aggMap["status"])
+
+	summary := aggMap["cluster_summary"].(map[string]interface{})
+	assert.Equal(t, 3, summary["total_nodes"])
+	assert.Equal(t, 2, summary["ready_nodes"])
+	assert.Equal(t, 48, summary["total_links"])
+	assert.Equal(t, 47, summary["active_links"])
+	assert.Equal(t, 2, summary["degraded_links"])
+	assert.Equal(t, 1, summary["nodes_with_degraded_links"])
+
+	links := aggMap["degraded_links"].([]interface{})
+	require.Len(t, links, 2)
+	first := links[0].(map[string]interface{})
+	assert.Equal(t, "node2", first["node"])
+	assert.Equal(t, "GPU-bbb", first["gpu_uuid"])
+	assert.Equal(t, float64(1), first["gpu_index"])
+	assert.Equal(t, float64(3), first["link"])
+	assert.Equal(t, "down", first["status"])
+	assert.Equal(t, "degraded", links[1].(map[string]interface{})["status"])
+
+	nodes := aggMap["nodes"].([]interface{})
+	require.Len(t, nodes, 3)
+	assert.Equal(t, 0, nodes[0].(map[string]interface{})["degraded_links"])
+	assert.Equal(t, 2, nodes[1].(map[string]interface{})["degraded_links"])
+	assert.Equal(t, "error", nodes[2].(map[string]interface{})["status"])
+}
+
+func TestAggregateNVLinkStatus_AllErrors(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "get_nvlink_status",
+		router:   &Router{},
+	}
+
+	results := []NodeResult{
+		{NodeName: "node1", Error: "timeout"},
+	}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	assert.Equal(t, "error", aggMap["status"])
+	assert.Empty(t, aggMap["degraded_links"])
+}
+
+func TestAggregateGPUHealth(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "get_gpu_health",
+		router:   &Router{},
+	}
+
+	healthy := map[string]interface{}{
+		"status": "healthy", "overall_score": 100, "device_count": 1,
+		"gpus": []interface{}{
+			map[string]interface{}{
+				"index": 0, "uuid": "GPU-aaa", "name": "NVIDIA A100",
+				"status": "healthy", "health_score": 100,
+			},
+		},
+		"recommendation": "All GPUs healthy.",
+	}
+	critical := map[string]interface{}{
+		"status": "critical", "overall_score": 40, "device_count": 2,
+		"gpus": []interface{}{
+			map[string]interface{}{
+				"index": 0, "uuid": "GPU-bbb", "name": "NVIDIA A100",
+				"status": "warning", "health_score": 85,
+				"issues": []interface{}{
+					map[string]interface{}{
+						"severity": "warning", "component": "temperature",
+						"message": "GPU running hot (84°C)",
+					},
+				},
+			},
+			map[string]interface{}{
+				"index": 1, "uuid": "GPU-ccc", "name": "NVIDIA A100",
+				"status": "critical", "health_score": 40,
+				"issues": []interface{}{
+					map[string]interface{}{
+						"severity": "warning", "component": "temperature",
+						"message": "GPU approaching slowdown",
+					},
+					map[string]interface{}{
+						"severity": "critical", "component": "ecc",
+						"message":    "2 uncorrectable ECC errors",
+						"suggestion": "Drain node and reset GPU",
+					},
+				},
+			},
+		},
+	}
+
+	results := []NodeResult{
+		{NodeName: "node1", PodName: "pod1",
+			Response: toolTextResponse(t, healthy)},
+		{NodeName: "node2", PodName: "pod2",
+			Response: toolTextResponse(t, critical)},
+		{NodeName: "node3", PodName: "pod3", Error: "connection refused"},
+	}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	assert.Equal(t, "partial", aggMap["status"])
+
+	summary := aggMap["cluster_summary"].(map[string]interface{})
+	assert.Equal(t, 3, summary["total_nodes"])
+	assert.Equal(t, 2, summary["ready_nodes"])
+	assert.Equal(t, 3, summary["total_gpus"])
+	assert.Equal(t, "critical", summary["overall_status"])
+	assert.Equal(t, 40, summary["overall_score"])
+	assert.Equal(t, map[string]int{
+		"healthy": 1, "warning": 1, "degraded": 0, "critical": 1,
+	}, summary["gpus_by_status"])
+	assert.Equal(t, map[string]int{
+		"healthy": 1, "warning": 0, "degraded": 0, "critical": 1,
+	}, summary["nodes_by_status"])
+
+	// Worst GPU first; healthy GPUs are not listed
+	worst := aggMap["worst_gpus"].([]map[string]interface{})
+	require.Len(t, worst, 2)
+	assert.Equal(t, "GPU-ccc", worst[0]["uuid"])
+	assert.Equal(t, "node2", worst[0]["node"])
+	assert.Equal(t, 2, worst[0]["issue_count"])
+	assert.Equal(t, "GPU-bbb", worst[1]["uuid"])
+
+	// Components with critical issues first, critical issues first
+	components := aggMap["issues_by_component"].([]map[string]interface{})
+	require.Len(t, components, 2)
+	assert.Equal(t, "ecc", components[0]["component"])
+	assert.Equal(t, 1, components[0]["critical_count"])
+	ecc := components[0]["issues"].([]map[string]interface{})
+	require.Len(t, ecc, 1)
+	assert.Equal(t, "node2", ecc[0]["node"])
+	assert.Equal(t, "GPU-ccc", ecc[0]["gpu_uuid"])
+	assert.Equal(t, float64(1), ecc[0]["gpu_index"])
+	assert.Equal(t, "Drain node and reset GPU", ecc[0]["suggestion"])
+	assert.Equal(t, "temperature", components[1]["component"])
+	assert.Equal(t, 2, components[1]["issue_count"])
+
+	// Failed nodes first, then the lowest scores
+	nodes := aggMap["nodes"].([]map[string]interface{})
+	require.Len(t, nodes, 3)
+	assert.Equal(t, "node3", nodes[0]["name"])
+	assert.Equal(t, "error", nodes[0]["status"])
+	assert.Equal(t, "node2", nodes[1]["name"])
+	assert.Equal(t, 40, nodes[1]["overall_score"])
+	assert.Equal(t, 3, nodes[1]["issue_count"])
+	assert.Equal(t, "node1", nodes[2]["name"])
+	assert.Equal(t, "All GPUs healthy.", nodes[2]["recommendation"])
+}
+
+func TestAggregateGPUHealth_WorstGPUsCapped(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "get_gpu_health",
+		router:   &Router{},
+	}
+
+	gpus := make([]interface{}, 0, 12)
+	for i := 0; i < 12; i++ {
+		gpus = append(gpus, map[string]interface{}{
+			"index": i, "uuid": fmt.Sprintf("GPU-%d", i),
+			"status": "warning", "health_score": 90 - i,
+		})
+	}
+	report := map[string]interface{}{
+		"status": "warning", "overall_score": 79, "gpus": gpus,
+	}
+
+	aggMap := handler.aggregateResults(context.Background(), []NodeResult{
+		{NodeName: "node1", Response: toolTextResponse(t, report)},
+	}, false).(map[string]interface{})
+
+	worst := aggMap["worst_gpus"].([]map[string]interface{})
+	require.Len(t, worst, maxWorstGPUs)
+	assert.Equal(t, "GPU-11", worst[0]["uuid"])
+	assert.Empty(t, aggMap["issues_by_component"])
+}
+
+// xidReport builds an analyze_xid_errors response with one error per entry.
+func xidReport(status string, errs ...map[string]interface{}) map[string]interface{} {
+	errors := make([]interface{}, 0, len(errs))
+	for _, e := range errs {
+		errors = append(errors, e)
+	}
+	return map[string]interface{}{
+		"status": status, "error_count": len(errs), "errors": errors,
+	}
+}
+
+// xidEntry builds an XID error on a GPU.
+func xidEntry(code int, name, severity, uuid, busID string) map[string]interface{} {
+	return map[string]interface{}{
+		"xid": code, "name": name, "severity": severity,
+		"gpu_index": 0, "gpu_uuid": uuid, "pci_bus_id": busID,
+	}
+}
+
+func TestAggregateXIDErrors(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "analyze_xid_errors",
+		router:   &Router{},
+	}
+
+	results := []NodeResult{
+		{NodeName: "node1", Response: toolTextResponse(t, xidReport("critical",
+			xidEntry(79, "GPU Fallen Off Bus", "fatal", "", "0000:3b:00.0"),
+			xidEntry(13, "Graphics Engine Exception", "warning",
+				"GPU-aaa", "0000:1a:00.0"),
+		))},
+		{NodeName: "node2", Response: toolTextResponse(t, xidReport("degraded",
+			xidEntry(48, "Double Bit ECC Error", "critical",
+				"GPU-bbb", "0000:1a:00.0"),
+			xidEntry(48, "Double Bit ECC Error", "critical",
+				"GPU-bbb", "0000:1a:00.0"),
+			xidEntry(13, "Graphics Engine Exception", "warning",
+				"GPU-ccc", "0000:3b:00.0"),
+		))},
+		{NodeName: "node3", Response: toolTextResponse(t, xidReport("warning",
+			xidEntry(13, "Graphics Engine Exception", "warning",
+				"GPU-ddd", "0000:1a:00.0"),
+		))},
+		{NodeName: "node4", Response: toolTextResponse(t, xidReport("ok"))},
+		{NodeName: "node5", Error: "timeout"},
+	}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	assert.Equal(t, "partial", aggMap["status"])
+
+	summary := aggMap["cluster_summary"].(map[string]interface{})
+	assert.Equal(t, 5, summary["total_nodes"])
+	assert.Equal(t, 4, summary["ready_nodes"])
+	assert.Equal(t, 3, summary["affected_nodes"])
+	assert.Equal(t, 5, summary["affected_gpus"])
+	assert.Equal(t, 6, summary["total_errors"])
+	assert.Equal(t, map[string]int{
+		"fatal": 1, "critical": 2, "warning": 3, "info": 0,
+	}, summary["by_severity"])
+
+	// Most severe codes first
+	byCode := aggMap["by_code"].([]*xidCodeSummary)
+	require.Len(t, byCode, 3)
+	assert.Equal(t, 79, byCode[0].XID)
+	assert.Equal(t, 48, byCode[1].XID)
+	assert.Equal(t, 2, byCode[1].Count)
+	assert.Equal(t, []string{"node2"}, byCode[1].Nodes)
+	assert.False(t, byCode[1].Widespread)
+	assert.Equal(t, 13, byCode[2].XID)
+	assert.Equal(t, 3, byCode[2].NodeCount)
+	assert.True(t, byCode[2].Widespread)
+
+	widespread := aggMap["widespread_xids"].([]*xidCodeSummary)
+	require.Len(t, widespread, 1)
+	assert.Equal(t, 13, widespread[0].XID)
+
+	// Nodes and GPUs ranked by fatal, then critical errors
+	nodes := aggMap["affected_nodes"].([]*xidNodeSummary)
+	require.Len(t, nodes, 3)
+	assert.Equal(t, "node1", nodes[0].Node)
+	assert.Equal(t, []int{13, 79}, nodes[0].XIDs)
+	assert.Equal(t, "node2", nodes[1].Node)
+	assert.Equal(t, 2, nodes[1].Critical)
+	assert.Equal(t, "node3", nodes[2].Node)
+
+	gpus := aggMap["affected_gpus"].([]*xidGPUSummary)
+	require.Len(t, gpus, 5)
+	assert.Equal(t, "0000:3b:00.0", gpus[0].PCIBusID)
+	assert.Empty(t, gpus[0].GPUUUID)
+	assert.Equal(t, "GPU-bbb", gpus[1].GPUUUID)
+	assert.Equal(t, 2, gpus[1].ErrorCount)
+	assert.Equal(t, []int{48}, gpus[1].XIDs)
+
+	recommendation := aggMap["recommendation"].(string)
+	assert.Contains(t, recommendation, "XID 13 (Graphics Engine Exception) "+
+		"appears on 3 of 4 nodes")
+	assert.Contains(t, recommendation, "driver or firmware issue")
+	assert.Contains(t, recommendation,
+		"URGENT: 1 fatal error(s). Drain and investigate: node1.")
+	assert.Contains(t, recommendation,
+		"2 critical error(s). Consider GPU reset or replacement on: node2.")
+
+	nodeList := aggMap["nodes"].([]interface{})
+	require.Len(t, nodeList, 5)
+	assert.Equal(t, "ok", nodeList[3].(map[string]interface{})["xid_status"])
+	assert.Equal(t, "error", nodeList[4].(map[string]interface{})["status"])
+}
+
+func TestAggregateXIDErrors_UnknownSeverityRanksLast(t *testing.T) {
+	handler := &ProxyHandler{toolName: "analyze_xid_errors", router: &Router{}}
+
+	results := []NodeResult{{
+		NodeName: "node1",
+		Response: toolTextResponse(t, xidReport("warning",
+			// An agent with a newer catalog may report a severity the
+			// gateway does not know
+			xidEntry(154, "GPU Recovery Action Changed", "urgent", "", "0000:3B:00.0"),
+			xidEntry(43, "GPU stopped processing", "info", "", "0000:3B:00.0"),
+			xidEntry(31, "GPU memory page fault", "warning", "", "0000:3B:00.0"),
+		)),
+	}}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	byCode := aggMap["by_code"].([]*xidCodeSummary)
+	require.Len(t, byCode, 3)
+	assert.Equal(t, []int{31, 43, 154},
+		[]int{byCode[0].XID, byCode[1].XID, byCode[2].XID})
+}
+
+func TestAggregateXIDErrors_SXidAndNVRMEvents(t *testing.T) {
+	handler := &ProxyHandler{toolName: "analyze_xid_errors", router: &Router{}}
+
+	fallenOff := map[string]interface{}{
+		"kind": "nvrm", "xid": 0, "event": "gpu_fallen_off_bus",
+		"name": "GPU Fallen Off Bus", "severity": "fatal",
+		"gpu_index": -1, "gpu_uuid": "", "pci_bus_id": "0000:3B:00.0",
+	}
+	results := []NodeResult{{
+		NodeName: "node1",
+		Response: toolTextResponse(t, xidReport("critical",
+			map[string]interface{}{
+				"kind": "sxid", "xid": 20034, "name": "NVLink LTSSM Fault",
+				"severity": "fatal", "device": "nvidia-nvswitch0",
+				"gpu_index": -1, "gpu_uuid": "", "pci_bus_id": "0000:05:00.0",
+			},
+			fallenOff,
+			// An agent without kinds reports XIDs
+			xidEntry(79, "GPU Fallen Off Bus", "fatal", "", "0000:3B:00.0"),
+		)),
+	}}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	// The NVRM event and XID 79 share no code summary
+	byCode := aggMap["by_code"].([]*xidCodeSummary)
+	require.Len(t, byCode, 3)
+	assert.Equal(t, "xid", byCode[0].Kind)
+	assert.Equal(t, 79, byCode[0].XID)
+	assert.Equal(t, "sxid", byCode[1].Kind)
+	assert.Equal(t, 20034, byCode[1].XID)
+	assert.Equal(t, "nvrm", byCode[2].Kind)
+	assert.Equal(t, "gpu_fallen_off_bus", byCode[2].Event)
+
+	nodes := aggMap["affected_nodes"].([]*xidNodeSummary)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, 3, nodes[0].Fatal)
+	assert.Equal(t, []int{79}, nodes[0].XIDs)
+	assert.Equal(t, []int{20034}, nodes[0].SXids)
+	assert.Equal(t, []string{"gpu_fallen_off_bus"}, nodes[0].Events)
+
+	// The NVSwitch is not a GPU
+	gpus := aggMap["affected_gpus"].([]*xidGPUSummary)
+	require.Len(t, gpus, 1)
+	assert.Equal(t, "0000:3B:00.0", gpus[0].PCIBusID)
+	assert.Equal(t, 2, gpus[0].ErrorCount)
+	assert.Equal(t, []int{79}, gpus[0].XIDs)
+	assert.Equal(t, []string{"gpu_fallen_off_bus"}, gpus[0].Events)
+}
+
+func TestAggregateXIDErrors_Truncated(t *testing.T) {
+	handler := &ProxyHandler{toolName: "analyze_xid_errors", router: &Router{}}
+
+	// The agent returned the most recent of 7 errors
+	results := []NodeResult{{
+		NodeName: "node1",
+		Response: toolTextResponse(t, map[string]interface{}{
+			"status":      "warning",
+			"error_count": 7,
+			"errors": []interface{}{
+				map[string]interface{}{"xid": 13, "severity": "warning"},
+			},
+			"truncated": true,
+		}),
+	}}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	node := aggMap["nodes"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, 7, node["error_count"])
+	assert.Equal(t, true, node["truncated"])
+}
+
+func TestAggregateXIDErrors_NoErrors(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "analyze_xid_errors",
+		router:   &Router{},
+	}
+
+	results := []NodeResult{
+		{NodeName: "node1", Response: toolTextResponse(t, xidReport("ok"))},
+		{NodeName: "node2", Response: toolTextResponse(t, xidReport("ok"))},
+	}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	assert.Equal(t, "success", aggMap["status"])
+	assert.Empty(t, aggMap["by_code"])
+	assert.Empty(t, aggMap["affected_nodes"])
+	assert.Equal(t, "No XID errors detected on 2 node(s).",
+		aggMap["recommendation"])
+}
+
+func TestAggregateXIDErrors_WidespreadNeedsFraction(t *testing.T) {
+	handler := &ProxyHandler{
+		toolName: "analyze_xid_errors",
+		router:   &Router{},
+	}
+
+	// 3 of 20 nodes is below the 25% threshold
+	results := make([]NodeResult, 0, 20)
+	for i := 0; i < 20; i++ {
+		report := xidReport("ok")
+		if i < 3 {
+			report = xidReport("warning", xidEntry(13,
+				"Graphics Engine Exception", "warning", "GPU-x", "0000:1a:00.0"))
+		}
+		results = append(results, NodeResult{
+			NodeName: fmt.Sprintf("node%02d", i),
+			Response: toolTextResponse(t, report),
+		})
+	}
+
+	aggMap := handler.aggregateResults(
+		context.Background(), results, false).(map[string]interface{})
+
+	assert.Empty(t, aggMap["widespread_xids"])
+	assert.Contains(t, aggMap["recommendation"],
+		"3 warning/info error(s) on 3 node(s)")
+}
+
 func TestFlattenGPUInfo(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -437,6 +1004,39 @@ func TestFlattenGPUInfo(t *testing.T) {
 				assert.Equal(t, 85, result["utilization_percent"])
 			},
 		},
+		{
+			name: "with MIG flattening",
+			input: map[string]interface{}{
+				"name": "NVIDIA A100-SXM4-40GB",
+				"mig": map[string]interface{}{
+					"enabled": true,
+					"devices": []interface{}{
+						map[string]interface{}{"profile": "3g.20gb"},
+						map[string]interface{}{"profile": "1g.5gb"},
+					},
+				},
+			},
+			checkFn: func(t *testing.T, result map[string]interface{}) {
+				assert.Equal(t, true, result["mig_enabled"])
+				assert.Equal(t, []interface{}{"3g.20gb", "1g.5gb"},
+					result["mig_profiles"])
+			},
+		},
+		{
+			name:  "missing fields",
+			input: map[string]interface{}{"name": "Tesla T4"},
+			checkFn: func(t *testing.T, result map[string]interface{}) {
+				assert.Equal(t, []string{"memory_total_gb", "temperature_c",
+					"utilization_percent"}, result["missing_fields"])
+			},
+		},
+		{
+			name:  "without MIG",
+			input: map[string]interface{}{"name": "Tesla T4"},
+			checkFn: func(t *testing.T, result map[string]interface{}) {
+				assert.NotContains(t, result, "mig_enabled")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -567,6 +1167,56 @@ func TestGetNodeK8sMetadata(t *testing.T) {
 	require.NotNil(t, metadata.GPUResources)
 	assert.Equal(t, int64(4), metadata.GPUResources.Capacity)
 	assert.Equal(t, int64(4), metadata.GPUResources.Allocatable)
+	assert.Nil(t, metadata.GPUResources.MIG)
+}
+
+func TestGetNodeK8sMetadata_MIG(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-node-1"},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceName("nvidia.com/mig-1g.10gb"): resource.MustParse("7"),
+				corev1.ResourceName("nvidia.com/mig-3g.40gb"): resource.MustParse("2"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceName("nvidia.com/mig-1g.10gb"): resource.MustParse("6"),
+				corev1.ResourceName("nvidia.com/mig-3g.40gb"): resource.MustParse("2"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "inference", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "gpu-node-1",
+			Containers: []corev1.Container{{
+				Name: "server",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceName("nvidia.com/mig-1g.10gb"): resource.MustParse("3"),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	//nolint:staticcheck // NewSimpleClientset used for testing
+	clientset := fake.NewSimpleClientset(node, pod)
+	handler := &ProxyHandler{
+		toolName: "get_gpu_inventory",
+		router:   NewRouter(k8s.NewClientWithConfig(clientset, nil, "default")),
+	}
+
+	metadata, err := handler.getNodeK8sMetadata(context.Background(), "gpu-node-1")
+	require.NoError(t, err)
+	require.NotNil(t, metadata.GPUResources)
+
+	// MIG devices count per profile, not as nvidia.com/gpu
+	assert.Equal(t, int64(0), metadata.GPUResources.Allocated)
+	assert.Equal(t, map[string]*MIGResourceInfo{
+		"1g.10gb": {Capacity: 7, Allocatable: 6, Allocated: 3},
+		"3g.40gb": {Capacity: 2, Allocatable: 2, Allocated: 0},
+	}, metadata.GPUResources.MIG)
 }
 
 func TestGetNodeGPUAllocation(t *testing.T) {
@@ -644,8 +1294,41 @@ func TestGetNodeGPUAllocation(t *testing.T) {
 		},
 	}
 
+	// MIG devices of the "mixed" strategy are counted per profile
+	pod4 := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "notebook",
+			Namespace: "ml-workloads",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "gpu-node-1",
+			Containers: []corev1.Container{
+				{
+					Name: "jupyter",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceName("nvidia.com/mig-1g.5gb"): resource.MustParse("2"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceName("nvidia.com/mig-1g.5gb"):  resource.MustParse("1"),
+							corev1.ResourceName("nvidia.com/mig-3g.20gb"): resource.MustParse("1"),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
 	//nolint:staticcheck // NewSimpleClientset used for testing
-	clientset := fake.NewSimpleClientset(pod1, pod2, pod3)
+	clientset := fake.NewSimpleClientset(pod1, pod2, pod3, pod4)
 	k8sClient := k8s.NewClientWithConfig(clientset, nil, "ml-workloads")
 
 	handler := &ProxyHandler{
@@ -654,11 +1337,12 @@ func TestGetNodeGPUAllocation(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	allocated, err := handler.getNodeGPUAllocation(ctx, "gpu-node-1")
+	allocated, allocatedMIG, err := handler.getNodeGPUAllocation(ctx, "gpu-node-1")
 
 	require.NoError(t, err)
 	// Should be 2 + 1 = 3 (completed pod excluded)
 	assert.Equal(t, int64(3), allocated)
+	assert.Equal(t, map[string]int64{"1g.5gb": 3, "3g.20gb": 1}, allocatedMIG)
 }
 
 func TestAggregateGPUInventory_WithK8sMetadata(t *testing.T) {
@@ -816,3 +1500,40 @@ func TestAggregateGPUInventory_WithoutK8sMetadata(t *testing.T) {
 	nodeData := nodes[0].(map[string]interface{})
 	assert.NotContains(t, nodeData, "kubernetes")
 }
+
+func TestProxyHandler_Handle_NodeTargeting(t *testing.T) {
+	handler := NewProxyHandler(targetingClient(t), "get_gpu_health")
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_gpu_health"
+	request.Params.Arguments = map[string]interface{}{"gpu_model": "A100"}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]interface{}
+	text := result.Content[0].(mcp.TextContent).Text
+	require.NoError(t, json.Unmarshal([]byte(text), &response))
+
+	assert.Equal(t, "gpu_model=A100", response["target"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"node_name": "t4-node",
+			"reason":    `GPU model "Tesla-T4" does not match "A100"`,
+		},
+	}, response["filtered_nodes"])
+}
+
+func TestProxyHandler_Handle_InvalidNodeSelector(t *testing.T) {
+	handler := NewProxyHandler(targetingClient(t), "get_gpu_health")
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"node_selector": "a=b=c"}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text,
+		"invalid node_selector")
+}
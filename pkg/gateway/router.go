@@ -8,7 +8,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	// compatibility with external distributed tracing systems (Jaeger,
 	// Zipkin, OpenTelemetry) that expect standard UUID format for trace IDs.
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -41,7 +44,10 @@ type Router struct {
 	httpClient     *AgentHTTPClient
 	routingMode    RoutingMode
 	circuitBreaker *CircuitBreaker
+	agents         *AgentCatalog
 	maxConcurrency int
+	callBudget     time.Duration
+	hedgeDelay     time.Duration
 }
 
 // RouterOption configures a Router.
@@ -61,6 +67,14 @@ func WithCircuitBreaker(cb *CircuitBreaker) RouterOption {
 	}
 }
 
+// WithAgentCatalog sets the catalog caching agent versions and tools,
+// so that it can be shared between routers.
+func WithAgentCatalog(c *AgentCatalog) RouterOption {
+	return func(r *Router) {
+		r.agents = c
+	}
+}
+
 // WithMaxConcurrency sets the maximum number of concurrent requests to agents.
 // This prevents memory exhaustion in large clusters. Default is 10.
 func WithMaxConcurrency(n int) RouterOption {
@@ -71,21 +85,40 @@ func WithMaxConcurrency(n int) RouterOption {
 	}
 }
 
+// WithCallBudget sets the default deadline budget of a fan-out, used when
+// a call does not set timeout_seconds. Default is DefaultCallBudget.
+func WithCallBudget(d time.Duration) RouterOption {
+	return func(r *Router) {
+		if d > 0 {
+			r.callBudget = min(d, MaxCallBudget)
+		}
+	}
+}
+
+// WithHedgeDelay enables hedged requests in HTTP mode: when an agent has
+// not answered on its pod IP after d, the same request is also sent to its
+// DNS endpoint and the first answer wins. Zero (the default) disables
+// hedging.
+func WithHedgeDelay(d time.Duration) RouterOption {
+	return func(r *Router) {
+		r.hedgeDelay = max(d, 0)
+	}
+}
+
 // NewRouter creates a new gateway router.
 func NewRouter(k8sClient *k8s.Client, opts ...RouterOption) *Router {
 	// Configure circuit breaker with metrics callback
 	cbConfig := DefaultCircuitBreakerConfig()
-	cbConfig.OnStateChange = func(node string, state int, healthy bool) {
-		metrics.SetCircuitState(node, state)
-		metrics.SetNodeHealth(node, healthy)
-	}
+	cbConfig.OnStateChange = RecordCircuitMetrics
 
 	r := &Router{
 		k8sClient:      k8sClient,
 		httpClient:     NewAgentHTTPClient(),
 		routingMode:    RoutingModeHTTP, // Default to HTTP
 		circuitBreaker: NewCircuitBreaker(cbConfig),
+		agents:         NewAgentCatalog(DefaultAgentInfoTTL),
 		maxConcurrency: DefaultMaxConcurrency,
+		callBudget:     DefaultCallBudget,
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -93,12 +126,51 @@ func NewRouter(k8sClient *k8s.Client, opts ...RouterOption) *Router {
 	return r
 }
 
+// Routing paths to an agent, recorded by the circuit breaker.
+const (
+	// RoutePodIP is a direct HTTP call to the agent pod IP.
+	RoutePodIP = "pod-ip"
+	// RouteDNS is an HTTP call through the agent's headless service DNS.
+	RouteDNS = "dns"
+	// RouteExec is a kubectl exec into the agent pod.
+	RouteExec = "exec"
+)
+
+// Node result statuses.
+const (
+	// NodeStatusSuccess means the agent answered.
+	NodeStatusSuccess = "success"
+	// NodeStatusError means the agent or the transport failed.
+	NodeStatusError = "error"
+	// NodeStatusTimeout means the agent did not answer within the
+	// deadline budget.
+	NodeStatusTimeout = "timeout"
+	// NodeStatusSkipped means the agent was not contacted, because its
+	// circuit is open or the call ended first.
+	NodeStatusSkipped = "skipped"
+	// NodeStatusUnsupported means the agent was not contacted, because its
+	// version does not provide the tool.
+	NodeStatusUnsupported = "unsupported"
+)
+
 // NodeResult holds the result from a single node.
 type NodeResult struct {
-	NodeName string          `json:"node_name"`
-	PodName  string          `json:"pod_name"`
-	Response json.RawMessage `json:"response,omitempty"`
-	Error    string          `json:"error,omitempty"`
+	NodeName string `json:"node_name"`
+	PodName  string `json:"pod_name"`
+	Status   string `json:"status"`
+	// AgentVersion is the version of the node's agent, when known
+	AgentVersion string          `json:"agent_version,omitempty"`
+	Response     json.RawMessage `json:"response,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// failureStatus returns the status reported for a failed node, defaulting
+// to NodeStatusError for results built without one.
+func (n NodeResult) failureStatus() string {
+	if n.Status == "" || n.Status == NodeStatusSuccess {
+		return NodeStatusError
+	}
+	return n.Status
 }
 
 // RoutingMode returns the current routing mode.
@@ -106,6 +178,26 @@ func (r *Router) RoutingMode() RoutingMode {
 	return r.routingMode
 }
 
+// CircuitBreaker returns the circuit breaker guarding the agents.
+func (r *Router) CircuitBreaker() *CircuitBreaker {
+	return r.circuitBreaker
+}
+
+// AgentCatalog returns the catalog of agent versions and tools.
+func (r *Router) AgentCatalog() *AgentCatalog {
+	return r.agents
+}
+
+// CallBudget returns the default deadline budget of a fan-out.
+func (r *Router) CallBudget() time.Duration {
+	return r.callBudget
+}
+
+// HedgeDelay returns the hedge delay, zero when hedging is disabled.
+func (r *Router) HedgeDelay() time.Duration {
+	return r.hedgeDelay
+}
+
 // RouteToNode sends an MCP request to a specific node's agent.
 // This performs a pod lookup by node name first.
 func (r *Router) RouteToNode(
@@ -146,8 +238,36 @@ func (r *Router) routeToGPUNode(
 		return nil, fmt.Errorf("circuit open for node %s", node.Name)
 	}
 
+	return r.callGPUNode(ctx, node, mcpRequest, requestID)
+}
+
+// callGPUNode sends an MCP request to a node's agent that the circuit
+// breaker allowed, and records the outcome. A tool call is not sent to an
+// agent known not to provide the tool; that returns an UnsupportedToolError
+// and does not count against the node's circuit.
+func (r *Router) callGPUNode(
+	ctx context.Context,
+	node k8s.GPUNode,
+	mcpRequest []byte,
+	requestID string,
+) ([]byte, error) {
+	if tool := toolCallName(mcpRequest); tool != "" {
+		if info := r.agentInfo(ctx, node, requestID); info != nil && !info.HasTool(tool) {
+			klog.V(2).InfoS("agent does not provide tool, skipping node",
+				"requestID", requestID, "node", node.Name,
+				"agentVersion", info.Version, "tool", tool)
+			r.circuitBreaker.Abandon(node.Name)
+			return nil, &UnsupportedToolError{
+				Node:         node.Name,
+				Tool:         tool,
+				AgentVersion: info.Version,
+			}
+		}
+	}
+
 	startTime := time.Now()
 	var response []byte
+	var route string
 	var err error
 
 	// Try HTTP routing if enabled
@@ -162,31 +282,45 @@ func (r *Router) routeToGPUNode(
 		// The Calico VXLAN fix (see docs/troubleshooting/cross-node-networking.md)
 		// enables Pod IP routing to work across nodes, making it the preferred path.
 		endpoint := node.GetAgentHTTPEndpoint()
+		hedgeEndpoint := node.GetAgentDNSEndpoint()
+		route = RoutePodIP
 		if endpoint == "" {
 			// Fall back to DNS if Pod IP not available (pod still starting?)
-			endpoint = node.GetAgentDNSEndpoint()
+			endpoint, hedgeEndpoint, route = hedgeEndpoint, "", RouteDNS
 		}
-		if endpoint != "" {
+		if endpoint != "" && hedgeEndpoint != "" && r.hedgeDelay > 0 {
+			response, route, err = r.routeViaHTTPHedged(ctx, node, endpoint,
+				hedgeEndpoint, mcpRequest, startTime, requestID)
+		} else if endpoint != "" {
 			response, err = r.routeViaHTTP(ctx, node, endpoint, mcpRequest,
 				startTime, requestID)
 		} else {
 			klog.V(2).InfoS("pod has no IP, falling back to exec",
 				"requestID", requestID, "node", node.Name, "pod", node.PodName)
+			route = RouteExec
 			response, err = r.routeViaExec(ctx, node, mcpRequest,
 				startTime, requestID)
 		}
 	} else {
 		// Fall back to exec routing
+		route = RouteExec
 		response, err = r.routeViaExec(ctx, node, mcpRequest, startTime, requestID)
 	}
 
-	// Record result with circuit breaker
+	// Record result with circuit breaker. A call abandoned by the client
+	// or cut off by its deadline budget, which the client chooses, says
+	// nothing about the node's health. Transport failures do, and so does
+	// an attempt that timed out while budget was left.
 	if err != nil {
-		r.circuitBreaker.RecordFailure(node.Name)
+		if ctx.Err() != nil && !errors.Is(err, errAttemptTimeout) {
+			r.circuitBreaker.Abandon(node.Name)
+		} else {
+			r.circuitBreaker.RecordFailure(node.Name, route, err)
+		}
 		return nil, err
 	}
 
-	r.circuitBreaker.RecordSuccess(node.Name)
+	r.circuitBreaker.RecordSuccess(node.Name, route)
 	return response, nil
 }
 
@@ -227,6 +361,67 @@ func (r *Router) routeViaHTTP(
 	return response, nil
 }
 
+// routeViaHTTPHedged sends a request to the pod IP endpoint and, if it has
+// not answered after the hedge delay, also to the DNS hedgeEndpoint. The
+// first success wins and cancels the other request. If both fail, the
+// first error is returned. The route of the answer is returned with it.
+func (r *Router) routeViaHTTPHedged(
+	ctx context.Context,
+	node k8s.GPUNode,
+	endpoint, hedgeEndpoint string,
+	mcpRequest []byte,
+	startTime time.Time,
+	requestID string,
+) ([]byte, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type attempt struct {
+		response []byte
+		route    string
+		err      error
+	}
+	attempts := make(chan attempt, 2)
+	send := func(endpoint, route string) {
+		go func() {
+			response, err := r.routeViaHTTP(ctx, node, endpoint, mcpRequest,
+				startTime, requestID)
+			attempts <- attempt{response, route, err}
+		}()
+	}
+
+	send(endpoint, RoutePodIP)
+	hedge := time.NewTimer(r.hedgeDelay)
+	defer hedge.Stop()
+
+	pending, hedged := 1, false
+	var first attempt
+	for pending > 0 {
+		select {
+		case <-hedge.C:
+			klog.V(2).InfoS("agent slow, hedging via DNS endpoint",
+				"requestID", requestID, "node", node.Name,
+				"endpoint", hedgeEndpoint, "hedgeDelay", r.hedgeDelay)
+			hedged = true
+			pending++
+			send(hedgeEndpoint, RouteDNS)
+		case a := <-attempts:
+			pending--
+			if a.err == nil {
+				return a.response, a.route, nil
+			}
+			if first.err == nil {
+				first = a
+			}
+			if !hedged {
+				// Failed fast: a hedge is for slow agents, not broken ones
+				return nil, first.route, first.err
+			}
+		}
+	}
+	return nil, first.route, first.err
+}
+
 // routeViaExec sends request via kubectl exec to agent pod (legacy mode).
 func (r *Router) routeViaExec(
 	ctx context.Context,
@@ -276,6 +471,74 @@ func (r *Router) RouteToAllNodes(
 		return nil, fmt.Errorf("failed to list GPU nodes: %w", err)
 	}
 
+	return r.routeToNodes(ctx, nodes, mcpRequest, requestID, startTime)
+}
+
+// RouteToTargetNodes sends an MCP request to the GPU nodes selected by
+// target and returns the nodes it excluded. A nil target selects all nodes.
+func (r *Router) RouteToTargetNodes(
+	ctx context.Context,
+	target *NodeTarget,
+	mcpRequest []byte,
+) ([]NodeResult, []FilteredNode, error) {
+	if target == nil {
+		results, err := r.RouteToAllNodes(ctx, mcpRequest)
+		return results, nil, err
+	}
+
+	requestID := uuid.New().String()
+	startTime := time.Now()
+
+	nodes, err := r.k8sClient.ListGPUNodes(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list GPU nodes: %w", err)
+	}
+
+	// Selector and GPU model are matched against the Node labels
+	var k8sNodes map[string]*corev1.Node
+	if target.needsLabels() {
+		list, err := r.k8sClient.ListNodes(ctx, "")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		k8sNodes = make(map[string]*corev1.Node, len(list))
+		for i := range list {
+			k8sNodes[list[i].Name] = &list[i]
+		}
+	}
+
+	targeted := make([]k8s.GPUNode, 0, len(nodes))
+	filtered := make([]FilteredNode, 0)
+	for _, node := range nodes {
+		if reason := target.match(node.Name, k8sNodes[node.Name]); reason != "" {
+			filtered = append(filtered, FilteredNode{
+				NodeName: node.Name,
+				Reason:   reason,
+			})
+			continue
+		}
+		targeted = append(targeted, node)
+	}
+
+	klog.V(2).InfoS("targeting nodes",
+		"requestID", requestID, "target", target.String(),
+		"targeted", len(targeted), "filtered", len(filtered))
+
+	results, err := r.routeToNodes(ctx, targeted, mcpRequest, requestID, startTime)
+	return results, filtered, err
+}
+
+// routeToNodes fans an MCP request out to the given nodes, skipping unready
+// ones. Returns partial success: results from healthy nodes even if some
+// fail. Progress is reported to the ProgressFunc of ctx as results arrive.
+// Once ctx is done, nodes not yet contacted are not contacted.
+func (r *Router) routeToNodes(
+	ctx context.Context,
+	nodes []k8s.GPUNode,
+	mcpRequest []byte,
+	requestID string,
+	startTime time.Time,
+) ([]NodeResult, error) {
 	// Count ready nodes for logging
 	readyCount := 0
 	for _, n := range nodes {
@@ -298,6 +561,10 @@ func (r *Router) RouteToAllNodes(
 
 	skippedCount := 0
 
+	progress := NodeProgress{Total: readyCount}
+	reportProgress := progressFromContext(ctx)
+	reportProgress(progress)
+
 	for _, node := range nodes {
 		if !node.Ready {
 			klog.V(2).InfoS("skipping unready node",
@@ -314,6 +581,7 @@ func (r *Router) RouteToAllNodes(
 			resultsCh <- NodeResult{
 				NodeName: node.Name,
 				PodName:  node.PodName,
+				Status:   NodeStatusSkipped,
 				Error: fmt.Sprintf("circuit open (state: %s)",
 					r.circuitBreaker.State(node.Name)),
 			}
@@ -329,24 +597,44 @@ func (r *Router) RouteToAllNodes(
 			case sem <- struct{}{}:
 				defer func() { <-sem }() // Release on completion
 			case <-ctx.Done():
+			}
+			// select picks randomly when both are ready
+			if ctx.Err() != nil {
+				reason := "context cancelled while waiting for semaphore"
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					reason = "deadline budget exhausted before contacting agent"
+				}
+				r.circuitBreaker.Abandon(n.Name)
 				resultsCh <- NodeResult{
 					NodeName: n.Name,
 					PodName:  n.PodName,
-					Error:    "context cancelled while waiting for semaphore",
+					Status:   NodeStatusSkipped,
+					Error:    reason,
 				}
 				return
 			}
 
-			// Use routeToGPUNode directly to avoid redundant API call
-			response, err := r.routeToGPUNode(ctx, n, mcpRequest, requestID)
+			// The circuit breaker already allowed the node above
+			response, err := r.callGPUNode(ctx, n, mcpRequest, requestID)
 
 			result := NodeResult{
-				NodeName: n.Name,
-				PodName:  n.PodName,
+				NodeName:     n.Name,
+				PodName:      n.PodName,
+				Status:       NodeStatusSuccess,
+				AgentVersion: r.agents.Version(n.Name),
 			}
-			if err != nil {
+			var unsupported *UnsupportedToolError
+			switch {
+			case errors.As(err, &unsupported):
+				result.Status = NodeStatusUnsupported
+				result.Error = err.Error()
+			case err != nil && isTimeout(ctx, err):
+				result.Status = NodeStatusTimeout
+				result.Error = err.Error()
+			case err != nil:
+				result.Status = NodeStatusError
 				result.Error = err.Error()
-			} else {
+			default:
 				result.Response = response
 			}
 			resultsCh <- result
@@ -368,9 +656,12 @@ func (r *Router) RouteToAllNodes(
 		results = append(results, result)
 		if result.Error != "" {
 			failCount++
+			progress.Failed++
 		} else {
 			successCount++
+			progress.Completed++
 		}
+		reportProgress(progress)
 	}
 
 	totalDuration := time.Since(startTime)
@@ -388,3 +679,14 @@ func (r *Router) RouteToAllNodes(
 
 	return results, nil
 }
+
+// isTimeout reports whether a node call failed because the deadline budget
+// of ctx ran out or a transport timeout fired.
+func isTimeout(ctx context.Context, err error) bool {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -5,7 +5,14 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/metrics"
@@ -239,3 +246,425 @@ func TestRouter_MetricsRecording(t *testing.T) {
 	count := testutil.CollectAndCount(metrics.GatewayRequestDuration)
 	assert.Greater(t, count, 0, "Should have recorded gateway request metrics")
 }
+
+// targetingClient returns a client with two unready agents: one on an A100
+// node in us-west-2a and one on a T4 node in us-east-1a.
+func targetingClient(t *testing.T) *k8s.Client {
+	t.Helper()
+
+	//nolint:staticcheck // NewSimpleClientset is used for testing without apply config
+	clientset := fake.NewSimpleClientset()
+	for _, n := range []struct{ node, product, zone string }{
+		{"a100-node", "NVIDIA-A100-SXM4-80GB", "us-west-2a"},
+		{"t4-node", "Tesla-T4", "us-east-1a"},
+	} {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: n.node,
+				Labels: map[string]string{
+					"nvidia.com/gpu.product":      n.product,
+					"topology.kubernetes.io/zone": n.zone,
+				},
+			},
+		}
+		_, err := clientset.CoreV1().Nodes().
+			Create(context.Background(), node, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		// Unready agents are never contacted
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gpu-agent-" + n.node,
+				Namespace: "gpu-diagnostics",
+				Labels: map[string]string{
+					"app.kubernetes.io/name": "k8s-gpu-mcp-server",
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: n.node},
+		}
+		_, err = clientset.CoreV1().Pods(pod.Namespace).
+			Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	return k8s.NewClientWithConfig(clientset, nil, "gpu-diagnostics")
+}
+
+func TestRouterRouteToTargetNodes(t *testing.T) {
+	router := NewRouter(targetingClient(t))
+
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		filtered []FilteredNode
+	}{
+		{
+			name: "node name",
+			args: map[string]interface{}{"node_name": "t4-node"},
+			filtered: []FilteredNode{{
+				NodeName: "a100-node",
+				Reason:   `node name does not match "t4-node"`,
+			}},
+		},
+		{
+			name: "label selector",
+			args: map[string]interface{}{
+				"node_selector": "topology.kubernetes.io/zone=us-west-2a",
+			},
+			filtered: []FilteredNode{{
+				NodeName: "t4-node",
+				Reason: `labels do not match selector ` +
+					`"topology.kubernetes.io/zone=us-west-2a"`,
+			}},
+		},
+		{
+			name: "gpu model",
+			args: map[string]interface{}{"gpu_model": "t4"},
+			filtered: []FilteredNode{{
+				NodeName: "a100-node",
+				Reason:   `GPU model "NVIDIA-A100-SXM4-80GB" does not match "t4"`,
+			}},
+		},
+		{
+			name:     "no filter",
+			args:     nil,
+			filtered: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := ParseNodeTarget(tt.args)
+			require.NoError(t, err)
+
+			results, filtered, err := router.RouteToTargetNodes(
+				context.Background(), target, []byte("{}"))
+			require.NoError(t, err)
+			assert.Empty(t, results)
+			assert.Equal(t, tt.filtered, filtered)
+		})
+	}
+}
+
+// readyAgentsClient returns a client with a ready agent on each node. The
+// agents' pod IPs are not reachable.
+func readyAgentsClient(t *testing.T, nodes ...string) *k8s.Client {
+	t.Helper()
+
+	//nolint:staticcheck // NewSimpleClientset is used for testing without apply config
+	clientset := fake.NewSimpleClientset()
+	for i, node := range nodes {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gpu-agent-" + node,
+				Namespace: "gpu-diagnostics",
+				Labels: map[string]string{
+					"app.kubernetes.io/name": "k8s-gpu-mcp-server",
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{
+				PodIP: fmt.Sprintf("192.0.2.%d", i+1),
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+		_, err := clientset.CoreV1().Pods(pod.Namespace).
+			Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	return k8s.NewClientWithConfig(clientset, nil, "gpu-diagnostics")
+}
+
+// openCircuits returns a circuit breaker with the circuit of every node
+// open, so routing fails without contacting agents.
+func openCircuits(nodes ...string) *CircuitBreaker {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MinRequests:  1,
+		ResetTimeout: time.Hour,
+	})
+	for _, node := range nodes {
+		cb.RecordFailure(node, RoutePodIP, errAgentDown)
+	}
+	return cb
+}
+
+func TestRouterRouteToAllNodes_Progress(t *testing.T) {
+	nodes := []string{"node-a", "node-b", "node-c"}
+	router := NewRouter(readyAgentsClient(t, nodes...),
+		WithCircuitBreaker(openCircuits(nodes...)))
+
+	var reports []NodeProgress
+	ctx := WithProgress(context.Background(), func(p NodeProgress) {
+		reports = append(reports, p)
+	})
+
+	results, err := router.RouteToAllNodes(ctx, nil)
+	assert.Error(t, err)
+	assert.Len(t, results, 3)
+
+	assert.Equal(t, []NodeProgress{
+		{Total: 3},
+		{Total: 3, Failed: 1},
+		{Total: 3, Failed: 2},
+		{Total: 3, Failed: 3},
+	}, reports)
+	assert.Equal(t, 0, reports[3].Remaining())
+}
+
+func TestRouterRouteToAllNodes_Cancelled(t *testing.T) {
+	nodes := []string{"node-a", "node-b"}
+	cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+	router := NewRouter(readyAgentsClient(t, nodes...), WithCircuitBreaker(cb))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := router.RouteToAllNodes(ctx, nil)
+	assert.Error(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.Contains(t, result.Error, "context cancelled")
+		// Abandoned calls do not count against the node
+		assert.Equal(t, 0, cb.Failures(result.NodeName))
+	}
+}
+
+func TestRouterRouteToAllNodes_NodeStatuses(t *testing.T) {
+	t.Run("circuit open", func(t *testing.T) {
+		router := NewRouter(readyAgentsClient(t, "node-a"),
+			WithCircuitBreaker(openCircuits("node-a")))
+
+		results, err := router.RouteToAllNodes(context.Background(), nil)
+		assert.Error(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, NodeStatusSkipped, results[0].Status)
+		assert.Contains(t, results[0].Error, "circuit open")
+	})
+
+	t.Run("budget exhausted", func(t *testing.T) {
+		cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+		router := NewRouter(readyAgentsClient(t, "node-a", "node-b"),
+			WithCircuitBreaker(cb))
+
+		ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+		defer cancel()
+
+		results, err := router.RouteToAllNodes(ctx, nil)
+		assert.Error(t, err)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.Equal(t, NodeStatusSkipped, result.Status)
+			assert.Contains(t, result.Error, "deadline budget exhausted")
+		}
+	})
+}
+
+// redirectTransport sends every request to one server, which stands in
+// for the agents behind their pod IPs.
+type redirectTransport struct {
+	host string
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Host = rt.host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRouterRouteToAllNodes_ShortBudgetKeepsCircuitClosed(t *testing.T) {
+	tests := []struct {
+		name         string
+		delay        time.Duration
+		status       int
+		wantFailures bool
+	}{
+		{
+			name:   "slow agent outlives the budget",
+			delay:  time.Second,
+			status: http.StatusOK,
+		},
+		{
+			name:         "agent fails",
+			status:       http.StatusInternalServerError,
+			wantFailures: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := agentServer(t, tt.delay, tt.status, `{"success":true}`)
+			cb := NewCircuitBreaker(DefaultCircuitBreakerConfig())
+			router := NewRouter(readyAgentsClient(t, "node-a"),
+				WithCircuitBreaker(cb))
+			router.httpClient.retryPolicy.BaseDelay = time.Millisecond
+			router.httpClient.client.Transport = redirectTransport{
+				host: strings.TrimPrefix(url, "http://"),
+			}
+
+			for range 3 {
+				ctx, cancel := context.WithTimeout(
+					context.Background(), 100*time.Millisecond)
+				_, err := router.RouteToAllNodes(ctx, nil)
+				cancel()
+				assert.Error(t, err)
+			}
+
+			if tt.wantFailures {
+				assert.Equal(t, 3, cb.Failures("node-a"))
+				assert.Equal(t, CircuitOpen, cb.State("node-a"))
+				return
+			}
+			// The client chose a budget the agent cannot answer in
+			assert.Equal(t, 0, cb.Failures("node-a"))
+			assert.Equal(t, CircuitClosed, cb.State("node-a"))
+		})
+	}
+}
+
+func TestIsTimeout(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{
+			name: "budget exhausted",
+			ctx:  expired,
+			err:  errors.New("HTTP request failed on node a: boom"),
+			want: true,
+		},
+		{
+			name: "attempt deadline",
+			ctx:  context.Background(),
+			err:  fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			want: true,
+		},
+		{
+			name: "transport timeout",
+			ctx:  context.Background(),
+			err:  &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+			want: true,
+		},
+		{
+			name: "connection refused",
+			ctx:  context.Background(),
+			err:  errors.New("connect: connection refused"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTimeout(tt.ctx, tt.err))
+		})
+	}
+}
+
+// agentServer returns an agent endpoint that answers body after delay.
+func agentServer(t *testing.T, delay time.Duration, status int, body string) string {
+	t.Helper()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			case <-release:
+				return
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	return server.URL
+}
+
+func TestRouterRouteViaHTTPHedged(t *testing.T) {
+	node := k8s.GPUNode{Name: "node-a"}
+
+	tests := []struct {
+		name      string
+		primary   func(t *testing.T) string
+		hedge     func(t *testing.T) string
+		want      string
+		wantRoute string
+		wantErr   string
+		maxDelay  time.Duration
+	}{
+		{
+			name: "fast primary",
+			primary: func(t *testing.T) string {
+				return agentServer(t, 0, http.StatusOK, "primary")
+			},
+			hedge: func(t *testing.T) string {
+				return agentServer(t, 0, http.StatusOK, "hedge")
+			},
+			want:      "primary",
+			wantRoute: RoutePodIP,
+		},
+		{
+			name: "slow primary",
+			primary: func(t *testing.T) string {
+				return agentServer(t, time.Minute, http.StatusOK, "primary")
+			},
+			hedge: func(t *testing.T) string {
+				return agentServer(t, 0, http.StatusOK, "hedge")
+			},
+			want:      "hedge",
+			wantRoute: RouteDNS,
+			maxDelay:  5 * time.Second,
+		},
+		{
+			name: "primary fails fast",
+			primary: func(t *testing.T) string {
+				return agentServer(t, 0, http.StatusBadRequest, "bad request")
+			},
+			hedge: func(t *testing.T) string {
+				return agentServer(t, 0, http.StatusOK, "hedge")
+			},
+			wantErr: "unexpected status 400",
+		},
+		{
+			name: "both fail",
+			primary: func(t *testing.T) string {
+				return agentServer(t, 100*time.Millisecond,
+					http.StatusBadRequest, "primary")
+			},
+			hedge: func(t *testing.T) string {
+				return agentServer(t, 0, http.StatusBadRequest, "hedge")
+			},
+			wantErr: "unexpected status 400: hedge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(nil, WithHedgeDelay(20*time.Millisecond))
+			router.httpClient.retryPolicy.MaxRetries = 0
+
+			start := time.Now()
+			response, route, err := router.routeViaHTTPHedged(context.Background(),
+				node, tt.primary(t), tt.hedge(t), []byte(`{}`), start, "test")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(response))
+			assert.Equal(t, tt.wantRoute, route)
+			if tt.maxDelay > 0 {
+				assert.Less(t, time.Since(start), tt.maxDelay)
+			}
+		})
+	}
+}
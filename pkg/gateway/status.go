@@ -0,0 +1,223 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/klog/v2"
+)
+
+// GetGatewayStatusTool returns the MCP tool definition for
+// get_gateway_status. It is only registered in gateway mode.
+func GetGatewayStatusTool() mcp.Tool {
+	return mcp.NewTool("get_gateway_status",
+		mcp.WithDescription(
+			"Reports how the gateway reaches the node agents: the circuit "+
+				"breaker state of each node (closed, open or half-open), "+
+				"requests and failures in the breaker window, the last "+
+				"error, the last successful call, and the routing path last "+
+				"used (pod-ip, dns or exec), and the version and tools each "+
+				"agent reported, flagging version skew. Use when gateway tools report "+
+				"nodes as skipped, timed out or failed. Does not contact "+
+				"the agents.",
+		),
+	)
+}
+
+// GatewayStatus is the output of get_gateway_status.
+type GatewayStatus struct {
+	Status            string          `json:"status"`
+	RoutingMode       string          `json:"routing_mode"`
+	CallBudgetSeconds float64         `json:"call_budget_seconds"`
+	HedgeDelaySeconds float64         `json:"hedge_delay_seconds"`
+	CircuitBreaker    BreakerSettings `json:"circuit_breaker"`
+	CircuitsByState   map[string]int  `json:"circuits_by_state"`
+	// AgentVersions counts the discovered agents by version; VersionSkew
+	// is set when they differ
+	AgentVersions map[string]int    `json:"agent_versions"`
+	VersionSkew   bool              `json:"version_skew"`
+	Nodes         []NodeAgentStatus `json:"nodes"`
+	// AgentListError is set when the agent pods could not be listed; the
+	// nodes are then only those the breaker has seen
+	AgentListError string `json:"agent_list_error,omitempty"`
+}
+
+// BreakerSettings is the circuit breaker configuration.
+type BreakerSettings struct {
+	FailureRate         float64 `json:"failure_rate"`
+	MinRequests         int     `json:"min_requests"`
+	WindowSeconds       float64 `json:"window_seconds"`
+	ResetTimeoutSeconds float64 `json:"reset_timeout_seconds"`
+}
+
+// NodeAgentStatus is the gateway's view of the agent on one node.
+type NodeAgentStatus struct {
+	NodeName   string `json:"node_name"`
+	PodName    string `json:"pod_name,omitempty"`
+	PodIP      string `json:"pod_ip,omitempty"`
+	AgentReady bool   `json:"agent_ready"`
+	// AgentVersion and AgentTools are what the agent reported when the
+	// gateway last discovered it
+	AgentVersion string   `json:"agent_version,omitempty"`
+	AgentTools   []string `json:"agent_tools,omitempty"`
+	// CircuitState is closed, open or half-open
+	CircuitState string `json:"circuit_state"`
+	// Requests and Failures are counted within the breaker window
+	Requests      int        `json:"requests"`
+	Failures      int        `json:"failures"`
+	FailureRate   float64    `json:"failure_rate"`
+	OpenedAt      *time.Time `json:"opened_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastErrorAt   *time.Time `json:"last_error_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	// LastRoute is the routing path of the last call: pod-ip, dns or exec
+	LastRoute string `json:"last_route,omitempty"`
+}
+
+// StatusHandler handles the get_gateway_status tool.
+type StatusHandler struct {
+	router *Router
+}
+
+// NewStatusHandler creates a handler reporting the state of the router
+// built from opts. Pass the circuit breaker shared with the proxy handlers
+// with WithCircuitBreaker.
+func NewStatusHandler(k8sClient *k8s.Client, opts ...RouterOption) *StatusHandler {
+	return &StatusHandler{router: NewRouter(k8sClient, opts...)}
+}
+
+// Handle processes the get_gateway_status tool request.
+func (h *StatusHandler) Handle(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	klog.InfoS("get_gateway_status invoked")
+
+	status := h.status(ctx)
+
+	jsonBytes, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to marshal response: %v", err)), nil
+	}
+
+	klog.InfoS("get_gateway_status completed", "nodeCount", len(status.Nodes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// status merges the agent pods with the circuit breaker state.
+func (h *StatusHandler) status(ctx context.Context) GatewayStatus {
+	cb := h.router.CircuitBreaker()
+	cfg := cb.Config()
+	status := GatewayStatus{
+		Status:            "success",
+		RoutingMode:       string(h.router.RoutingMode()),
+		CallBudgetSeconds: h.router.CallBudget().Seconds(),
+		HedgeDelaySeconds: h.router.HedgeDelay().Seconds(),
+		CircuitBreaker: BreakerSettings{
+			FailureRate:         cfg.FailureRate,
+			MinRequests:         cfg.MinRequests,
+			WindowSeconds:       cfg.Window.Seconds(),
+			ResetTimeoutSeconds: cfg.ResetTimeout.Seconds(),
+		},
+		CircuitsByState: map[string]int{
+			CircuitClosed.String():   0,
+			CircuitOpen.String():     0,
+			CircuitHalfOpen.String(): 0,
+		},
+	}
+
+	nodes := make(map[string]*NodeAgentStatus)
+	agents, err := h.router.k8sClient.ListGPUNodes(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to list GPU agents")
+		status.Status = "partial"
+		status.AgentListError = err.Error()
+	}
+	for _, agent := range agents {
+		nodes[agent.Name] = &NodeAgentStatus{
+			NodeName:     agent.Name,
+			PodName:      agent.PodName,
+			PodIP:        agent.PodIP,
+			AgentReady:   agent.Ready,
+			CircuitState: CircuitClosed.String(),
+		}
+	}
+
+	for name, info := range h.router.AgentCatalog().Snapshot() {
+		// A replaced pod has not been discovered yet
+		if node, ok := nodes[name]; ok && node.PodName == info.PodName {
+			node.AgentVersion = info.Version
+			node.AgentTools = info.Tools
+		}
+	}
+
+	// Nodes the breaker has seen but whose agent is gone are kept, as
+	// their circuit still decides whether they are contacted
+	for _, circuit := range cb.Snapshot() {
+		node, ok := nodes[circuit.Node]
+		if !ok {
+			node = &NodeAgentStatus{NodeName: circuit.Node}
+			nodes[circuit.Node] = node
+		}
+		node.CircuitState = circuit.State.String()
+		node.Requests = circuit.Requests
+		node.Failures = circuit.Failures
+		if circuit.Requests > 0 {
+			node.FailureRate = float64(circuit.Failures) /
+				float64(circuit.Requests)
+		}
+		if circuit.State != CircuitClosed {
+			node.OpenedAt = timePtr(circuit.OpenedAt)
+		}
+		node.LastError = circuit.LastError
+		node.LastErrorAt = timePtr(circuit.LastErrorAt)
+		node.LastSuccessAt = timePtr(circuit.LastSuccessAt)
+		node.LastRoute = circuit.LastRoute
+	}
+
+	status.AgentVersions = make(map[string]int)
+	for _, node := range nodes {
+		if node.AgentVersion != "" {
+			status.AgentVersions[node.AgentVersion]++
+		}
+	}
+	status.VersionSkew = len(status.AgentVersions) > 1
+
+	status.Nodes = make([]NodeAgentStatus, 0, len(nodes))
+	for _, node := range nodes {
+		status.CircuitsByState[node.CircuitState]++
+		status.Nodes = append(status.Nodes, *node)
+	}
+
+	// Open and half-open circuits first, then by name
+	sort.Slice(status.Nodes, func(i, j int) bool {
+		a, b := status.Nodes[i], status.Nodes[j]
+		aClosed := a.CircuitState == CircuitClosed.String()
+		bClosed := b.CircuitState == CircuitClosed.String()
+		if aClosed != bClosed {
+			return bClosed
+		}
+		return a.NodeName < b.NodeName
+	})
+
+	return status
+}
+
+// timePtr returns a pointer to the UTC time t, or nil for the zero time.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
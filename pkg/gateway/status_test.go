@@ -0,0 +1,100 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGatewayStatusTool(t *testing.T) {
+	tool := GetGatewayStatusTool()
+
+	assert.Equal(t, "get_gateway_status", tool.Name)
+	assert.Contains(t, tool.Description, "circuit breaker")
+	assert.Empty(t, tool.InputSchema.Properties)
+}
+
+func TestStatusHandler_Handle(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		MinRequests:  1,
+		ResetTimeout: time.Hour,
+	})
+	cb.RecordSuccess("node-a", RoutePodIP)
+	cb.RecordFailure("node-b", RouteDNS, errAgentDown)
+	// The agent of node-gone was removed since the breaker saw it
+	cb.RecordSuccess("node-gone", RouteExec)
+
+	catalog := NewAgentCatalog(time.Hour)
+	catalog.store(k8s.GPUNode{Name: "node-a", PodName: "gpu-agent-node-a"},
+		&AgentInfo{Version: "v0.3.0", PodName: "gpu-agent-node-a",
+			Tools: []string{"get_gpu_inventory"}})
+	catalog.store(k8s.GPUNode{Name: "node-b", PodName: "gpu-agent-node-b"},
+		&AgentInfo{Version: "v0.2.0", PodName: "gpu-agent-node-b"})
+
+	handler := NewStatusHandler(readyAgentsClient(t, "node-a", "node-b", "node-c"),
+		WithCircuitBreaker(cb), WithCallBudget(20*time.Second),
+		WithAgentCatalog(catalog))
+
+	result, err := handler.Handle(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var status GatewayStatus
+	text := result.Content[0].(mcp.TextContent).Text
+	require.NoError(t, json.Unmarshal([]byte(text), &status))
+
+	assert.Equal(t, "success", status.Status)
+	assert.Equal(t, "http", status.RoutingMode)
+	assert.Equal(t, 20.0, status.CallBudgetSeconds)
+	assert.Equal(t, 1, status.CircuitBreaker.MinRequests)
+	assert.Equal(t, 3600.0, status.CircuitBreaker.ResetTimeoutSeconds)
+	assert.Equal(t, map[string]int{"closed": 3, "open": 1, "half-open": 0},
+		status.CircuitsByState)
+	assert.Equal(t, map[string]int{"v0.3.0": 1, "v0.2.0": 1}, status.AgentVersions)
+	assert.True(t, status.VersionSkew)
+
+	names := make([]string, 0, len(status.Nodes))
+	for _, node := range status.Nodes {
+		names = append(names, node.NodeName)
+	}
+	assert.Equal(t, []string{"node-b", "node-a", "node-c", "node-gone"}, names,
+		"open circuits first, then by name")
+
+	b := status.Nodes[0]
+	assert.Equal(t, "open", b.CircuitState)
+	assert.True(t, b.AgentReady)
+	assert.Equal(t, "192.0.2.2", b.PodIP)
+	assert.Equal(t, 1, b.Failures)
+	assert.Equal(t, 1.0, b.FailureRate)
+	assert.Equal(t, "agent down", b.LastError)
+	assert.NotNil(t, b.LastErrorAt)
+	assert.NotNil(t, b.OpenedAt)
+	assert.Nil(t, b.LastSuccessAt)
+	assert.Equal(t, RouteDNS, b.LastRoute)
+
+	a := status.Nodes[1]
+	assert.Equal(t, "closed", a.CircuitState)
+	assert.NotNil(t, a.LastSuccessAt)
+	assert.Nil(t, a.OpenedAt)
+	assert.Equal(t, RoutePodIP, a.LastRoute)
+	assert.Equal(t, "v0.3.0", a.AgentVersion)
+	assert.Equal(t, []string{"get_gpu_inventory"}, a.AgentTools)
+
+	c := status.Nodes[2]
+	assert.Zero(t, c.Requests)
+	assert.Empty(t, c.LastRoute)
+
+	gone := status.Nodes[3]
+	assert.False(t, gone.AgentReady)
+	assert.Empty(t, gone.PodName)
+	assert.Equal(t, RouteExec, gone.LastRoute)
+}
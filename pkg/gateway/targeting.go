@@ -0,0 +1,167 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Tool arguments that select the nodes a proxied call is sent to. They are
+// consumed by the gateway and not forwarded to agents.
+const (
+	ArgNodeName     = "node_name"
+	ArgNodeSelector = "node_selector"
+	ArgGPUModel     = "gpu_model"
+)
+
+// gpuProductLabel is the GPU Feature Discovery label holding the GPU model.
+const gpuProductLabel = "nvidia.com/gpu.product"
+
+// NodeTarget selects the GPU nodes a tool call is routed to. Empty fields
+// match every node; set fields must all match.
+type NodeTarget struct {
+	// NodeName is the exact name of the node
+	NodeName string
+	// Selector matches node labels
+	Selector labels.Selector
+	// GPUModel is matched case-insensitively against whole dash-separated
+	// words of the nvidia.com/gpu.product label (e.g., "A100" and
+	// "A100-SXM4" match "NVIDIA-A100-SXM4-80GB", "A10" does not)
+	GPUModel string
+}
+
+// FilteredNode is a GPU node excluded by a NodeTarget.
+type FilteredNode struct {
+	NodeName string `json:"node_name"`
+	Reason   string `json:"reason"`
+}
+
+// ParseNodeTarget reads the targeting arguments of a tool call. It returns
+// nil when no targeting argument is set.
+func ParseNodeTarget(args map[string]interface{}) (*NodeTarget, error) {
+	var target NodeTarget
+	for _, arg := range []string{ArgNodeName, ArgNodeSelector, ArgGPUModel} {
+		v, ok := args[arg]
+		if !ok || v == nil {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a string", arg)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		switch arg {
+		case ArgNodeName:
+			target.NodeName = s
+		case ArgNodeSelector:
+			selector, err := labels.Parse(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", arg, err)
+			}
+			target.Selector = selector
+		case ArgGPUModel:
+			target.GPUModel = s
+		}
+	}
+
+	if target.NodeName == "" && target.Selector == nil && target.GPUModel == "" {
+		return nil, nil
+	}
+	return &target, nil
+}
+
+// needsLabels reports whether matching requires the node labels.
+func (t *NodeTarget) needsLabels() bool {
+	return t.Selector != nil || t.GPUModel != ""
+}
+
+// match returns an empty string if the node is targeted, or the reason it
+// is not. node may be nil when the Node object could not be found.
+func (t *NodeTarget) match(nodeName string, node *corev1.Node) string {
+	if t.NodeName != "" && nodeName != t.NodeName {
+		return fmt.Sprintf("node name does not match %q", t.NodeName)
+	}
+	if !t.needsLabels() {
+		return ""
+	}
+	if node == nil {
+		return "node labels unavailable"
+	}
+
+	if t.Selector != nil && !t.Selector.Matches(labels.Set(node.Labels)) {
+		return fmt.Sprintf("labels do not match selector %q", t.Selector)
+	}
+	if t.GPUModel != "" {
+		product, ok := node.Labels[gpuProductLabel]
+		if !ok {
+			return fmt.Sprintf("no %s label", gpuProductLabel)
+		}
+		if !matchGPUModel(product, t.GPUModel) {
+			return fmt.Sprintf("GPU model %q does not match %q",
+				product, t.GPUModel)
+		}
+	}
+	return ""
+}
+
+// matchGPUModel reports whether model names a run of whole words of a
+// nvidia.com/gpu.product label. GPU Feature Discovery replaces the spaces
+// of the product name with dashes, so either separates the words of model.
+func matchGPUModel(product, model string) bool {
+	split := func(s string) []string {
+		return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+			return r == '-' || r == ' '
+		})
+	}
+	words, want := split(product), split(model)
+	if len(want) == 0 {
+		return false
+	}
+	for i := 0; i+len(want) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(want)], want) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the target for logs.
+func (t *NodeTarget) String() string {
+	var parts []string
+	if t.NodeName != "" {
+		parts = append(parts, ArgNodeName+"="+t.NodeName)
+	}
+	if t.Selector != nil {
+		parts = append(parts, ArgNodeSelector+"="+t.Selector.String())
+	}
+	if t.GPUModel != "" {
+		parts = append(parts, ArgGPUModel+"="+t.GPUModel)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// stripTargetArgs returns the arguments without the targeting arguments.
+func stripTargetArgs(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	forwarded := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		switch k {
+		case ArgNodeName, ArgNodeSelector, ArgGPUModel:
+			continue
+		}
+		forwarded[k] = v
+	}
+	return forwarded
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseNodeTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    string
+		wantNil bool
+		wantErr string
+	}{
+		{
+			name:    "no arguments",
+			args:    nil,
+			wantNil: true,
+		},
+		{
+			name:    "empty values",
+			args:    map[string]interface{}{"node_name": " ", "gpu_model": ""},
+			wantNil: true,
+		},
+		{
+			name: "all arguments",
+			args: map[string]interface{}{
+				"node_name":     "gpu-node-1",
+				"node_selector": "zone in (a,b)",
+				"gpu_model":     "A100",
+			},
+			want: "node_name=gpu-node-1, node_selector=zone in (a,b), gpu_model=A100",
+		},
+		{
+			name:    "invalid selector",
+			args:    map[string]interface{}{"node_selector": "a=b=c"},
+			wantErr: "invalid node_selector",
+		},
+		{
+			name:    "not a string",
+			args:    map[string]interface{}{"node_name": 3.0},
+			wantErr: "node_name must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := ParseNodeTarget(tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, target)
+				return
+			}
+			require.NotNil(t, target)
+			assert.Equal(t, tt.want, target.String())
+		})
+	}
+}
+
+func TestNodeTarget_Match(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu-node-1",
+			Labels: map[string]string{
+				"nvidia.com/gpu.product":      "NVIDIA-A100-SXM4-80GB",
+				"topology.kubernetes.io/zone": "us-west-2a",
+			},
+		},
+	}
+	unlabeled := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node"}}
+
+	tests := []struct {
+		name   string
+		args   map[string]interface{}
+		node   *corev1.Node
+		reason string
+	}{
+		{
+			name: "node name matches",
+			args: map[string]interface{}{"node_name": "gpu-node-1"},
+			node: node,
+		},
+		{
+			name:   "node name differs",
+			args:   map[string]interface{}{"node_name": "gpu-node-2"},
+			node:   node,
+			reason: `node name does not match "gpu-node-2"`,
+		},
+		{
+			name: "selector matches",
+			args: map[string]interface{}{
+				"node_selector": "topology.kubernetes.io/zone=us-west-2a",
+			},
+			node: node,
+		},
+		{
+			name: "selector differs",
+			args: map[string]interface{}{
+				"node_selector": "topology.kubernetes.io/zone=us-east-1a",
+			},
+			node: node,
+			reason: `labels do not match selector ` +
+				`"topology.kubernetes.io/zone=us-east-1a"`,
+		},
+		{
+			name: "gpu model is case-insensitive",
+			args: map[string]interface{}{"gpu_model": "a100"},
+			node: node,
+		},
+		{
+			name:   "gpu model differs",
+			args:   map[string]interface{}{"gpu_model": "H100"},
+			node:   node,
+			reason: `GPU model "NVIDIA-A100-SXM4-80GB" does not match "H100"`,
+		},
+		{
+			name: "gpu model with several words",
+			args: map[string]interface{}{"gpu_model": "A100-SXM4"},
+			node: node,
+		},
+		{
+			name:   "gpu model is a whole word",
+			args:   map[string]interface{}{"gpu_model": "A10"},
+			node:   node,
+			reason: `GPU model "NVIDIA-A100-SXM4-80GB" does not match "A10"`,
+		},
+		{
+			name:   "gpu model without label",
+			args:   map[string]interface{}{"gpu_model": "H100"},
+			node:   unlabeled,
+			reason: "no nvidia.com/gpu.product label",
+		},
+		{
+			name:   "missing node object",
+			args:   map[string]interface{}{"gpu_model": "A100"},
+			node:   nil,
+			reason: "node labels unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := ParseNodeTarget(tt.args)
+			require.NoError(t, err)
+			require.NotNil(t, target)
+			assert.Equal(t, tt.reason, target.match("gpu-node-1", tt.node))
+		})
+	}
+}
+
+func TestMatchGPUModel(t *testing.T) {
+	tests := []struct {
+		product string
+		model   string
+		want    bool
+	}{
+		{"NVIDIA-A10", "A10", true},
+		{"NVIDIA-A10", "A100", false},
+		{"NVIDIA-A100-PCIE-40GB", "A10", false},
+		{"NVIDIA-A100-PCIE-40GB", "a100", true},
+		{"NVIDIA-RTX-A1000", "A100", false},
+		{"NVIDIA-H100-80GB-HBM3", "H100 80GB", true},
+		{"Tesla-T4", "T4", true},
+		{"Tesla-T4", "-", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.product+"_"+tt.model, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchGPUModel(tt.product, tt.model))
+		})
+	}
+}
+
+func TestStripTargetArgs(t *testing.T) {
+	args := map[string]interface{}{
+		"node_name":            "gpu-node-1",
+		"node_selector":        "a=b",
+		"gpu_model":            "A100",
+		"include_k8s_metadata": false,
+	}
+
+	assert.Equal(t, map[string]interface{}{"include_k8s_metadata": false},
+		stripTargetArgs(args))
+	assert.Len(t, args, 4, "input must not be modified")
+	assert.Nil(t, stripTargetArgs(nil))
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// agentPodSelector selects GPU agent pods, excluding the gateway.
+	agentPodSelector = "app.kubernetes.io/name=k8s-gpu-mcp-server," +
+		"app.kubernetes.io/component!=gateway"
+
+	// nodeNameIndex indexes pods by spec.nodeName.
+	nodeNameIndex = "nodeName"
+)
+
+// Resource names the NVIDIA device plugin advertises GPUs under.
+const (
+	// GPUResourceName is the resource name for NVIDIA GPUs.
+	GPUResourceName = "nvidia.com/gpu"
+	// MIGResourcePrefix is the resource name prefix for MIG devices
+	// advertised with the "mixed" MIG strategy (e.g.,
+	// nvidia.com/mig-1g.5gb). With the "single" strategy MIG devices are
+	// advertised as nvidia.com/gpu.
+	MIGResourcePrefix = "nvidia.com/mig-"
+)
+
+// Cache keeps GPU agent pods, nodes and GPU-requesting pods in shared
+// informers, so requests are served from memory instead of listing from
+// the API server. Pods that request no GPUs are stored as stubs holding only
+// their identity and node, which keeps memory bounded in large clusters.
+type Cache struct {
+	factories []informers.SharedInformerFactory
+	agentPods cache.SharedIndexInformer
+	nodes     cache.SharedIndexInformer
+	gpuPods   cache.SharedIndexInformer
+	synced    atomic.Bool
+}
+
+// newCache creates the informers. They do not run until start is called.
+func newCache(clientset kubernetes.Interface, namespace string) *Cache {
+	agentFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = agentPodSelector
+		}))
+	clusterFactory := informers.NewSharedInformerFactory(clientset, 0)
+
+	c := &Cache{
+		factories: []informers.SharedInformerFactory{agentFactory, clusterFactory},
+		agentPods: agentFactory.Core().V1().Pods().Informer(),
+		nodes:     clusterFactory.Core().V1().Nodes().Informer(),
+		gpuPods:   clusterFactory.Core().V1().Pods().Informer(),
+	}
+
+	indexers := cache.Indexers{nodeNameIndex: indexPodByNodeName}
+	for _, informer := range []cache.SharedIndexInformer{c.agentPods, c.gpuPods} {
+		// Only fails once the informer is running
+		_ = informer.AddIndexers(indexers)
+	}
+	_ = c.agentPods.SetTransform(stripManagedFields)
+	_ = c.nodes.SetTransform(stripManagedFields)
+	_ = c.gpuPods.SetTransform(stubNonGPUPod)
+
+	return c
+}
+
+// start runs the informers until ctx is cancelled and marks the cache
+// synced once their initial lists complete.
+func (c *Cache) start(ctx context.Context) {
+	for _, factory := range c.factories {
+		factory.Start(ctx.Done())
+	}
+
+	go func() {
+		startTime := time.Now()
+		if !cache.WaitForCacheSync(ctx.Done(),
+			c.agentPods.HasSynced, c.nodes.HasSynced, c.gpuPods.HasSynced) {
+			return
+		}
+		c.synced.Store(true)
+		klog.InfoS("k8s cache synced",
+			"durationSeconds", time.Since(startTime).Seconds(),
+			"agentPods", len(c.agentPods.GetStore().ListKeys()),
+			"nodes", len(c.nodes.GetStore().ListKeys()))
+	}()
+}
+
+// Synced reports whether the initial lists have completed.
+func (c *Cache) Synced() bool {
+	return c.synced.Load()
+}
+
+// agentPodsOnNode returns the agent pods scheduled on a node.
+func (c *Cache) agentPodsOnNode(nodeName string) []*corev1.Pod {
+	return podsByIndex(c.agentPods, nodeName)
+}
+
+// listAgentPods returns all agent pods, sorted by name for stable output.
+func (c *Cache) listAgentPods() []*corev1.Pod {
+	objs := c.agentPods.GetStore().List()
+	pods := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods
+}
+
+// getNode returns a cached node, or false if it is unknown.
+func (c *Cache) getNode(name string) (*corev1.Node, bool) {
+	obj, ok, err := c.nodes.GetStore().GetByKey(name)
+	if err != nil || !ok {
+		return nil, false
+	}
+	node, ok := obj.(*corev1.Node)
+	return node, ok
+}
+
+// listNodes returns the cached nodes matching selector, sorted by name.
+func (c *Cache) listNodes(selector labels.Selector) []*corev1.Node {
+	objs := c.nodes.GetStore().List()
+	nodes := make([]*corev1.Node, 0, len(objs))
+	for _, obj := range objs {
+		node, ok := obj.(*corev1.Node)
+		if ok && selector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// gpuPodsOnNode returns the cached pods requesting GPUs on a node. An empty
+// namespace matches all namespaces.
+func (c *Cache) gpuPodsOnNode(namespace, nodeName string) []*corev1.Pod {
+	var pods []*corev1.Pod
+	for _, pod := range podsByIndex(c.gpuPods, nodeName) {
+		if (namespace == "" || pod.Namespace == namespace) && RequestsGPU(pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// podsByIndex returns the pods of an informer on a node, sorted by
+// namespace and name.
+func podsByIndex(informer cache.SharedIndexInformer, nodeName string) []*corev1.Pod {
+	objs, err := informer.GetIndexer().ByIndex(nodeNameIndex, nodeName)
+	if err != nil {
+		return nil
+	}
+	pods := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods
+}
+
+// indexPodByNodeName is the nodeName index function.
+func indexPodByNodeName(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("expected *corev1.Pod, got %T", obj)
+	}
+	if pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
+// stripManagedFields drops server-side apply bookkeeping, which is never
+// read and often larger than the rest of the object.
+func stripManagedFields(obj interface{}) (interface{}, error) {
+	if accessor, ok := obj.(metav1.ObjectMetaAccessor); ok {
+		accessor.GetObjectMeta().SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+// stubNonGPUPod reduces pods that request no GPUs to their identity and
+// node. GPU pods are kept whole, minus managed fields.
+func stubNonGPUPod(obj interface{}) (interface{}, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return stripManagedFields(obj)
+	}
+	if RequestsGPU(pod) {
+		return stripManagedFields(pod)
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pod.Name,
+			Namespace:       pod.Namespace,
+			UID:             pod.UID,
+			ResourceVersion: pod.ResourceVersion,
+		},
+		Spec: corev1.PodSpec{NodeName: pod.Spec.NodeName},
+	}, nil
+}
+
+// RequestsGPU reports whether any container of the pod, init containers
+// included, requests or limits full GPUs (nvidia.com/gpu) or MIG devices
+// (nvidia.com/mig-*). The device plugin allocates devices to init
+// containers too, so the cache keeps every pod that was given some.
+func RequestsGPU(pod *corev1.Pod) bool {
+	for _, containers := range [][]corev1.Container{
+		pod.Spec.InitContainers, pod.Spec.Containers,
+	} {
+		for _, container := range containers {
+			for _, list := range []corev1.ResourceList{
+				container.Resources.Requests, container.Resources.Limits,
+			} {
+				for name := range list {
+					if name == GPUResourceName ||
+						strings.HasPrefix(string(name), MIGResourcePrefix) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// cacheTestObjects returns an agent, a gateway, a GPU pod and a CPU pod on
+// gpu-node-1, plus the node itself.
+func cacheTestObjects() []runtime.Object {
+	agentLabels := map[string]string{"app.kubernetes.io/name": "k8s-gpu-mcp-server"}
+	gatewayLabels := map[string]string{
+		"app.kubernetes.io/name":      "k8s-gpu-mcp-server",
+		"app.kubernetes.io/component": "gateway",
+	}
+	gpuResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("2"),
+		},
+	}
+
+	return []runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "gpu-node-1",
+			Labels: map[string]string{"nvidia.com/gpu.product": "Tesla-T4"},
+		}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node-1"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-1",
+				Namespace: "gpu-diagnostics", Labels: agentLabels},
+			Spec: corev1.PodSpec{NodeName: "gpu-node-1"},
+			Status: corev1.PodStatus{PodIP: "10.0.0.1",
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway",
+				Namespace: "gpu-diagnostics", Labels: gatewayLabels},
+			Spec: corev1.PodSpec{NodeName: "cpu-node-1"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "training",
+				Namespace: "ml"},
+			Spec: corev1.PodSpec{NodeName: "gpu-node-1",
+				Containers: []corev1.Container{
+					{Name: "trainer", Resources: gpuResources},
+				}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidecar", Namespace: "ml"},
+			Spec: corev1.PodSpec{NodeName: "gpu-node-1",
+				Containers: []corev1.Container{{Name: "proxy"}}},
+		},
+	}
+}
+
+func TestClient_StartCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//nolint:staticcheck // NewSimpleClientset is used for testing without apply config
+	clientset := fake.NewSimpleClientset(cacheTestObjects()...)
+	var reads atomic.Int32
+	clientset.PrependReactor("*", "*",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetVerb() == "list" || action.GetVerb() == "get" {
+				reads.Add(1)
+			}
+			return false, nil, nil
+		})
+	client := NewClientWithConfig(clientset, nil, "gpu-diagnostics")
+
+	assert.True(t, client.Ready(), "a client without cache is ready")
+	assert.False(t, client.WaitForCacheSync(ctx))
+
+	client.StartCache(ctx)
+	syncCtx, syncCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer syncCancel()
+	require.True(t, client.WaitForCacheSync(syncCtx))
+	assert.True(t, client.Ready())
+
+	// Reads after sync are served from the cache
+	initialReads := reads.Load()
+
+	nodes, err := client.ListGPUNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1, "the gateway pod is not an agent")
+	assert.Equal(t, "gpu-node-1", nodes[0].Name)
+	assert.Equal(t, "10.0.0.1", nodes[0].PodIP)
+	assert.True(t, nodes[0].Ready)
+
+	agent, err := client.GetPodForNode(ctx, "gpu-node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", agent.PodName)
+
+	node, err := client.GetNode(ctx, "gpu-node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Tesla-T4", node.Labels["nvidia.com/gpu.product"])
+
+	gpuNodes, err := client.ListNodes(ctx, "nvidia.com/gpu.product")
+	require.NoError(t, err)
+	require.Len(t, gpuNodes, 1)
+	assert.Equal(t, "gpu-node-1", gpuNodes[0].Name)
+
+	pods, err := client.ListGPUPodsOnNode(ctx, "", "gpu-node-1")
+	require.NoError(t, err)
+	require.Len(t, pods, 1, "pods without GPUs are excluded")
+	assert.Equal(t, "training", pods[0].Name)
+	assert.Len(t, pods[0].Spec.Containers, 1, "GPU pods are cached whole")
+
+	pods, err = client.ListGPUPodsOnNode(ctx, "other", "gpu-node-1")
+	require.NoError(t, err)
+	assert.Empty(t, pods)
+
+	assert.Equal(t, initialReads, reads.Load(), "no API reads after sync")
+
+	// Results are copies; modifying them does not corrupt the cache
+	node.Labels["nvidia.com/gpu.product"] = "changed"
+	node, err = client.GetNode(ctx, "gpu-node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Tesla-T4", node.Labels["nvidia.com/gpu.product"])
+
+	// Watch events update the cache
+	_, err = clientset.CoreV1().Pods("gpu-diagnostics").Create(ctx,
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-2",
+				Namespace: "gpu-diagnostics",
+				Labels: map[string]string{
+					"app.kubernetes.io/name": "k8s-gpu-mcp-server",
+				}},
+			Spec: corev1.PodSpec{NodeName: "gpu-node-2"},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		nodes, err := client.ListGPUNodes(ctx)
+		return err == nil && len(nodes) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClient_ListGPUPodsOnNode_WithoutCache(t *testing.T) {
+	//nolint:staticcheck // NewSimpleClientset is used for testing without apply config
+	clientset := fake.NewSimpleClientset(cacheTestObjects()...)
+	client := NewClientWithConfig(clientset, nil, "gpu-diagnostics")
+
+	pods, err := client.ListGPUPodsOnNode(context.Background(), "", "gpu-node-1")
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "training", pods[0].Name)
+}
+
+func TestRequestsGPU(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources corev1.ResourceRequirements
+		want      bool
+	}{
+		{
+			name: "no resources",
+			want: false,
+		},
+		{
+			name: "gpu request",
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("1"),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "mig limit",
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					"nvidia.com/mig-1g.5gb": resource.MustParse("1"),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "cpu only",
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Resources: tt.resources}},
+			}}
+			assert.Equal(t, tt.want, RequestsGPU(pod))
+
+			// Init containers are given devices too
+			initPod := &corev1.Pod{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: tt.resources}},
+				Containers:     []corev1.Container{{}},
+			}}
+			assert.Equal(t, tt.want, RequestsGPU(initPod))
+		})
+	}
+}
@@ -16,9 +16,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
@@ -66,6 +68,8 @@ type Client struct {
 	namespace   string
 	execTimeout time.Duration
 	serviceName string
+	// cache serves reads once synced (nil until StartCache)
+	cache *Cache
 }
 
 // ClientOption configures a Client.
@@ -219,40 +223,53 @@ func getEnvOrDefault(key, defaultVal string) string {
 
 // ListGPUNodes returns all nodes running the GPU agent DaemonSet.
 func (c *Client) ListGPUNodes(ctx context.Context) ([]GPUNode, error) {
+	if c.cacheSynced() {
+		pods := c.cache.listAgentPods()
+		nodes := make([]GPUNode, 0, len(pods))
+		for _, pod := range pods {
+			nodes = append(nodes, c.gpuNodeFromPod(pod))
+		}
+		return nodes, nil
+	}
+
 	// List pods with the GPU agent label, excluding gateway pods
 	pods, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx,
 		metav1.ListOptions{
-			LabelSelector: "app.kubernetes.io/name=k8s-gpu-mcp-server," +
-				"app.kubernetes.io/component!=gateway",
+			LabelSelector: agentPodSelector,
 		})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
 	nodes := make([]GPUNode, 0, len(pods.Items))
-	for _, pod := range pods.Items {
-		ready := false
-		for _, cond := range pod.Status.Conditions {
-			if cond.Type == corev1.PodReady &&
-				cond.Status == corev1.ConditionTrue {
-				ready = true
-				break
-			}
-		}
-
-		nodes = append(nodes, GPUNode{
-			Name:        pod.Spec.NodeName,
-			PodName:     pod.Name,
-			PodIP:       pod.Status.PodIP,
-			Ready:       ready,
-			Namespace:   c.namespace,
-			ServiceName: c.serviceName,
-		})
+	for i := range pods.Items {
+		nodes = append(nodes, c.gpuNodeFromPod(&pods.Items[i]))
 	}
 
 	return nodes, nil
 }
 
+// gpuNodeFromPod describes the node of a GPU agent pod.
+func (c *Client) gpuNodeFromPod(pod *corev1.Pod) GPUNode {
+	ready := false
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady &&
+			cond.Status == corev1.ConditionTrue {
+			ready = true
+			break
+		}
+	}
+
+	return GPUNode{
+		Name:        pod.Spec.NodeName,
+		PodName:     pod.Name,
+		PodIP:       pod.Status.PodIP,
+		Ready:       ready,
+		Namespace:   c.namespace,
+		ServiceName: c.serviceName,
+	}
+}
+
 // ExecInPod executes the agent binary in a pod with MCP request as stdin.
 // Returns the stdout and any error encountered.
 //
@@ -334,6 +351,14 @@ func (c *Client) GetPodForNode(
 	ctx context.Context,
 	nodeName string,
 ) (*GPUNode, error) {
+	if c.cacheSynced() {
+		if pods := c.cache.agentPodsOnNode(nodeName); len(pods) > 0 {
+			node := c.gpuNodeFromPod(pods[0])
+			return &node, nil
+		}
+		// The pod may be newer than the cache; confirm with the API
+	}
+
 	// Use field selector to query directly by node name for efficiency
 	pods, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx,
 		metav1.ListOptions{
@@ -349,24 +374,8 @@ func (c *Client) GetPodForNode(
 		return nil, fmt.Errorf("no GPU agent found on node %s", nodeName)
 	}
 
-	pod := pods.Items[0]
-	ready := false
-	for _, cond := range pod.Status.Conditions {
-		if cond.Type == corev1.PodReady &&
-			cond.Status == corev1.ConditionTrue {
-			ready = true
-			break
-		}
-	}
-
-	return &GPUNode{
-		Name:        pod.Spec.NodeName,
-		PodName:     pod.Name,
-		PodIP:       pod.Status.PodIP,
-		Ready:       ready,
-		Namespace:   c.namespace,
-		ServiceName: c.serviceName,
-	}, nil
+	node := c.gpuNodeFromPod(&pods.Items[0])
+	return &node, nil
 }
 
 // Namespace returns the configured namespace.
@@ -401,6 +410,19 @@ func (c *Client) ListNodes(
 	ctx context.Context,
 	labelSelector string,
 ) ([]corev1.Node, error) {
+	if c.cacheSynced() {
+		selector, err := labels.Parse(labelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		cached := c.cache.listNodes(selector)
+		nodes := make([]corev1.Node, 0, len(cached))
+		for _, node := range cached {
+			nodes = append(nodes, *node.DeepCopy())
+		}
+		return nodes, nil
+	}
+
 	nodeList, err := c.clientset.CoreV1().Nodes().List(ctx,
 		metav1.ListOptions{
 			LabelSelector: labelSelector,
@@ -417,6 +439,12 @@ func (c *Client) GetNode(
 	ctx context.Context,
 	name string,
 ) (*corev1.Node, error) {
+	if c.cacheSynced() {
+		if node, ok := c.cache.getNode(name); ok {
+			return node.DeepCopy(), nil
+		}
+	}
+
 	node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
@@ -473,6 +501,74 @@ func (c *Client) ListPodsAllNamespaces(
 	return podList.Items, nil
 }
 
+// ListGPUPodsOnNode returns the pods on a node that request GPUs or MIG
+// devices. An empty namespace matches all namespaces, which requires
+// cluster-wide RBAC permissions.
+func (c *Client) ListGPUPodsOnNode(
+	ctx context.Context,
+	namespace string,
+	nodeName string,
+) ([]corev1.Pod, error) {
+	if c.cacheSynced() {
+		cached := c.cache.gpuPodsOnNode(namespace, nodeName)
+		pods := make([]corev1.Pod, 0, len(cached))
+		for _, pod := range cached {
+			pods = append(pods, *pod.DeepCopy())
+		}
+		return pods, nil
+	}
+
+	podList, err := c.clientset.CoreV1().Pods(namespace).List(ctx,
+		metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w",
+			nodeName, err)
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		// Client-side node filter (FieldSelector backup for fake clients)
+		if pod.Spec.NodeName == nodeName && RequestsGPU(pod) {
+			pods = append(pods, *pod)
+		}
+	}
+	return pods, nil
+}
+
+// StartCache starts informers for agent pods, nodes and GPU pods. Until
+// they sync, reads fall back to direct API calls. Must be called before the
+// client is shared; later calls are no-ops.
+func (c *Client) StartCache(ctx context.Context) {
+	if c.cache != nil {
+		return
+	}
+	c.cache = newCache(c.clientset, c.namespace)
+	c.cache.start(ctx)
+}
+
+// WaitForCacheSync blocks until the cache has synced or ctx is done.
+// Returns false if the cache was not started or did not sync.
+func (c *Client) WaitForCacheSync(ctx context.Context) bool {
+	if c.cache == nil {
+		return false
+	}
+	return cache.WaitForCacheSync(ctx.Done(), c.cache.Synced)
+}
+
+// Ready reports whether the client serves reads from a synced cache, or
+// true when no cache was started.
+func (c *Client) Ready() bool {
+	return c.cache == nil || c.cache.Synced()
+}
+
+// cacheSynced reports whether reads can be served from the cache.
+func (c *Client) cacheSynced() bool {
+	return c.cache != nil && c.cache.Synced()
+}
+
 // GetPod returns a pod by namespace and name.
 // If namespace is empty, uses the client's configured namespace.
 func (c *Client) GetPod(
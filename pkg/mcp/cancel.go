@@ -0,0 +1,127 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// methodCancelled is the MCP notification a client sends to abandon a
+	// request.
+	methodCancelled = "notifications/cancelled"
+
+	// requestIDMetaKey carries the JSON-RPC request ID of a tool call from
+	// the before-call hook to the cancellation middleware, as mcp-go does
+	// not pass it to tool handlers.
+	requestIDMetaKey = "io.k8s-gpu-mcp-server/request-id"
+)
+
+// cancellations tracks in-flight tool calls so that a notifications/cancelled
+// from the client cancels the context of the call it names. Calls are keyed
+// by session and request ID. Stateless HTTP sessions last one request, so
+// there the call is cancelled when the client closes the connection instead.
+type cancellations struct {
+	mu    sync.Mutex
+	calls map[string]context.CancelFunc
+}
+
+// newCancellations creates an empty registry.
+func newCancellations() *cancellations {
+	return &cancellations{calls: make(map[string]context.CancelFunc)}
+}
+
+// serverOptions returns the MCP server options that track tool calls. The
+// server must also route methodCancelled to handleCancelled.
+func (c *cancellations) serverOptions() []server.ServerOption {
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(c.beforeCallTool)
+	return []server.ServerOption{
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(c.middleware),
+	}
+}
+
+// beforeCallTool records the request ID in the tool call metadata.
+func (c *cancellations) beforeCallTool(
+	_ context.Context,
+	id any,
+	request *mcp.CallToolRequest,
+) {
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	request.Params.Meta.AdditionalFields[requestIDMetaKey] = id
+}
+
+// middleware runs each tool call with a context that handleCancelled can
+// cancel.
+func (c *cancellations) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta == nil {
+			return next(ctx, request)
+		}
+		id, ok := request.Params.Meta.AdditionalFields[requestIDMetaKey]
+		if !ok {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		key := callKey(ctx, id)
+		c.mu.Lock()
+		c.calls[key] = cancel
+		c.mu.Unlock()
+		defer func() {
+			c.mu.Lock()
+			delete(c.calls, key)
+			c.mu.Unlock()
+		}()
+
+		return next(ctx, request)
+	}
+}
+
+// handleCancelled cancels the call named by a notifications/cancelled.
+// Unknown or finished requests are ignored, as the protocol requires.
+func (c *cancellations) handleCancelled(
+	ctx context.Context,
+	notification mcp.JSONRPCNotification,
+) {
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok {
+		return
+	}
+	reason, _ := notification.Params.AdditionalFields["reason"].(string)
+
+	c.mu.Lock()
+	cancel, ok := c.calls[callKey(ctx, id)]
+	c.mu.Unlock()
+	if !ok {
+		klog.V(4).InfoS("cancellation for unknown request", "requestID", id)
+		return
+	}
+
+	klog.InfoS("tool call cancelled by client", "requestID", id, "reason", reason)
+	cancel()
+}
+
+// callKey identifies a request within its client session. IDs are
+// normalized so that 1 and 1.0 match.
+func callKey(ctx context.Context, id any) string {
+	sessionID := ""
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+	return sessionID + "/" + mcp.NewRequestId(id).String()
+}
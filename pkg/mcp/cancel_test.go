@@ -0,0 +1,82 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowToolServer returns a server with a "slow" tool that blocks until its
+// context is done, and a channel signalled when a call starts.
+func slowToolServer() (*server.MCPServer, chan struct{}) {
+	cancels := newCancellations()
+	mcpServer := server.NewMCPServer("test", "v0.0.0", cancels.serverOptions()...)
+	mcpServer.AddNotificationHandler(methodCancelled, cancels.handleCancelled)
+
+	started := make(chan struct{}, 1)
+	mcpServer.AddTool(mcp.NewTool("slow"),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			started <- struct{}{}
+			select {
+			case <-ctx.Done():
+				return mcp.NewToolResultError(ctx.Err().Error()), nil
+			case <-time.After(time.Second):
+				return mcp.NewToolResultText("finished"), nil
+			}
+		})
+	return mcpServer, started
+}
+
+func TestCancellations_CancelledNotification(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancelID   string
+		wantResult string
+	}{
+		{name: "matching request", cancelID: "7", wantResult: "context canceled"},
+		{name: "string ID does not match", cancelID: `"7"`, wantResult: "finished"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpServer, started := slowToolServer()
+			ctx := context.Background()
+
+			done := make(chan mcp.JSONRPCMessage, 1)
+			go func() {
+				done <- mcpServer.HandleMessage(ctx, []byte(
+					`{"jsonrpc":"2.0","id":7,"method":"tools/call",`+
+						`"params":{"name":"slow"}}`))
+			}()
+			<-started
+
+			mcpServer.HandleMessage(ctx, []byte(
+				`{"jsonrpc":"2.0","method":"notifications/cancelled",`+
+					`"params":{"requestId":`+tt.cancelID+`,"reason":"user abort"}}`))
+
+			select {
+			case response := <-done:
+				data, err := json.Marshal(response)
+				require.NoError(t, err)
+				assert.Contains(t, string(data), tt.wantResult)
+			case <-time.After(10 * time.Second):
+				t.Fatal("tool call did not return")
+			}
+		})
+	}
+}
+
+func TestCallKey(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, callKey(ctx, float64(3)), callKey(ctx, int64(3)))
+	assert.NotEqual(t, callKey(ctx, "3"), callKey(ctx, int64(3)))
+}
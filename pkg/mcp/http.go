@@ -22,6 +22,8 @@ type HTTPServer struct {
 	addr       string
 	version    string
 	ready      chan struct{}
+	// readinessCheck fails /readyz while it returns an error (optional)
+	readinessCheck func() error
 }
 
 // NewHTTPServer creates an HTTP transport server.
@@ -102,6 +104,12 @@ func (h *HTTPServer) ListenAndServe(ctx context.Context) error {
 	}
 }
 
+// SetReadinessCheck makes /readyz report not ready while check returns an
+// error. Must be called before ListenAndServe.
+func (h *HTTPServer) SetReadinessCheck(check func() error) {
+	h.readinessCheck = check
+}
+
 // Ready returns a channel that is closed when the server is ready to accept
 // connections. This can be used to synchronize tests or health checks.
 func (h *HTTPServer) Ready() <-chan struct{} {
@@ -145,6 +153,18 @@ func (h *HTTPServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
 	}
 	// TODO: Check NVML initialization status
 	w.Header().Set("Content-Type", "application/json")
+	if h.readinessCheck != nil {
+		if err := h.readinessCheck(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			if err := json.NewEncoder(w).Encode(map[string]string{
+				"status": "not ready",
+				"reason": err.Error(),
+			}); err != nil {
+				klog.ErrorS(err, "failed to encode readyz response")
+			}
+			return
+		}
+	}
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(map[string]string{
 		"status": "ready",
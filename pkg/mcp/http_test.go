@@ -6,6 +6,7 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -53,6 +54,28 @@ func TestHTTPServer_Readyz(t *testing.T) {
 	assert.Equal(t, "ready", resp["status"])
 }
 
+func TestHTTPServer_Readyz_ReadinessCheck(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "1.0.0")
+	httpServer := NewHTTPServer(mcpServer, ":0", "1.0.0")
+
+	checkErr := errors.New("k8s cache not synced")
+	httpServer.SetReadinessCheck(func() error { return checkErr })
+
+	w := httptest.NewRecorder()
+	httpServer.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "not ready", resp["status"])
+	assert.Equal(t, "k8s cache not synced", resp["reason"])
+
+	checkErr = nil
+	w = httptest.NewRecorder()
+	httpServer.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestHTTPServer_Version(t *testing.T) {
 	mcpServer := server.NewMCPServer("test", "1.0.0")
 	httpServer := NewHTTPServer(mcpServer, ":0", "1.2.3")
@@ -10,13 +10,16 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/gateway"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/prompts"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/tools"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
 	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
 
@@ -62,12 +65,39 @@ type Config struct {
 	GatewayMode bool
 	// Namespace for GPU agent pods (gateway mode only)
 	Namespace string
-	// K8sClient is the Kubernetes client (gateway mode only)
+	// K8sClient is the Kubernetes client (gateway mode, or agent mode
+	// where it resolves process owners and evicts pods in operator mode)
 	K8sClient *k8s.Client
 	// Oneshot exits after processing N requests (0=disabled)
 	Oneshot int
 	// RoutingMode specifies gateway routing: "http" (default) or "exec"
 	RoutingMode string
+	// ResponseCache enables the gateway response cache with the default
+	// per-tool TTLs (gateway mode only)
+	ResponseCache bool
+	// CallBudget is the default deadline of a gateway fan-out, retries
+	// included (gateway mode only, 0 = gateway.DefaultCallBudget)
+	CallBudget time.Duration
+	// HedgeDelay is how long the gateway waits for an agent's pod IP
+	// before also trying its DNS endpoint (gateway mode only, 0 = off)
+	HedgeDelay time.Duration
+	// CircuitBreaker configures the per-node circuit breaker shared by all
+	// gateway tools; zero fields use the defaults (gateway mode only)
+	CircuitBreaker gateway.CircuitBreakerConfig
+	// NodeName is the Kubernetes node the agent runs on (agent mode only)
+	NodeName string
+	// ProcRoot is the procfs mount used to map GPU processes to pods
+	// (agent mode only, default "/proc")
+	ProcRoot string
+	// KmsgPath replaces /dev/kmsg as the source of XID events, e.g. for
+	// simulated scenarios (agent mode only)
+	KmsgPath string
+	// KmsgBootTime is the wall-clock time that KmsgPath timestamps count
+	// from (default: host boot time)
+	KmsgBootTime time.Time
+	// XIDFollower, when set with a journal, replaces the kernel log as the
+	// source of XID events while it follows the kernel log (agent mode only)
+	XIDFollower *xid.Follower
 }
 
 // New creates a new MCP server instance.
@@ -108,12 +138,16 @@ func New(cfg Config) (*Server, error) {
 		oneshot:     cfg.Oneshot,
 	}
 
-	// Create MCP server with prompt capabilities
+	// Create MCP server with prompt capabilities. Tool calls can be
+	// cancelled by the client with notifications/cancelled.
+	cancels := newCancellations()
 	mcpServer := server.NewMCPServer(
 		"k8s-gpu-mcp-server",
 		cfg.Version,
-		server.WithPromptCapabilities(true),
+		append([]server.ServerOption{server.WithPromptCapabilities(true)},
+			cancels.serverOptions()...)...,
 	)
+	mcpServer.AddNotificationHandler(methodCancelled, cancels.handleCancelled)
 
 	if cfg.GatewayMode {
 		// Gateway mode: register GPU tools with proxy handlers
@@ -130,30 +164,63 @@ func New(cfg Config) (*Server, error) {
 			routerOpts = append(routerOpts,
 				gateway.WithRoutingMode(gateway.RoutingModeHTTP))
 		}
+		// All tools share one circuit breaker, so a node failing for one
+		// tool is skipped by the others and get_gateway_status sees it all.
+		// Agent versions and tools are likewise discovered once per pod.
+		breakerCfg := cfg.CircuitBreaker
+		breakerCfg.OnStateChange = gateway.RecordCircuitMetrics
+		routerOpts = append(routerOpts,
+			gateway.WithCallBudget(cfg.CallBudget),
+			gateway.WithHedgeDelay(cfg.HedgeDelay),
+			gateway.WithCircuitBreaker(gateway.NewCircuitBreaker(breakerCfg)),
+			gateway.WithAgentCatalog(
+				gateway.NewAgentCatalog(gateway.DefaultAgentInfoTTL)))
 
 		inventoryProxy := gateway.NewProxyHandler(cfg.K8sClient,
 			"get_gpu_inventory", routerOpts...)
-		mcpServer.AddTool(tools.GetGPUInventoryTool(), inventoryProxy.Handle)
-
 		healthProxy := gateway.NewProxyHandler(cfg.K8sClient,
 			"get_gpu_health", routerOpts...)
-		mcpServer.AddTool(tools.GetGPUHealthTool(), healthProxy.Handle)
-
 		xidProxy := gateway.NewProxyHandler(cfg.K8sClient,
 			"analyze_xid_errors", routerOpts...)
+		processesProxy := gateway.NewProxyHandler(cfg.K8sClient,
+			"list_gpu_processes", routerOpts...)
+		nvlinkProxy := gateway.NewProxyHandler(cfg.K8sClient,
+			"get_nvlink_status", routerOpts...)
+		catalogProxy := gateway.NewProxyHandler(cfg.K8sClient,
+			"get_xid_catalog", routerOpts...)
+
+		// One cache for all tools; TTLs are per tool
+		if cfg.ResponseCache {
+			responseCache := gateway.NewResponseCache(gateway.DefaultCacheTTLs())
+			for _, proxy := range []*gateway.ProxyHandler{inventoryProxy,
+				healthProxy, xidProxy, processesProxy, nvlinkProxy} {
+				proxy.SetResponseCache(responseCache)
+			}
+		}
+
+		mcpServer.AddTool(tools.GetGPUInventoryTool(), inventoryProxy.Handle)
+		mcpServer.AddTool(tools.GetGPUHealthTool(), healthProxy.Handle)
 		mcpServer.AddTool(tools.GetAnalyzeXIDTool(), xidProxy.Handle)
+		mcpServer.AddTool(tools.GetListGPUProcessesTool(),
+			processesProxy.Handle)
+		mcpServer.AddTool(tools.GetNVLinkStatusTool(), nvlinkProxy.Handle)
+		mcpServer.AddTool(tools.GetXIDCatalogTool(), catalogProxy.Handle)
 
 		// Register K8s-native tools (don't need proxy, query K8s API directly)
-		podGPUHandler := tools.NewPodGPUAllocationHandler(
-			cfg.K8sClient.Clientset())
+		podGPUHandler := tools.NewPodGPUAllocationHandlerWithLister(
+			cfg.K8sClient.Clientset(), cfg.K8sClient)
 		mcpServer.AddTool(tools.GetPodGPUAllocationTool(), podGPUHandler.Handle)
 
-		// describe_gpu_node needs both K8s and NVML (nil in gateway mode)
-		// It will use K8s capacity data when NVML is unavailable
-		describeHandler := tools.NewDescribeGPUNodeHandler(
-			cfg.K8sClient.Clientset(), nil)
+		// describe_gpu_node reads K8s data directly and GPU hardware from
+		// the node's agent, falling back to K8s capacity when it is down
+		hardwareProxy := gateway.NewHardwareProxy(cfg.K8sClient, routerOpts...)
+		describeHandler := tools.NewDescribeGPUNodeHandlerWithHardware(
+			cfg.K8sClient.Clientset(), hardwareProxy)
 		mcpServer.AddTool(tools.GetDescribeGPUNodeTool(), describeHandler.Handle)
 
+		statusHandler := gateway.NewStatusHandler(cfg.K8sClient, routerOpts...)
+		mcpServer.AddTool(gateway.GetGatewayStatusTool(), statusHandler.Handle)
+
 		// Register prompts
 		registerPrompts(mcpServer)
 
@@ -163,7 +230,9 @@ func New(cfg Config) (*Server, error) {
 			"namespace", cfg.Namespace,
 			"routingMode", cfg.RoutingMode,
 			"tools", []string{"get_gpu_inventory", "get_gpu_health",
-				"analyze_xid_errors", "get_pod_gpu_allocation", "describe_gpu_node"},
+				"analyze_xid_errors", "list_gpu_processes",
+				"get_nvlink_status", "get_xid_catalog", "get_pod_gpu_allocation",
+				"describe_gpu_node", "get_gateway_status"},
 			"prompts", prompts.GetAllPromptNames(),
 			"version", cfg.Version,
 			"commit", cfg.GitCommit)
@@ -174,18 +243,63 @@ func New(cfg Config) (*Server, error) {
 			gpuInventoryHandler.Handle)
 
 		xidHandler := tools.NewAnalyzeXIDHandler(cfg.NVMLClient)
+		switch {
+		case cfg.XIDFollower != nil && cfg.XIDFollower.Journal() != nil:
+			xidHandler = tools.NewAnalyzeXIDHandlerWithJournal(
+				cfg.NVMLClient, cfg.XIDFollower)
+		case cfg.KmsgPath != "":
+			xidHandler = tools.NewAnalyzeXIDHandlerWithKmsgPath(
+				cfg.NVMLClient, cfg.KmsgPath, cfg.KmsgBootTime)
+		}
 		mcpServer.AddTool(tools.GetAnalyzeXIDTool(), xidHandler.Handle)
 
+		catalogHandler := tools.NewXIDCatalogHandler()
+		mcpServer.AddTool(tools.GetXIDCatalogTool(), catalogHandler.Handle)
+
 		healthHandler := tools.NewGPUHealthHandler(cfg.NVMLClient)
 		mcpServer.AddTool(tools.GetGPUHealthTool(), healthHandler.Handle)
 
+		nvlinkHandler := tools.NewNVLinkStatusHandler(cfg.NVMLClient)
+		mcpServer.AddTool(tools.GetNVLinkStatusTool(), nvlinkHandler.Handle)
+
+		// K8s access is optional in agent mode; it adds pod names to
+		// process mapping and is required by operator tools
+		var clientset kubernetes.Interface
+		if cfg.K8sClient != nil {
+			clientset = cfg.K8sClient.Clientset()
+		}
+		resolver := tools.NewProcessResolver(cfg.ProcRoot)
+
+		processesHandler := tools.NewListGPUProcessesHandler(cfg.NVMLClient,
+			resolver, clientset, cfg.NodeName)
+		mcpServer.AddTool(tools.GetListGPUProcessesTool(),
+			processesHandler.Handle)
+
+		// describe_gpu_node is also what the gateway calls for the GPU
+		// hardware of a node
+		describeHandler := tools.NewDescribeGPUNodeHandler(clientset,
+			cfg.NVMLClient)
+		mcpServer.AddTool(tools.GetDescribeGPUNodeTool(), describeHandler.Handle)
+
+		toolNames := []string{"get_gpu_inventory", "get_gpu_health",
+			"get_nvlink_status", "analyze_xid_errors", "get_xid_catalog",
+			"list_gpu_processes", "describe_gpu_node"}
+
+		// Operator-only tools are never advertised in read-only mode
+		if cfg.Mode == "operator" {
+			killHandler := tools.NewKillGPUProcessHandler(clientset,
+				cfg.NodeName, resolver)
+			mcpServer.AddTool(tools.GetKillGPUProcessTool(), killHandler.Handle)
+			toolNames = append(toolNames, "kill_gpu_process")
+		}
+
 		// Register prompts
 		registerPrompts(mcpServer)
 
 		klog.InfoS("MCP server initialized",
 			"mode", cfg.Mode,
 			"gateway", false,
-			"tools", []string{"get_gpu_inventory", "get_gpu_health", "analyze_xid_errors"},
+			"tools", toolNames,
 			"prompts", prompts.GetAllPromptNames(),
 			"version", cfg.Version,
 			"commit", cfg.GitCommit)
@@ -283,6 +397,15 @@ func (s *Server) runHTTP(ctx context.Context) error {
 		"transport", "http", "addr", s.httpAddr, "mode", s.mode)
 
 	httpServer := NewHTTPServer(s.mcpServer, s.httpAddr, s.version)
+	if s.gatewayMode && s.k8sClient != nil {
+		// Report not ready until the K8s cache has synced
+		httpServer.SetReadinessCheck(func() error {
+			if !s.k8sClient.Ready() {
+				return fmt.Errorf("k8s cache not synced")
+			}
+			return nil
+		})
+	}
 	return httpServer.ListenAndServe(ctx)
 }
 
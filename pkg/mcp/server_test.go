@@ -220,3 +220,42 @@ func TestServer_PromptsCapability(t *testing.T) {
 	// We can verify the server was created successfully
 	assert.Equal(t, "read-only", s.mode)
 }
+
+func TestNew_OperatorToolsRegistration(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		wantKill bool
+	}{
+		{name: "read-only hides kill_gpu_process", mode: "read-only", wantKill: false},
+		{name: "default mode hides kill_gpu_process", mode: "", wantKill: false},
+		{name: "operator registers kill_gpu_process", mode: "operator", wantKill: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(Config{
+				Mode:       tt.mode,
+				Version:    "test",
+				NVMLClient: nvml.NewMock(2),
+				NodeName:   "gpu-node-1",
+			})
+			require.NoError(t, err)
+
+			tool := s.mcpServer.GetTool("kill_gpu_process")
+			if tt.wantKill {
+				assert.NotNil(t, tool, "operator mode should advertise tool")
+			} else {
+				assert.Nil(t, tool, "read-only mode must not advertise tool")
+			}
+
+			// Read-only tools are always registered
+			assert.NotNil(t, s.mcpServer.GetTool("get_gpu_health"))
+			assert.NotNil(t, s.mcpServer.GetTool("list_gpu_processes"))
+			assert.NotNil(t, s.mcpServer.GetTool("get_nvlink_status"))
+			assert.NotNil(t, s.mcpServer.GetTool("get_xid_catalog"))
+			// The gateway reads node hardware through it
+			assert.NotNil(t, s.mcpServer.GetTool("describe_gpu_node"))
+		})
+	}
+}
@@ -5,6 +5,9 @@
 package metrics
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -73,8 +76,42 @@ var (
 		},
 		[]string{"node", "transport", "status"},
 	)
+
+	// GatewayCacheRequests counts gateway tool calls by how the response
+	// cache served them: "hit" (cached), "miss" (new fan-out) or "shared"
+	// (joined a fan-out already in flight).
+	GatewayCacheRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mcp_gateway_cache_requests_total",
+			Help: "Gateway tool calls by response cache result",
+		},
+		[]string{"tool", "result"},
+	)
+
+	// XIDErrorsTotal counts XID errors read from the kernel log of an
+	// agent's node, classified as analyze_xid_errors classifies them.
+	XIDErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gpu_xid_errors_total",
+			Help: "XID errors logged by the NVIDIA driver",
+		},
+		xidLabels,
+	)
+
+	// XIDLastSeen is the time an XID error was last logged, with the same
+	// labels as XIDErrorsTotal.
+	XIDLastSeen = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gpu_xid_last_seen_timestamp_seconds",
+			Help: "Unix time an XID error was last logged",
+		},
+		xidLabels,
+	)
 )
 
+// xidLabels are the labels of the XID error metrics.
+var xidLabels = []string{"gpu_uuid", "pci_bus_id", "xid", "severity", "category"}
+
 // RecordRequest records metrics for a completed request.
 func RecordRequest(tool, status string, durationSeconds float64) {
 	RequestsTotal.WithLabelValues(tool, status).Inc()
@@ -104,3 +141,17 @@ func SetCircuitState(node string, state int) {
 func RecordGatewayRequest(node, transport, status string, durationSeconds float64) {
 	GatewayRequestDuration.WithLabelValues(node, transport, status).Observe(durationSeconds)
 }
+
+// RecordCacheRequest counts a gateway tool call served by the response cache
+// with the given result ("hit", "miss" or "shared").
+func RecordCacheRequest(tool, result string) {
+	GatewayCacheRequests.WithLabelValues(tool, result).Inc()
+}
+
+// RecordXIDError counts an XID error on a GPU and sets its last-seen time
+// to at.
+func RecordXIDError(gpuUUID, pciBusID string, xid int, severity, category string, at time.Time) {
+	labels := []string{gpuUUID, pciBusID, strconv.Itoa(xid), severity, category}
+	XIDErrorsTotal.WithLabelValues(labels...).Inc()
+	XIDLastSeen.WithLabelValues(labels...).Set(float64(at.Unix()))
+}
@@ -5,6 +5,7 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
@@ -143,3 +144,32 @@ func TestGatewayRequestDuration_LabelCardinality(t *testing.T) {
 		RecordGatewayRequest("node-11", "http", "success", 0.1)
 	})
 }
+
+func TestRecordCacheRequest(t *testing.T) {
+	GatewayCacheRequests.Reset()
+
+	RecordCacheRequest("get_gpu_inventory", "miss")
+	RecordCacheRequest("get_gpu_inventory", "hit")
+	RecordCacheRequest("get_gpu_inventory", "hit")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(
+		GatewayCacheRequests.WithLabelValues("get_gpu_inventory", "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(
+		GatewayCacheRequests.WithLabelValues("get_gpu_inventory", "miss")))
+}
+
+func TestRecordXIDError(t *testing.T) {
+	XIDErrorsTotal.Reset()
+	XIDLastSeen.Reset()
+
+	first := time.Unix(1768471200, 0)
+	RecordXIDError("GPU-1", "0000:01:00.0", 79, "fatal", "hardware", first)
+	RecordXIDError("GPU-1", "0000:01:00.0", 79, "fatal", "hardware", first.Add(time.Minute))
+	RecordXIDError("GPU-2", "0000:02:00.0", 31, "warning", "application", first)
+
+	labels := []string{"GPU-1", "0000:01:00.0", "79", "fatal", "hardware"}
+	assert.Equal(t, 2.0, testutil.ToFloat64(XIDErrorsTotal.WithLabelValues(labels...)))
+	assert.Equal(t, float64(first.Add(time.Minute).Unix()),
+		testutil.ToFloat64(XIDLastSeen.WithLabelValues(labels...)))
+	assert.Equal(t, 2, testutil.CollectAndCount(XIDErrorsTotal))
+}
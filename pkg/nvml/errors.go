@@ -46,4 +46,8 @@ var (
 
 	// ErrContextCancelled indicates the operation was cancelled via context.
 	ErrContextCancelled = errors.New("context cancelled")
+
+	// ErrNotRecorded indicates a replayed snapshot has no result for the
+	// call, because it was never made while recording.
+	ErrNotRecorded = errors.New("call not recorded in snapshot")
 )
@@ -0,0 +1,401 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvml
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Fixture describes a set of mock GPUs loaded from a YAML or JSON file.
+// Each device starts from the default mock A100 profile; only the fields
+// set in the fixture are overridden, so a fixture lists just what differs
+// (a hot GPU, an ECC storm, a different model).
+type Fixture struct {
+	// DriverVersion is the NVIDIA driver version (default "575.57.08")
+	DriverVersion string `json:"driver_version,omitempty"`
+	// CUDAVersion is the CUDA driver version (default "12.9")
+	CUDAVersion string `json:"cuda_version,omitempty"`
+	// Errors maps Interface methods (e.g., "GetDeviceCount") to the NVML
+	// return code they fail with (e.g., "ERROR_UNKNOWN")
+	Errors map[string]string `json:"errors,omitempty"`
+	// Devices are the GPUs on the node, in index order
+	Devices []FixtureDevice `json:"devices"`
+}
+
+// FixtureDevice describes a single mock GPU. Nil fields keep the default
+// mock value.
+type FixtureDevice struct {
+	Name              *string `json:"name,omitempty"`
+	UUID              *string `json:"uuid,omitempty"`
+	PCIBusID          *string `json:"pci_bus_id,omitempty"`
+	ComputeCapability *string `json:"compute_capability,omitempty"`
+
+	MemoryTotalBytes *uint64 `json:"memory_total_bytes,omitempty"`
+	MemoryUsedBytes  *uint64 `json:"memory_used_bytes,omitempty"`
+
+	Temperature         *uint32 `json:"temperature,omitempty"`
+	TemperatureSlowdown *uint32 `json:"temperature_slowdown,omitempty"`
+	TemperatureShutdown *uint32 `json:"temperature_shutdown,omitempty"`
+
+	PowerUsageMW *uint32 `json:"power_usage_mw,omitempty"`
+	PowerLimitMW *uint32 `json:"power_limit_mw,omitempty"`
+
+	GPUUtil    *uint32 `json:"gpu_util,omitempty"`
+	MemoryUtil *uint32 `json:"memory_util,omitempty"`
+
+	SMClockMHz     *uint32 `json:"sm_clock_mhz,omitempty"`
+	MemoryClockMHz *uint32 `json:"memory_clock_mhz,omitempty"`
+
+	// ThrottleReasons is a bitmask of ThrottleReason constants
+	ThrottleReasons *uint64 `json:"throttle_reasons,omitempty"`
+
+	ECC          *FixtureECC          `json:"ecc,omitempty"`
+	PCIe         *FixturePCIe         `json:"pcie,omitempty"`
+	MIGProfiles  []string             `json:"mig_profiles,omitempty"`
+	Processes    []FixtureProcess     `json:"processes,omitempty"`
+	NvLinks      []FixtureNvLink      `json:"nvlinks,omitempty"`
+	RetiredPages *FixtureRetiredPages `json:"retired_pages,omitempty"`
+	RowRemapping *FixtureRowRemapping `json:"row_remapping,omitempty"`
+
+	// Errors maps Device methods (e.g., "GetTemperature") to the NVML
+	// return code they fail with (e.g., "ERROR_GPU_IS_LOST").
+	// "GetDeviceByIndex" makes the device lookup itself fail.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// FixtureECC describes the ECC mode and aggregate error counts.
+type FixtureECC struct {
+	Enabled       *bool   `json:"enabled,omitempty"`
+	Correctable   *uint64 `json:"correctable,omitempty"`
+	Uncorrectable *uint64 `json:"uncorrectable,omitempty"`
+}
+
+// FixturePCIe describes the PCIe link. Zero fields keep the default.
+type FixturePCIe struct {
+	Gen      uint32 `json:"gen,omitempty"`
+	MaxGen   uint32 `json:"max_gen,omitempty"`
+	Width    uint32 `json:"width,omitempty"`
+	MaxWidth uint32 `json:"max_width,omitempty"`
+	TxKBps   uint32 `json:"tx_kbps,omitempty"`
+	RxKBps   uint32 `json:"rx_kbps,omitempty"`
+	Replays  uint32 `json:"replays,omitempty"`
+}
+
+// FixtureProcess describes a process running on the GPU.
+type FixtureProcess struct {
+	PID             uint32 `json:"pid"`
+	Name            string `json:"name"`
+	Type            string `json:"type,omitempty"`
+	UsedMemoryBytes uint64 `json:"used_memory_bytes"`
+}
+
+// FixtureNvLink describes a single NVLink.
+type FixtureNvLink struct {
+	Link           int    `json:"link"`
+	Active         bool   `json:"active"`
+	Version        uint32 `json:"version,omitempty"`
+	RemoteType     string `json:"remote_type,omitempty"`
+	RemotePCIBusID string `json:"remote_pci_bus_id,omitempty"`
+	RemoteUUID     string `json:"remote_uuid,omitempty"`
+	CRCErrors      uint64 `json:"crc_errors,omitempty"`
+	ReplayErrors   uint64 `json:"replay_errors,omitempty"`
+	RecoveryErrors uint64 `json:"recovery_errors,omitempty"`
+}
+
+// FixtureRetiredPages switches the GPU to page retirement (pre-Ampere).
+type FixtureRetiredPages struct {
+	MultipleSingleBitECC int  `json:"multiple_single_bit_ecc"`
+	DoubleBitECC         int  `json:"double_bit_ecc"`
+	Pending              bool `json:"pending"`
+}
+
+// FixtureRowRemapping describes the row remapping state.
+type FixtureRowRemapping struct {
+	CorrectableRows   int                   `json:"correctable_rows"`
+	UncorrectableRows int                   `json:"uncorrectable_rows"`
+	Pending           bool                  `json:"pending"`
+	Failed            bool                  `json:"failed"`
+	Histogram         *RowRemapperHistogram `json:"histogram,omitempty"`
+}
+
+// nvmlReturnMessages maps NVML return code names to the message
+// nvmlErrorString reports for them.
+var nvmlReturnMessages = map[string]string{
+	"ERROR_UNINITIALIZED":           "Uninitialized",
+	"ERROR_INVALID_ARGUMENT":        "Invalid Argument",
+	"ERROR_NOT_SUPPORTED":           "Not Supported",
+	"ERROR_NO_PERMISSION":           "Insufficient Permissions",
+	"ERROR_NOT_FOUND":               "Not Found",
+	"ERROR_INSUFFICIENT_POWER":      "Insufficient External Power",
+	"ERROR_DRIVER_NOT_LOADED":       "Driver Not Loaded",
+	"ERROR_TIMEOUT":                 "Timeout",
+	"ERROR_IRQ_ISSUE":               "Interrupt Request Issue",
+	"ERROR_LIBRARY_NOT_FOUND":       "NVML Shared Library Not Found",
+	"ERROR_CORRUPTED_INFOROM":       "Corrupted infoROM",
+	"ERROR_GPU_IS_LOST":             "GPU is lost",
+	"ERROR_RESET_REQUIRED":          "GPU requires restart",
+	"ERROR_LIB_RM_VERSION_MISMATCH": "RM has detected an NVML/RM version mismatch",
+	"ERROR_IN_USE":                  "In use by another client",
+	"ERROR_MEMORY":                  "Insufficient Memory",
+	"ERROR_NO_DATA":                 "No data",
+	"ERROR_UNKNOWN":                 "Unknown Error",
+}
+
+// LoadFixture reads a fixture from a YAML or JSON file. Unknown fields are
+// rejected so typos do not silently fall back to defaults.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := yaml.UnmarshalStrict(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// NewMockFromFixture creates a mock NVML implementation with the devices,
+// driver version and injected errors described by the fixture.
+func NewMockFromFixture(fixture *Fixture) (*Mock, error) {
+	if len(fixture.Devices) == 0 {
+		return nil, fmt.Errorf("fixture has no devices")
+	}
+
+	var opts []MockOption
+	for method, code := range fixture.Errors {
+		injected, err := fixtureError(interfaceMethods, method, code)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithMockError(method, injected))
+	}
+
+	m := NewMock(len(fixture.Devices), opts...)
+	if fixture.DriverVersion != "" {
+		m.driverVersion = fixture.DriverVersion
+	}
+	if fixture.CUDAVersion != "" {
+		m.cudaVersion = fixture.CUDAVersion
+	}
+
+	for i := range fixture.Devices {
+		if err := fixture.Devices[i].apply(m.devices[i]); err != nil {
+			return nil, fmt.Errorf("device %d: %w", i, err)
+		}
+	}
+
+	// Identities may have changed, so rewire the generated NVLinks unless
+	// the fixture lists them explicitly
+	for i, fd := range fixture.Devices {
+		if fd.NvLinks == nil {
+			m.devices[i].nvlinks = generateMockNvLinks(i, m.devices, newMockConfig())
+		}
+	}
+
+	return m, nil
+}
+
+// NewMockFromFixtureFile loads a fixture file and creates a mock NVML
+// implementation from it.
+func NewMockFromFixtureFile(path string) (*Mock, error) {
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMockFromFixture(fixture)
+}
+
+// apply overrides the mock device fields set in the fixture. Within ecc
+// and pcie only the set fields change; other sections are replaced as a
+// whole. An empty error code removes a previously injected error.
+func (fd *FixtureDevice) apply(d *MockDevice) error {
+	override(&d.name, fd.Name)
+	override(&d.uuid, fd.UUID)
+	override(&d.computeCapability, fd.ComputeCapability)
+	if fd.PCIBusID != nil {
+		d.busID = *fd.PCIBusID
+		var domain, bus, device, function uint32
+		if _, err := fmt.Sscanf(d.busID, "%x:%x:%x.%x",
+			&domain, &bus, &device, &function); err == nil {
+			d.domain, d.bus, d.device = domain, bus, device
+		}
+	}
+
+	override(&d.memoryTotal, fd.MemoryTotalBytes)
+	override(&d.memoryUsed, fd.MemoryUsedBytes)
+	override(&d.temperature, fd.Temperature)
+	override(&d.tempSlowdown, fd.TemperatureSlowdown)
+	override(&d.tempShutdown, fd.TemperatureShutdown)
+	override(&d.powerUsage, fd.PowerUsageMW)
+	override(&d.powerLimit, fd.PowerLimitMW)
+	override(&d.gpuUtil, fd.GPUUtil)
+	override(&d.memoryUtil, fd.MemoryUtil)
+	override(&d.smClock, fd.SMClockMHz)
+	override(&d.memClock, fd.MemoryClockMHz)
+	override(&d.throttleReasons, fd.ThrottleReasons)
+
+	if fd.ECC != nil {
+		override(&d.eccEnabled, fd.ECC.Enabled)
+		override(&d.eccCorrectable, fd.ECC.Correctable)
+		override(&d.eccUncorrectable, fd.ECC.Uncorrectable)
+	}
+
+	if p := fd.PCIe; p != nil {
+		for _, f := range []struct {
+			dst *uint32
+			src uint32
+		}{
+			{&d.pcieGen, p.Gen}, {&d.pcieMaxGen, p.MaxGen},
+			{&d.pcieWidth, p.Width}, {&d.pcieMaxWidth, p.MaxWidth},
+			{&d.pcieTxKBs, p.TxKBps}, {&d.pcieRxKBs, p.RxKBps},
+			{&d.pcieReplays, p.Replays},
+		} {
+			if f.src != 0 {
+				*f.dst = f.src
+			}
+		}
+	}
+
+	if fd.Processes != nil {
+		d.processes = make([]ProcessInfo, len(fd.Processes))
+		for j, p := range fd.Processes {
+			d.processes[j] = ProcessInfo{
+				PID:           p.PID,
+				Name:          p.Name,
+				Type:          p.Type,
+				UsedGPUMemory: p.UsedMemoryBytes,
+			}
+			if d.processes[j].Type == "" {
+				d.processes[j].Type = ProcessTypeCompute
+			}
+		}
+	}
+
+	if fd.MIGProfiles != nil {
+		d.migEnabled = len(fd.MIGProfiles) > 0
+		d.migDevices = generateMockMigDevices(d.index, fd.MIGProfiles)
+	}
+
+	if rp := fd.RetiredPages; rp != nil {
+		d.retiredPages = RetiredPagesInfo{
+			Supported:            true,
+			MultipleSingleBitECC: rp.MultipleSingleBitECC,
+			DoubleBitECC:         rp.DoubleBitECC,
+			Pending:              rp.Pending,
+		}
+		d.remappedRows = RemappedRowsInfo{}
+	}
+	if rr := fd.RowRemapping; rr != nil {
+		histogram := d.remappedRows.Histogram
+		if !d.remappedRows.Supported {
+			histogram = RowRemapperHistogram{Max: mockRowRemapBanks}
+		}
+		if rr.Histogram != nil {
+			histogram = *rr.Histogram
+		}
+		d.remappedRows = RemappedRowsInfo{
+			Supported:         true,
+			CorrectableRows:   rr.CorrectableRows,
+			UncorrectableRows: rr.UncorrectableRows,
+			Pending:           rr.Pending,
+			Failed:            rr.Failed,
+			Histogram:         histogram,
+		}
+	}
+
+	if fd.NvLinks != nil {
+		d.nvlinks = make([]NvLinkInfo, len(fd.NvLinks))
+		for j, link := range fd.NvLinks {
+			d.nvlinks[j] = NvLinkInfo(link)
+		}
+	}
+
+	if len(fd.Errors) > 0 {
+		// Copy so devices sharing the map are not affected
+		errs := make(map[string]error, len(d.errors)+len(fd.Errors))
+		maps.Copy(errs, d.errors)
+		for method, code := range fd.Errors {
+			if code == "" {
+				if !deviceMethods[method] {
+					return fmt.Errorf("unknown method %q in fixture errors", method)
+				}
+				delete(errs, method)
+				continue
+			}
+			injected, err := fixtureError(deviceMethods, method, code)
+			if err != nil {
+				return err
+			}
+			errs[method] = injected
+		}
+		d.errors = errs
+	}
+
+	return nil
+}
+
+// fixtureError converts an NVML return code name (e.g., "ERROR_GPU_IS_LOST"
+// or "NVML_ERROR_GPU_IS_LOST") injected for a method into the error the
+// method returns. err reports an invalid fixture.
+func fixtureError(
+	methods map[string]bool,
+	method, code string,
+) (injected error, err error) {
+	if !methods[method] {
+		return nil, fmt.Errorf("unknown method %q in fixture errors", method)
+	}
+
+	name := strings.TrimPrefix(strings.ToUpper(code), "NVML_")
+	if !strings.HasPrefix(name, "ERROR_") {
+		name = "ERROR_" + name
+	}
+	message, ok := nvmlReturnMessages[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown NVML error %q for method %s", code, method)
+	}
+
+	switch name {
+	case "ERROR_NOT_SUPPORTED":
+		return fmt.Errorf("%s: %s: %w", method, message, ErrNotSupported), nil
+	case "ERROR_UNINITIALIZED":
+		return fmt.Errorf("%s: %s: %w", method, message, ErrNotInitialized), nil
+	}
+	return fmt.Errorf("%s: %s", method, message), nil
+}
+
+// interfaceMethods and deviceMethods are the method names errors can be
+// injected into. A device can also fail its own GetDeviceByIndex lookup.
+var (
+	interfaceMethods = methodNames(reflect.TypeOf((*Interface)(nil)).Elem())
+	deviceMethods    = methodNames(reflect.TypeOf((*Device)(nil)).Elem(),
+		"GetDeviceByIndex")
+)
+
+// methodNames returns the method names of an interface type plus any extra
+// names.
+func methodNames(t reflect.Type, extra ...string) map[string]bool {
+	names := make(map[string]bool, t.NumMethod()+len(extra))
+	for i := 0; i < t.NumMethod(); i++ {
+		names[t.Method(i).Name] = true
+	}
+	for _, name := range extra {
+		names[name] = true
+	}
+	return names
+}
+
+// override sets dst to *src if the fixture set the field.
+func override[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
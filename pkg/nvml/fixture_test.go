@@ -0,0 +1,300 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvml
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFixture writes a fixture file into a temporary directory.
+func writeFixture(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewMockFromFixtureFile(t *testing.T) {
+	ctx := context.Background()
+	path := writeFixture(t, "node.yaml", `
+driver_version: "550.54.15"
+cuda_version: "12.4"
+devices:
+  - {}
+  - name: "NVIDIA H100 80GB HBM3"
+    uuid: "GPU-h100"
+    pci_bus_id: "0000:18:00.0"
+    compute_capability: "9.0"
+    temperature: 86
+    throttle_reasons: 0x60
+    ecc: {enabled: true, correctable: 25000, uncorrectable: 2}
+    pcie: {gen: 5, max_gen: 5, width: 8}
+    mig_profiles: ["3g.40gb"]
+    processes:
+      - {pid: 4242, name: "torchrun", used_memory_bytes: 1024}
+    row_remapping:
+      uncorrectable_rows: 2
+      pending: true
+      histogram: {max: 639, none: 1}
+`)
+
+	mock, err := NewMockFromFixtureFile(path)
+	require.NoError(t, err)
+
+	driver, err := mock.GetDriverVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "550.54.15", driver)
+	cuda, err := mock.GetCudaDriverVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "12.4", cuda)
+
+	count, err := mock.GetDeviceCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	t.Run("unset fields keep mock defaults", func(t *testing.T) {
+		device, err := mock.GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		name, err := device.GetName(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, name, "A100")
+		temp, err := device.GetTemperature(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(45), temp)
+	})
+
+	t.Run("fixture fields override defaults", func(t *testing.T) {
+		device, err := mock.GetDeviceByIndex(ctx, 1)
+		require.NoError(t, err)
+
+		name, err := device.GetName(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "NVIDIA H100 80GB HBM3", name)
+		cc, err := device.GetCudaComputeCapability(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "9.0", cc)
+
+		pci, err := device.GetPCIInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "0000:18:00.0", pci.BusID)
+		assert.Equal(t, uint32(0x18), pci.Bus)
+		assert.Equal(t, uint32(5), pci.CurrentLinkGen)
+		assert.Equal(t, uint32(8), pci.CurrentLinkWidth)
+		assert.Equal(t, uint32(16), pci.MaxLinkWidth)
+
+		temp, err := device.GetTemperature(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(86), temp)
+
+		reasons, err := device.GetCurrentClocksThrottleReasons(ctx)
+		require.NoError(t, err)
+		assert.Equal(t,
+			ThrottleReasonSwThermalSlowdown|ThrottleReasonHwThermalSlowdown,
+			reasons)
+
+		correctable, err := device.GetTotalEccErrors(ctx, EccErrorCorrectable)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(25000), correctable)
+
+		migEnabled, _, err := device.GetMigMode(ctx)
+		require.NoError(t, err)
+		assert.True(t, migEnabled)
+
+		processes, err := device.GetRunningProcesses(ctx)
+		require.NoError(t, err)
+		require.Len(t, processes, 1)
+		assert.Equal(t, uint32(4242), processes[0].PID)
+		assert.Equal(t, ProcessTypeCompute, processes[0].Type)
+
+		rows, err := device.GetRemappedRows(ctx)
+		require.NoError(t, err)
+		assert.True(t, rows.Pending)
+		assert.Equal(t, uint32(1), rows.Histogram.None)
+	})
+
+	t.Run("NVLinks are rewired to fixture identities", func(t *testing.T) {
+		device, err := mock.GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		links, err := device.GetNvLinks(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, links)
+		assert.Equal(t, "GPU-h100", links[0].RemoteUUID)
+		assert.Equal(t, "0000:18:00.0", links[0].RemotePCIBusID)
+	})
+}
+
+func TestNewMockFromFixture_Errors(t *testing.T) {
+	ctx := context.Background()
+	path := writeFixture(t, "errors.json", `{
+  "errors": {"GetCudaDriverVersion": "ERROR_UNKNOWN"},
+  "devices": [
+    {"errors": {"GetTemperature": "NVML_ERROR_GPU_IS_LOST",
+                "GetPowerUsage": "NOT_SUPPORTED"}},
+    {"errors": {"GetDeviceByIndex": "ERROR_GPU_IS_LOST"}}
+  ]
+}`)
+
+	mock, err := NewMockFromFixtureFile(path)
+	require.NoError(t, err)
+
+	_, err = mock.GetCudaDriverVersion(ctx)
+	assert.EqualError(t, err, "GetCudaDriverVersion: Unknown Error")
+
+	device, err := mock.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+
+	_, err = device.GetTemperature(ctx)
+	assert.EqualError(t, err, "GetTemperature: GPU is lost")
+
+	_, err = device.GetPowerUsage(ctx)
+	assert.True(t, errors.Is(err, ErrNotSupported))
+
+	// Methods without injected errors are unaffected
+	_, err = device.GetName(ctx)
+	assert.NoError(t, err)
+
+	_, err = mock.GetDeviceByIndex(ctx, 1)
+	assert.EqualError(t, err, "GetDeviceByIndex: GPU is lost")
+}
+
+func TestNewMockFromFixture_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no devices",
+			content: `driver_version: "550.54.15"`,
+			wantErr: "no devices",
+		},
+		{
+			name:    "unknown field",
+			content: "devices:\n  - temprature: 90\n",
+			wantErr: "temprature",
+		},
+		{
+			name:    "unknown interface method",
+			content: "errors: {GetDevice: ERROR_UNKNOWN}\ndevices: [{}]\n",
+			wantErr: `unknown method "GetDevice"`,
+		},
+		{
+			name:    "unknown device method",
+			content: "devices:\n  - errors: {GetTemp: ERROR_UNKNOWN}\n",
+			wantErr: `device 0: unknown method "GetTemp"`,
+		},
+		{
+			name:    "unknown NVML error",
+			content: "devices:\n  - errors: {GetTemperature: ERROR_ON_FIRE}\n",
+			wantErr: `unknown NVML error "ERROR_ON_FIRE"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFixture(t, "fixture.yaml", tt.content)
+
+			_, err := NewMockFromFixtureFile(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMock_UpdateDevice(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMock(2, WithMockDeviceError(0, "GetPowerUsage", ErrNotSupported))
+
+	before, err := mock.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+
+	temp := uint32(88)
+	correctable := uint64(1200)
+	require.NoError(t, mock.UpdateDevice(0, &FixtureDevice{
+		Temperature: &temp,
+		ECC:         &FixtureECC{Correctable: &correctable},
+		Errors: map[string]string{
+			"GetPowerUsage":    "",
+			"GetDeviceByIndex": "ERROR_GPU_IS_LOST",
+		},
+	}))
+
+	t.Run("earlier handles keep their state", func(t *testing.T) {
+		got, err := before.GetTemperature(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(45), got)
+		_, err = before.GetPowerUsage(ctx)
+		assert.ErrorIs(t, err, ErrNotSupported)
+	})
+
+	t.Run("new lookups see the patch", func(t *testing.T) {
+		_, err := mock.GetDeviceByIndex(ctx, 0)
+		assert.EqualError(t, err, "GetDeviceByIndex: GPU is lost")
+
+		require.NoError(t, mock.UpdateDevice(0, &FixtureDevice{
+			Errors: map[string]string{"GetDeviceByIndex": ""},
+		}))
+		device, err := mock.GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		got, err := device.GetTemperature(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(88), got)
+		_, err = device.GetPowerUsage(ctx)
+		assert.NoError(t, err)
+
+		// Fields outside the patch are unchanged
+		enabled, _, err := device.GetEccMode(ctx)
+		require.NoError(t, err)
+		assert.True(t, enabled)
+		ecc, err := device.GetTotalEccErrors(ctx, EccErrorCorrectable)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1200), ecc)
+	})
+
+	t.Run("other devices are unaffected", func(t *testing.T) {
+		device, err := mock.GetDeviceByIndex(ctx, 1)
+		require.NoError(t, err)
+		got, err := device.GetTemperature(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(50), got)
+	})
+
+	t.Run("invalid patches are rejected", func(t *testing.T) {
+		assert.ErrorIs(t, mock.UpdateDevice(5, &FixtureDevice{}), ErrInvalidDevice)
+		err := mock.UpdateDevice(1, &FixtureDevice{
+			Errors: map[string]string{"GetTemp": ""},
+		})
+		assert.ErrorContains(t, err, `device 1: unknown method "GetTemp"`)
+	})
+}
+
+func TestLoadFixture_MissingFile(t *testing.T) {
+	_, err := LoadFixture(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read fixture")
+}
+
+func TestExampleFixtures(t *testing.T) {
+	paths, err := filepath.Glob("../../examples/fixtures/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			_, err := NewMockFromFixtureFile(path)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -44,7 +44,8 @@ type Device interface {
 	// GetUUID returns the globally unique identifier of the device.
 	GetUUID(ctx context.Context) (string, error)
 
-	// GetPCIInfo returns PCI bus information for the device.
+	// GetPCIInfo returns PCI bus information and the PCIe link
+	// generation and width for the device.
 	GetPCIInfo(ctx context.Context) (*PCIInfo, error)
 
 	// GetMemoryInfo returns memory usage information.
@@ -90,6 +91,48 @@ type Device interface {
 	// GetCudaComputeCapability returns the CUDA compute capability as a
 	// string (e.g., "7.5" for Turing, "8.0" for Ampere).
 	GetCudaComputeCapability(ctx context.Context) (string, error)
+
+	// GetPCIeThroughput returns the PCIe throughput in KB/s over the last
+	// 20ms. counter: PCIeUtilTXBytes (0) or PCIeUtilRXBytes (1).
+	// If not supported, returns 0 with no error.
+	GetPCIeThroughput(ctx context.Context, counter int) (uint32, error)
+
+	// GetPCIeReplayCounter returns the number of PCIe replays (link-level
+	// retransmissions) since the driver was loaded.
+	// If not supported, returns 0 with no error.
+	GetPCIeReplayCounter(ctx context.Context) (uint32, error)
+
+	// GetRunningProcesses returns the compute and graphics processes
+	// currently running on the device. A process with both contexts is
+	// reported once with type ProcessTypeComputeGraphics.
+	GetRunningProcesses(ctx context.Context) ([]ProcessInfo, error)
+
+	// GetMigMode returns whether MIG mode is currently enabled and whether
+	// it is pending activation (takes effect after a GPU reset).
+	// If MIG is not supported, returns (false, false, nil).
+	GetMigMode(ctx context.Context) (current, pending bool, err error)
+
+	// GetMigDevices returns the MIG devices (GPU instance and compute
+	// instance pairs) configured on the device. Returns an empty slice if
+	// MIG is disabled or not supported.
+	GetMigDevices(ctx context.Context) ([]MigDeviceInfo, error)
+
+	// GetNvLinks returns the state, remote peer and error counters of each
+	// NVLink on the device. Returns an empty slice if NVLink is not
+	// supported (e.g., PCIe GPUs without a bridge).
+	GetNvLinks(ctx context.Context) ([]NvLinkInfo, error)
+
+	// GetRetiredPages returns the number of memory pages retired by cause
+	// and whether a retirement is pending. Page retirement is used by GPUs
+	// before Ampere; if not supported, returns a RetiredPagesInfo with
+	// Supported set to false and no error.
+	GetRetiredPages(ctx context.Context) (*RetiredPagesInfo, error)
+
+	// GetRemappedRows returns the row remapping state and the row remapper
+	// histogram. Row remapping replaces page retirement on Ampere and newer
+	// GPUs; if not supported, returns a RemappedRowsInfo with Supported set
+	// to false and no error.
+	GetRemappedRows(ctx context.Context) (*RemappedRowsInfo, error)
 }
 
 // PCIInfo contains PCI bus information for a device.
@@ -102,6 +145,16 @@ type PCIInfo struct {
 	Bus uint32
 	// Device is the PCI device number
 	Device uint32
+	// CurrentLinkGen is the current PCIe link generation (e.g., 4 for
+	// Gen4). Zero if not reported.
+	CurrentLinkGen uint32
+	// MaxLinkGen is the maximum PCIe link generation supported by both
+	// the GPU and the slot it is installed in
+	MaxLinkGen uint32
+	// CurrentLinkWidth is the current number of PCIe lanes (e.g., 16)
+	CurrentLinkWidth uint32
+	// MaxLinkWidth is the maximum number of PCIe lanes
+	MaxLinkWidth uint32
 }
 
 // MemoryInfo contains memory usage information.
@@ -122,6 +175,60 @@ type Utilization struct {
 	Memory uint32
 }
 
+// ProcessInfo describes a process with an active context on a device.
+type ProcessInfo struct {
+	// PID is the process ID in the host PID namespace
+	PID uint32
+	// Name is the process name, empty if it could not be resolved
+	Name string
+	// Type is one of the ProcessType constants
+	Type string
+	// UsedGPUMemory is the GPU memory used by the process in bytes.
+	// Zero if the driver does not expose per-process accounting.
+	UsedGPUMemory uint64
+}
+
+// MigDeviceInfo describes a MIG device: a compute instance within a GPU
+// instance on a MIG-enabled parent GPU.
+type MigDeviceInfo struct {
+	// Index is the MIG device index on the parent GPU
+	Index int `json:"index"`
+	// UUID is the MIG device UUID (e.g., "MIG-4f6c...")
+	UUID string `json:"uuid"`
+	// GPUInstanceID is the GPU instance the device belongs to
+	GPUInstanceID uint32 `json:"gpu_instance_id"`
+	// ComputeInstanceID is the compute instance within the GPU instance
+	ComputeInstanceID uint32 `json:"compute_instance_id"`
+	// Profile is the MIG profile name (e.g., "1g.5gb", "1c.3g.20gb")
+	Profile string `json:"profile"`
+	// MemoryBytes is the memory available to the device in bytes
+	MemoryBytes uint64 `json:"memory_bytes"`
+	// MultiprocessorCount is the number of SMs available to the device
+	MultiprocessorCount uint32 `json:"multiprocessor_count"`
+}
+
+// NvLinkInfo describes a single NVLink on a device.
+type NvLinkInfo struct {
+	// Link is the link index on the device
+	Link int
+	// Active is true if the link is up
+	Active bool
+	// Version is the NVLink version (e.g., 3 for A100, 4 for H100)
+	Version uint32
+	// RemoteType is one of the NvLinkRemote constants
+	RemoteType string
+	// RemotePCIBusID is the PCI bus ID of the device at the other end
+	RemotePCIBusID string
+	// RemoteUUID is the UUID of the peer GPU, empty for NVSwitch peers
+	RemoteUUID string
+	// CRCErrors is the number of flit and data CRC errors on the link
+	CRCErrors uint64
+	// ReplayErrors is the number of packets retransmitted on the link
+	ReplayErrors uint64
+	// RecoveryErrors is the number of times the link was retrained
+	RecoveryErrors uint64
+}
+
 // GPUInfo is a consolidated view of GPU device information.
 type GPUInfo struct {
 	Index             int    `json:"index"`
@@ -147,6 +254,9 @@ type GPUInfo struct {
 
 	// ECC status (nil if not supported)
 	ECC *ECCSpec `json:"ecc,omitempty"`
+
+	// MIG status (nil if MIG is not enabled or pending)
+	MIG *MIGSpec `json:"mig,omitempty"`
 }
 
 // MemorySpec contains memory capacity information.
@@ -188,6 +298,21 @@ type ECCSpec struct {
 	UncorrectableErrors uint64 `json:"uncorrectable_errors"`
 }
 
+// MIGSpec contains MIG mode and the configured MIG devices.
+type MIGSpec struct {
+	Enabled        bool            `json:"enabled"`
+	PendingEnabled bool            `json:"pending_enabled"`
+	Devices        []MigDeviceInfo `json:"devices"`
+}
+
+// NvLinkRemote constants describe the device at the other end of an NVLink.
+const (
+	NvLinkRemoteGPU     = "gpu"
+	NvLinkRemoteSwitch  = "nvswitch"
+	NvLinkRemoteIBMNPU  = "ibmnpu"
+	NvLinkRemoteUnknown = "unknown"
+)
+
 // ThrottleReason constants for interpreting GetCurrentClocksThrottleReasons.
 // These are bitmask values that can be combined.
 const (
@@ -213,6 +338,71 @@ const (
 	TempThresholdSlowdown = 1 // GPU slowdown/throttle temperature
 )
 
+// PCIeThroughputCounter constants for GetPCIeThroughput.
+const (
+	PCIeUtilTXBytes = 0 // Data transmitted by the GPU
+	PCIeUtilRXBytes = 1 // Data received by the GPU
+)
+
+// ProcessType constants for ProcessInfo.Type.
+const (
+	ProcessTypeCompute         = "compute"          // CUDA context
+	ProcessTypeGraphics        = "graphics"         // Graphics context
+	ProcessTypeComputeGraphics = "compute+graphics" // Both contexts
+)
+
+// RetiredPagesInfo contains the dynamic page retirement state of a device.
+// Retired pages are blacklisted by the driver so the faulty memory is no
+// longer used.
+type RetiredPagesInfo struct {
+	// Supported is false if the device does not use page retirement
+	Supported bool
+	// MultipleSingleBitECC is the number of pages retired after repeated
+	// single-bit ECC errors
+	MultipleSingleBitECC int
+	// DoubleBitECC is the number of pages retired after a double-bit ECC
+	// error
+	DoubleBitECC int
+	// Pending is true if pages are waiting to be retired. Retirement takes
+	// effect after the GPU is reset or the driver is reloaded.
+	Pending bool
+}
+
+// RemappedRowsInfo contains the row remapping state of a device. Rows with
+// ECC errors are remapped to spare rows in the same memory bank.
+type RemappedRowsInfo struct {
+	// Supported is false if the device does not use row remapping
+	Supported bool
+	// CorrectableRows is the number of rows remapped after correctable
+	// errors
+	CorrectableRows int
+	// UncorrectableRows is the number of rows remapped after uncorrectable
+	// errors
+	UncorrectableRows int
+	// Pending is true if a remapping is waiting for a GPU reset
+	Pending bool
+	// Failed is true if a remapping failed because the bank had no spare
+	// rows left
+	Failed bool
+	// Histogram reports the remaining spare rows per memory bank
+	Histogram RowRemapperHistogram
+}
+
+// RowRemapperHistogram counts memory banks by the number of spare rows
+// still available for remapping.
+type RowRemapperHistogram struct {
+	// Max is the number of banks with all spare rows available
+	Max uint32
+	// High is the number of banks with most spare rows available
+	High uint32
+	// Partial is the number of banks with some spare rows available
+	Partial uint32
+	// Low is the number of banks with one spare row available
+	Low uint32
+	// None is the number of banks with no spare rows available
+	None uint32
+}
+
 // EccErrorType constants for GetTotalEccErrors.
 const (
 	EccErrorCorrectable   = 0 // Single-bit correctable errors
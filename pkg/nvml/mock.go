@@ -6,6 +6,7 @@ package nvml
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
 // Mock is a mock implementation of the NVML Interface for testing.
@@ -14,6 +15,13 @@ type Mock struct {
 	UnimplementedInterface // Embedded for forward compatibility
 	deviceCount            int
 	devices                []*MockDevice
+	driverVersion          string
+	cudaVersion            string
+	errors                 map[string]error
+
+	// mu guards devices against UpdateDevice. Published devices are never
+	// modified, so device methods need no locking.
+	mu sync.RWMutex
 }
 
 // Compile-time interface satisfaction checks.
@@ -22,16 +30,207 @@ var (
 	_ Device    = (*MockDevice)(nil)
 )
 
+// defaultMockProcessesPerDevice is the number of fake processes generated
+// per mock GPU unless overridden with WithMockProcessesPerDevice.
+const defaultMockProcessesPerDevice = 1
+
+// mockProcessNames are cycled through when generating fake processes.
+var mockProcessNames = []string{"python3", "torchrun", "tritonserver"}
+
+// defaultMockMIGProfiles is the MIG geometry used by WithMockMIG when no
+// profiles are given. It fills all seven compute slices of an A100-40GB.
+var defaultMockMIGProfiles = []string{"3g.20gb", "2g.10gb", "1g.5gb", "1g.5gb"}
+
+// mockSMsPerSlice is the number of SMs per MIG compute slice on an A100.
+const mockSMsPerSlice = 14
+
+// mockNvLinksPerDevice is the number of NVLinks on an A100 SXM4.
+const mockNvLinksPerDevice = 12
+
+// mockNvLinkVersion is the NVLink generation of an A100.
+const mockNvLinkVersion = 3
+
+// mockRowRemapBanks is the number of HBM banks reported in the row remapper
+// histogram of an A100.
+const mockRowRemapBanks = 640
+
+// MockOption configures a Mock.
+type MockOption func(*mockConfig)
+
+// mockConfig holds the settings applied by MockOption functions.
+type mockConfig struct {
+	processesPerDevice int
+	processes          map[int][]ProcessInfo
+	migProfiles        map[int][]string
+	nvlinkDown         map[mockLink]bool
+	nvlinkErrors       map[mockLink]NvLinkInfo
+	pcieLinks          map[int]mockPCIeLink
+	pcieReplays        map[int]uint32
+	retiredPages       map[int]RetiredPagesInfo
+	remappedRows       map[int]RemappedRowsInfo
+	remapHistograms    map[int]RowRemapperHistogram
+	errors             map[string]error
+	deviceErrors       map[int]map[string]error
+}
+
+// mockPCIeLink is the trained PCIe link generation and width of a mock GPU.
+type mockPCIeLink struct {
+	gen   uint32
+	width uint32
+}
+
+// mockLink identifies an NVLink on a mock GPU.
+type mockLink struct {
+	device int
+	link   int
+}
+
+// WithMockProcessesPerDevice sets the number of fake compute processes
+// generated on each mock GPU. Zero leaves the GPUs idle. The processes
+// split the device's used memory evenly.
+func WithMockProcessesPerDevice(n int) MockOption {
+	return func(c *mockConfig) {
+		if n >= 0 {
+			c.processesPerDevice = n
+		}
+	}
+}
+
+// WithMockDeviceProcesses sets the exact process list reported by the mock
+// GPU at the given index, overriding the generated processes.
+func WithMockDeviceProcesses(idx int, processes []ProcessInfo) MockOption {
+	return func(c *mockConfig) {
+		c.processes[idx] = processes
+	}
+}
+
+// WithMockMIG enables MIG mode on the mock GPU at the given index and
+// creates one MIG device per GPU instance profile (e.g., "1g.5gb").
+// Without profiles, the GPU is split into 3g.20gb, 2g.10gb and 2x 1g.5gb.
+func WithMockMIG(idx int, profiles ...string) MockOption {
+	return func(c *mockConfig) {
+		if len(profiles) == 0 {
+			profiles = defaultMockMIGProfiles
+		}
+		c.migProfiles[idx] = profiles
+	}
+}
+
+// WithMockNvLinkDown marks an NVLink on the mock GPU at the given index as
+// inactive.
+func WithMockNvLinkDown(idx, link int) MockOption {
+	return func(c *mockConfig) {
+		c.nvlinkDown[mockLink{device: idx, link: link}] = true
+	}
+}
+
+// WithMockNvLinkErrors sets the CRC, replay and recovery error counters of
+// an NVLink on the mock GPU at the given index.
+func WithMockNvLinkErrors(idx, link int, crc, replay, recovery uint64) MockOption {
+	return func(c *mockConfig) {
+		c.nvlinkErrors[mockLink{device: idx, link: link}] = NvLinkInfo{
+			CRCErrors:      crc,
+			ReplayErrors:   replay,
+			RecoveryErrors: recovery,
+		}
+	}
+}
+
+// WithMockPCIeLink sets the current PCIe link generation and width of the
+// mock GPU at the given index. Values below Gen4 x16 simulate a downtrained
+// link.
+func WithMockPCIeLink(idx int, gen, width uint32) MockOption {
+	return func(c *mockConfig) {
+		c.pcieLinks[idx] = mockPCIeLink{gen: gen, width: width}
+	}
+}
+
+// WithMockPCIeReplays sets the PCIe replay counter of the mock GPU at the
+// given index.
+func WithMockPCIeReplays(idx int, replays uint32) MockOption {
+	return func(c *mockConfig) {
+		c.pcieReplays[idx] = replays
+	}
+}
+
+// WithMockRetiredPages turns the mock GPU at the given index into a
+// pre-Ampere GPU that uses page retirement instead of row remapping, with
+// the given retired page counts and pending status.
+func WithMockRetiredPages(idx, singleBit, doubleBit int, pending bool) MockOption {
+	return func(c *mockConfig) {
+		c.retiredPages[idx] = RetiredPagesInfo{
+			Supported:            true,
+			MultipleSingleBitECC: singleBit,
+			DoubleBitECC:         doubleBit,
+			Pending:              pending,
+		}
+	}
+}
+
+// WithMockRemappedRows sets the remapped row counts and the pending and
+// failed flags of the mock GPU at the given index.
+func WithMockRemappedRows(
+	idx, correctable, uncorrectable int,
+	pending, failed bool,
+) MockOption {
+	return func(c *mockConfig) {
+		c.remappedRows[idx] = RemappedRowsInfo{
+			CorrectableRows:   correctable,
+			UncorrectableRows: uncorrectable,
+			Pending:           pending,
+			Failed:            failed,
+		}
+	}
+}
+
+// WithMockRowRemapperHistogram sets the row remapper histogram of the mock
+// GPU at the given index. By default all banks have every spare row
+// available.
+func WithMockRowRemapperHistogram(idx int, hist RowRemapperHistogram) MockOption {
+	return func(c *mockConfig) {
+		c.remapHistograms[idx] = hist
+	}
+}
+
+// WithMockError makes the named Interface method (e.g., "GetDeviceCount")
+// of the mock return err.
+func WithMockError(method string, err error) MockOption {
+	return func(c *mockConfig) {
+		c.errors[method] = err
+	}
+}
+
+// WithMockDeviceError makes the named Device method (e.g.,
+// "GetTemperature") of the mock GPU at the given index return err. The
+// method "GetDeviceByIndex" makes the device lookup itself fail, as for a
+// GPU that fell off the bus.
+func WithMockDeviceError(idx int, method string, err error) MockOption {
+	return func(c *mockConfig) {
+		if c.deviceErrors[idx] == nil {
+			c.deviceErrors[idx] = make(map[string]error)
+		}
+		c.deviceErrors[idx][method] = err
+	}
+}
+
 // NewMock creates a new mock NVML implementation with the specified
 // number of fake GPU devices.
-func NewMock(deviceCount int) *Mock {
+func NewMock(deviceCount int, opts ...MockOption) *Mock {
 	if deviceCount <= 0 {
 		deviceCount = 2 // Default to 2 fake GPUs
 	}
 
+	cfg := newMockConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	m := &Mock{
-		deviceCount: deviceCount,
-		devices:     make([]*MockDevice, deviceCount),
+		deviceCount:   deviceCount,
+		devices:       make([]*MockDevice, deviceCount),
+		driverVersion: "575.57.08",
+		cudaVersion:   "12.9",
+		errors:        cfg.errors,
 	}
 
 	// Create fake devices
@@ -61,14 +260,166 @@ func NewMock(deviceCount int) *Mock {
 			memClock:         1215,
 			tempShutdown:     90,
 			tempSlowdown:     82,
+
+			computeCapability: "8.0", // Ampere
+
+			// PCIe Gen4 x16 link
+			pcieGen:      4,
+			pcieMaxGen:   4,
+			pcieWidth:    16,
+			pcieMaxWidth: 16,
+			pcieTxKBs:    1200000 + uint32(i*100000),
+			pcieRxKBs:    2400000 + uint32(i*100000),
+			pcieReplays:  cfg.pcieReplays[i],
+
+			errors: cfg.deviceErrors[i],
+		}
+
+		if link, ok := cfg.pcieLinks[i]; ok {
+			m.devices[i].pcieGen = link.gen
+			m.devices[i].pcieWidth = link.width
 		}
+
+		m.devices[i].retiredPages, m.devices[i].remappedRows =
+			mockMemoryRepair(i, cfg)
+
+		if processes, ok := cfg.processes[i]; ok {
+			m.devices[i].processes = processes
+		} else {
+			m.devices[i].processes = generateMockProcesses(
+				i, cfg.processesPerDevice, m.devices[i].memoryUsed)
+		}
+
+		if profiles, ok := cfg.migProfiles[i]; ok {
+			m.devices[i].migEnabled = true
+			m.devices[i].migDevices = generateMockMigDevices(i, profiles)
+		}
+	}
+
+	// NVLinks connect each GPU to its peers, so they are wired up once all
+	// devices exist
+	for i := 0; i < deviceCount; i++ {
+		m.devices[i].nvlinks = generateMockNvLinks(i, m.devices, cfg)
 	}
 
 	return m
 }
 
+// newMockConfig returns a mockConfig with the default settings.
+func newMockConfig() *mockConfig {
+	return &mockConfig{
+		processesPerDevice: defaultMockProcessesPerDevice,
+		processes:          make(map[int][]ProcessInfo),
+		migProfiles:        make(map[int][]string),
+		nvlinkDown:         make(map[mockLink]bool),
+		nvlinkErrors:       make(map[mockLink]NvLinkInfo),
+		pcieLinks:          make(map[int]mockPCIeLink),
+		pcieReplays:        make(map[int]uint32),
+		retiredPages:       make(map[int]RetiredPagesInfo),
+		remappedRows:       make(map[int]RemappedRowsInfo),
+		remapHistograms:    make(map[int]RowRemapperHistogram),
+		errors:             make(map[string]error),
+		deviceErrors:       make(map[int]map[string]error),
+	}
+}
+
+// mockMemoryRepair returns the page retirement and row remapping state of
+// the device at idx. A100 GPUs use row remapping unless WithMockRetiredPages
+// simulates an older GPU.
+func mockMemoryRepair(idx int, cfg *mockConfig) (RetiredPagesInfo, RemappedRowsInfo) {
+	if pages, ok := cfg.retiredPages[idx]; ok {
+		return pages, RemappedRowsInfo{}
+	}
+
+	rows := cfg.remappedRows[idx]
+	rows.Supported = true
+	rows.Histogram = RowRemapperHistogram{Max: mockRowRemapBanks}
+	if hist, ok := cfg.remapHistograms[idx]; ok {
+		rows.Histogram = hist
+	}
+	return RetiredPagesInfo{}, rows
+}
+
+// generateMockNvLinks creates the NVLinks of the device at idx, spreading
+// them round-robin across the other GPUs. A single GPU has no NVLinks.
+func generateMockNvLinks(
+	idx int,
+	devices []*MockDevice,
+	cfg *mockConfig,
+) []NvLinkInfo {
+	if len(devices) < 2 {
+		return nil
+	}
+
+	links := make([]NvLinkInfo, mockNvLinksPerDevice)
+	for j := range links {
+		key := mockLink{device: idx, link: j}
+		links[j] = cfg.nvlinkErrors[key]
+		links[j].Link = j
+		links[j].Version = mockNvLinkVersion
+		links[j].Active = !cfg.nvlinkDown[key]
+		if !links[j].Active {
+			continue
+		}
+
+		peer := devices[(idx+1+j%(len(devices)-1))%len(devices)]
+		links[j].RemoteType = NvLinkRemoteGPU
+		links[j].RemotePCIBusID = peer.busID
+		links[j].RemoteUUID = peer.uuid
+	}
+	return links
+}
+
+// generateMockMigDevices creates one MIG device per GPU instance profile
+// on the device at idx. Memory and SM count are derived from the profile
+// name ("<slices>g.<memory>gb").
+func generateMockMigDevices(idx int, profiles []string) []MigDeviceInfo {
+	devices := make([]MigDeviceInfo, len(profiles))
+	for j, profile := range profiles {
+		var slices, memoryGB int
+		if _, err := fmt.Sscanf(profile, "%dg.%dgb",
+			&slices, &memoryGB); err != nil {
+			slices, memoryGB = 0, 0
+		}
+		devices[j] = MigDeviceInfo{
+			Index: j,
+			UUID: fmt.Sprintf("MIG-%08d-%04d-0000-0000-%012d",
+				idx, j, idx),
+			GPUInstanceID:       uint32(j + 1),
+			ComputeInstanceID:   0,
+			Profile:             profile,
+			MemoryBytes:         uint64(memoryGB) * 1024 * 1024 * 1024,
+			MultiprocessorCount: uint32(slices * mockSMsPerSlice),
+		}
+	}
+	return devices
+}
+
+// generateMockProcesses creates n deterministic compute processes for the
+// device at idx, splitting usedMemory evenly between them.
+func generateMockProcesses(idx, n int, usedMemory uint64) []ProcessInfo {
+	if n <= 0 {
+		return nil
+	}
+
+	processes := make([]ProcessInfo, n)
+	for j := 0; j < n; j++ {
+		processes[j] = ProcessInfo{
+			PID:           uint32(10000 + idx*100 + j),
+			Name:          mockProcessNames[(idx+j)%len(mockProcessNames)],
+			Type:          ProcessTypeCompute,
+			UsedGPUMemory: usedMemory / uint64(n),
+		}
+	}
+	return processes
+}
+
 // Init initializes the mock NVML library (no-op).
 func (m *Mock) Init(ctx context.Context) error {
+	if err := m.injectedError("Init"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -79,26 +430,75 @@ func (m *Mock) Shutdown(ctx context.Context) error {
 
 // GetDeviceCount returns the number of mock GPU devices.
 func (m *Mock) GetDeviceCount(ctx context.Context) (int, error) {
+	if err := m.injectedError("GetDeviceCount"); err != nil {
+		return 0, err
+	}
+
 	return m.deviceCount, nil
 }
 
 // GetDeviceByIndex returns a mock Device handle for the given index.
 func (m *Mock) GetDeviceByIndex(ctx context.Context, idx int) (Device, error) {
+	if err := m.injectedError("GetDeviceByIndex"); err != nil {
+		return nil, err
+	}
+
 	if idx < 0 || idx >= m.deviceCount {
 		return nil, fmt.Errorf("%w: %d (count: %d)",
 			ErrInvalidDevice, idx, m.deviceCount)
 	}
-	return m.devices[idx], nil
+
+	m.mu.RLock()
+	device := m.devices[idx]
+	m.mu.RUnlock()
+
+	if err := device.injectedError("GetDeviceByIndex"); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// UpdateDevice applies a fixture patch to the mock GPU at the given index;
+// only the fields set in the patch change. The device is replaced rather
+// than modified, so handles returned earlier keep their previous state.
+func (m *Mock) UpdateDevice(idx int, patch *FixtureDevice) error {
+	if idx < 0 || idx >= m.deviceCount {
+		return fmt.Errorf("%w: %d (count: %d)",
+			ErrInvalidDevice, idx, m.deviceCount)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := *m.devices[idx]
+	if err := patch.apply(&updated); err != nil {
+		return fmt.Errorf("device %d: %w", idx, err)
+	}
+	m.devices[idx] = &updated
+	return nil
 }
 
 // GetDriverVersion returns the mock NVIDIA driver version.
 func (m *Mock) GetDriverVersion(ctx context.Context) (string, error) {
-	return "575.57.08", nil
+	if err := m.injectedError("GetDriverVersion"); err != nil {
+		return "", err
+	}
+
+	return m.driverVersion, nil
 }
 
 // GetCudaDriverVersion returns the mock CUDA driver version.
 func (m *Mock) GetCudaDriverVersion(ctx context.Context) (string, error) {
-	return "12.9", nil
+	if err := m.injectedError("GetCudaDriverVersion"); err != nil {
+		return "", err
+	}
+
+	return m.cudaVersion, nil
+}
+
+// injectedError returns the error configured for the named method, if any.
+func (m *Mock) injectedError(method string) error {
+	return m.errors[method]
 }
 
 // MockDevice is a mock implementation of the Device interface.
@@ -128,30 +528,83 @@ type MockDevice struct {
 	memClock         uint32
 	tempShutdown     uint32
 	tempSlowdown     uint32
+
+	computeCapability string
+
+	// PCIe link
+	pcieGen      uint32
+	pcieMaxGen   uint32
+	pcieWidth    uint32
+	pcieMaxWidth uint32
+	pcieTxKBs    uint32
+	pcieRxKBs    uint32
+	pcieReplays  uint32
+
+	// Running processes
+	processes []ProcessInfo
+
+	// MIG configuration
+	migEnabled bool
+	migDevices []MigDeviceInfo
+
+	// NVLink topology and error counters
+	nvlinks []NvLinkInfo
+
+	// Memory repair state
+	retiredPages RetiredPagesInfo
+	remappedRows RemappedRowsInfo
+
+	// Errors returned by individual methods
+	errors map[string]error
+}
+
+// injectedError returns the error configured for the named method, if any.
+func (d *MockDevice) injectedError(method string) error {
+	return d.errors[method]
 }
 
 // GetName returns the mock device name.
 func (d *MockDevice) GetName(ctx context.Context) (string, error) {
+	if err := d.injectedError("GetName"); err != nil {
+		return "", err
+	}
+
 	return d.name, nil
 }
 
 // GetUUID returns the mock device UUID.
 func (d *MockDevice) GetUUID(ctx context.Context) (string, error) {
+	if err := d.injectedError("GetUUID"); err != nil {
+		return "", err
+	}
+
 	return d.uuid, nil
 }
 
 // GetPCIInfo returns mock PCI information.
 func (d *MockDevice) GetPCIInfo(ctx context.Context) (*PCIInfo, error) {
+	if err := d.injectedError("GetPCIInfo"); err != nil {
+		return nil, err
+	}
+
 	return &PCIInfo{
-		BusID:  d.busID,
-		Domain: d.domain,
-		Bus:    d.bus,
-		Device: d.device,
+		BusID:            d.busID,
+		Domain:           d.domain,
+		Bus:              d.bus,
+		Device:           d.device,
+		CurrentLinkGen:   d.pcieGen,
+		MaxLinkGen:       d.pcieMaxGen,
+		CurrentLinkWidth: d.pcieWidth,
+		MaxLinkWidth:     d.pcieMaxWidth,
 	}, nil
 }
 
 // GetMemoryInfo returns mock memory usage information.
 func (d *MockDevice) GetMemoryInfo(ctx context.Context) (*MemoryInfo, error) {
+	if err := d.injectedError("GetMemoryInfo"); err != nil {
+		return nil, err
+	}
+
 	return &MemoryInfo{
 		Total: d.memoryTotal,
 		Used:  d.memoryUsed,
@@ -161,11 +614,19 @@ func (d *MockDevice) GetMemoryInfo(ctx context.Context) (*MemoryInfo, error) {
 
 // GetTemperature returns the mock temperature.
 func (d *MockDevice) GetTemperature(ctx context.Context) (uint32, error) {
+	if err := d.injectedError("GetTemperature"); err != nil {
+		return 0, err
+	}
+
 	return d.temperature, nil
 }
 
 // GetPowerUsage returns the mock power usage.
 func (d *MockDevice) GetPowerUsage(ctx context.Context) (uint32, error) {
+	if err := d.injectedError("GetPowerUsage"); err != nil {
+		return 0, err
+	}
+
 	return d.powerUsage, nil
 }
 
@@ -173,6 +634,10 @@ func (d *MockDevice) GetPowerUsage(ctx context.Context) (uint32, error) {
 func (d *MockDevice) GetUtilizationRates(
 	ctx context.Context,
 ) (*Utilization, error) {
+	if err := d.injectedError("GetUtilizationRates"); err != nil {
+		return nil, err
+	}
+
 	return &Utilization{
 		GPU:    d.gpuUtil,
 		Memory: d.memoryUtil,
@@ -183,6 +648,10 @@ func (d *MockDevice) GetUtilizationRates(
 func (d *MockDevice) GetPowerManagementLimit(
 	ctx context.Context,
 ) (uint32, error) {
+	if err := d.injectedError("GetPowerManagementLimit"); err != nil {
+		return 0, err
+	}
+
 	return d.powerLimit, nil
 }
 
@@ -190,6 +659,10 @@ func (d *MockDevice) GetPowerManagementLimit(
 func (d *MockDevice) GetEccMode(
 	ctx context.Context,
 ) (current, pending bool, err error) {
+	if err := d.injectedError("GetEccMode"); err != nil {
+		return false, false, err
+	}
+
 	return d.eccEnabled, d.eccEnabled, nil
 }
 
@@ -198,6 +671,10 @@ func (d *MockDevice) GetTotalEccErrors(
 	ctx context.Context,
 	errorType int,
 ) (uint64, error) {
+	if err := d.injectedError("GetTotalEccErrors"); err != nil {
+		return 0, err
+	}
+
 	if errorType == EccErrorCorrectable {
 		return d.eccCorrectable, nil
 	}
@@ -208,6 +685,10 @@ func (d *MockDevice) GetTotalEccErrors(
 func (d *MockDevice) GetCurrentClocksThrottleReasons(
 	ctx context.Context,
 ) (uint64, error) {
+	if err := d.injectedError("GetCurrentClocksThrottleReasons"); err != nil {
+		return 0, err
+	}
+
 	return d.throttleReasons, nil
 }
 
@@ -216,6 +697,10 @@ func (d *MockDevice) GetClockInfo(
 	ctx context.Context,
 	clockType int,
 ) (uint32, error) {
+	if err := d.injectedError("GetClockInfo"); err != nil {
+		return 0, err
+	}
+
 	if clockType == ClockGraphics {
 		return d.smClock, nil
 	}
@@ -227,6 +712,10 @@ func (d *MockDevice) GetTemperatureThreshold(
 	ctx context.Context,
 	thresholdType int,
 ) (uint32, error) {
+	if err := d.injectedError("GetTemperatureThreshold"); err != nil {
+		return 0, err
+	}
+
 	if thresholdType == TempThresholdShutdown {
 		return d.tempShutdown, nil
 	}
@@ -234,9 +723,110 @@ func (d *MockDevice) GetTemperatureThreshold(
 }
 
 // GetCudaComputeCapability returns the mock CUDA compute capability.
-// Returns "8.0" for mock A100 GPUs.
 func (d *MockDevice) GetCudaComputeCapability(
 	ctx context.Context,
 ) (string, error) {
-	return "8.0", nil // A100 compute capability
+	if err := d.injectedError("GetCudaComputeCapability"); err != nil {
+		return "", err
+	}
+
+	return d.computeCapability, nil
+}
+
+// GetPCIeThroughput returns the mock PCIe throughput for the given counter.
+func (d *MockDevice) GetPCIeThroughput(
+	ctx context.Context,
+	counter int,
+) (uint32, error) {
+	if err := d.injectedError("GetPCIeThroughput"); err != nil {
+		return 0, err
+	}
+
+	if counter == PCIeUtilTXBytes {
+		return d.pcieTxKBs, nil
+	}
+	return d.pcieRxKBs, nil
+}
+
+// GetPCIeReplayCounter returns the mock PCIe replay counter.
+func (d *MockDevice) GetPCIeReplayCounter(ctx context.Context) (uint32, error) {
+	if err := d.injectedError("GetPCIeReplayCounter"); err != nil {
+		return 0, err
+	}
+
+	return d.pcieReplays, nil
+}
+
+// GetRunningProcesses returns the mock processes running on the device.
+func (d *MockDevice) GetRunningProcesses(
+	ctx context.Context,
+) ([]ProcessInfo, error) {
+	if err := d.injectedError("GetRunningProcesses"); err != nil {
+		return nil, err
+	}
+
+	processes := make([]ProcessInfo, len(d.processes))
+	copy(processes, d.processes)
+	return processes, nil
+}
+
+// GetMigMode returns the mock MIG mode.
+func (d *MockDevice) GetMigMode(
+	ctx context.Context,
+) (current, pending bool, err error) {
+	if err := d.injectedError("GetMigMode"); err != nil {
+		return false, false, err
+	}
+
+	return d.migEnabled, d.migEnabled, nil
+}
+
+// GetMigDevices returns the mock MIG devices.
+func (d *MockDevice) GetMigDevices(
+	ctx context.Context,
+) ([]MigDeviceInfo, error) {
+	if err := d.injectedError("GetMigDevices"); err != nil {
+		return nil, err
+	}
+
+	devices := make([]MigDeviceInfo, len(d.migDevices))
+	copy(devices, d.migDevices)
+	return devices, nil
+}
+
+// GetNvLinks returns the mock NVLinks of the device.
+func (d *MockDevice) GetNvLinks(
+	ctx context.Context,
+) ([]NvLinkInfo, error) {
+	if err := d.injectedError("GetNvLinks"); err != nil {
+		return nil, err
+	}
+
+	links := make([]NvLinkInfo, len(d.nvlinks))
+	copy(links, d.nvlinks)
+	return links, nil
+}
+
+// GetRetiredPages returns the mock page retirement state.
+func (d *MockDevice) GetRetiredPages(
+	ctx context.Context,
+) (*RetiredPagesInfo, error) {
+	if err := d.injectedError("GetRetiredPages"); err != nil {
+		return nil, err
+	}
+
+	pages := d.retiredPages
+	return &pages, nil
+}
+
+// GetRemappedRows returns the mock row remapping state.
+func (d *MockDevice) GetRemappedRows(
+	ctx context.Context,
+) (*RemappedRowsInfo, error) {
+	if err := d.injectedError("GetRemappedRows"); err != nil {
+		return nil, err
+	}
+
+	rows := d.remappedRows
+	return &rows, nil
 }
@@ -5,6 +5,7 @@ package nvml
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -307,3 +308,342 @@ func TestMockDevice_GetTemperatureThreshold(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, uint32(82), slowdown)
 }
+
+func TestMockDevice_GetRunningProcesses(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		opts      []MockOption
+		wantCount int
+		wantPID   uint32
+	}{
+		{
+			name:      "default one process per device",
+			wantCount: 1,
+			wantPID:   10000,
+		},
+		{
+			name:      "idle devices",
+			opts:      []MockOption{WithMockProcessesPerDevice(0)},
+			wantCount: 0,
+		},
+		{
+			name:      "multiple processes",
+			opts:      []MockOption{WithMockProcessesPerDevice(3)},
+			wantCount: 3,
+			wantPID:   10000,
+		},
+		{
+			name: "explicit processes",
+			opts: []MockOption{WithMockDeviceProcesses(0, []ProcessInfo{
+				{PID: 4242, Name: "vllm", Type: ProcessTypeCompute,
+					UsedGPUMemory: 1024},
+			})},
+			wantCount: 1,
+			wantPID:   4242,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMock(2, tt.opts...)
+
+			device, err := mock.GetDeviceByIndex(ctx, 0)
+			require.NoError(t, err)
+
+			processes, err := device.GetRunningProcesses(ctx)
+			require.NoError(t, err)
+			require.Len(t, processes, tt.wantCount)
+			if tt.wantCount == 0 {
+				return
+			}
+			assert.Equal(t, tt.wantPID, processes[0].PID)
+			assert.NotEmpty(t, processes[0].Name)
+			assert.Equal(t, ProcessTypeCompute, processes[0].Type)
+		})
+	}
+}
+
+func TestMockDevice_GetRunningProcessesMemory(t *testing.T) {
+	mock := NewMock(1, WithMockProcessesPerDevice(4))
+	ctx := context.Background()
+
+	device, err := mock.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+
+	mem, err := device.GetMemoryInfo(ctx)
+	require.NoError(t, err)
+
+	processes, err := device.GetRunningProcesses(ctx)
+	require.NoError(t, err)
+
+	var total uint64
+	for _, p := range processes {
+		total += p.UsedGPUMemory
+	}
+	assert.Equal(t, mem.Used, total)
+}
+
+func TestMockDevice_MIG(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		opts        []MockOption
+		wantEnabled bool
+		wantCount   int
+	}{
+		{
+			name:        "MIG disabled by default",
+			wantEnabled: false,
+			wantCount:   0,
+		},
+		{
+			name:        "default A100 geometry",
+			opts:        []MockOption{WithMockMIG(0)},
+			wantEnabled: true,
+			wantCount:   4,
+		},
+		{
+			name:        "custom profiles",
+			opts:        []MockOption{WithMockMIG(0, "1g.5gb", "1g.5gb")},
+			wantEnabled: true,
+			wantCount:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMock(2, tt.opts...)
+
+			device, err := mock.GetDeviceByIndex(ctx, 0)
+			require.NoError(t, err)
+
+			current, pending, err := device.GetMigMode(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEnabled, current)
+			assert.Equal(t, tt.wantEnabled, pending)
+
+			migDevices, err := device.GetMigDevices(ctx)
+			require.NoError(t, err)
+			assert.Len(t, migDevices, tt.wantCount)
+
+			// MIG is only configured on the requested device
+			other, err := mock.GetDeviceByIndex(ctx, 1)
+			require.NoError(t, err)
+			current, _, err = other.GetMigMode(ctx)
+			require.NoError(t, err)
+			assert.False(t, current)
+		})
+	}
+}
+
+func TestMockDevice_MIGDeviceDetails(t *testing.T) {
+	mock := NewMock(1, WithMockMIG(0, "3g.20gb", "1g.5gb"))
+	ctx := context.Background()
+
+	device, err := mock.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+
+	migDevices, err := device.GetMigDevices(ctx)
+	require.NoError(t, err)
+	require.Len(t, migDevices, 2)
+
+	assert.Equal(t, "3g.20gb", migDevices[0].Profile)
+	assert.Equal(t, uint64(20*1024*1024*1024), migDevices[0].MemoryBytes)
+	assert.Equal(t, uint32(42), migDevices[0].MultiprocessorCount)
+	assert.Equal(t, uint32(1), migDevices[0].GPUInstanceID)
+	assert.Contains(t, migDevices[0].UUID, "MIG-")
+
+	assert.Equal(t, "1g.5gb", migDevices[1].Profile)
+	assert.Equal(t, uint32(2), migDevices[1].GPUInstanceID)
+	assert.NotEqual(t, migDevices[0].UUID, migDevices[1].UUID)
+}
+
+func TestMockDevice_GetNvLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single GPU has no NVLinks", func(t *testing.T) {
+		device, err := NewMock(1).GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		links, err := device.GetNvLinks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("links connect to peer GPUs", func(t *testing.T) {
+		mock := NewMock(4)
+		device, err := mock.GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		links, err := device.GetNvLinks(ctx)
+		require.NoError(t, err)
+		require.Len(t, links, 12)
+
+		peers := make(map[string]bool)
+		for i, link := range links {
+			assert.Equal(t, i, link.Link)
+			assert.True(t, link.Active)
+			assert.Equal(t, uint32(3), link.Version)
+			assert.Equal(t, NvLinkRemoteGPU, link.RemoteType)
+			assert.NotEmpty(t, link.RemotePCIBusID)
+			assert.NotEqual(t, "0000:01:00.0", link.RemotePCIBusID)
+			assert.Zero(t, link.CRCErrors)
+			peers[link.RemoteUUID] = true
+		}
+		assert.Len(t, peers, 3)
+	})
+
+	t.Run("down and erroring links", func(t *testing.T) {
+		mock := NewMock(2,
+			WithMockNvLinkDown(1, 4),
+			WithMockNvLinkErrors(1, 7, 12, 340, 2),
+		)
+		device, err := mock.GetDeviceByIndex(ctx, 1)
+		require.NoError(t, err)
+
+		links, err := device.GetNvLinks(ctx)
+		require.NoError(t, err)
+		require.Len(t, links, 12)
+
+		assert.False(t, links[4].Active)
+		assert.Empty(t, links[4].RemoteUUID)
+
+		assert.True(t, links[7].Active)
+		assert.Equal(t, uint64(12), links[7].CRCErrors)
+		assert.Equal(t, uint64(340), links[7].ReplayErrors)
+		assert.Equal(t, uint64(2), links[7].RecoveryErrors)
+		assert.Equal(t, "GPU-00000000-0000-0000-0000-000000000000",
+			links[7].RemoteUUID)
+	})
+}
+
+func TestMockDevice_PCIe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to healthy Gen4 x16 link", func(t *testing.T) {
+		device, err := NewMock(1).GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		pci, err := device.GetPCIInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(4), pci.CurrentLinkGen)
+		assert.Equal(t, uint32(4), pci.MaxLinkGen)
+		assert.Equal(t, uint32(16), pci.CurrentLinkWidth)
+		assert.Equal(t, uint32(16), pci.MaxLinkWidth)
+
+		tx, err := device.GetPCIeThroughput(ctx, PCIeUtilTXBytes)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1200000), tx)
+
+		rx, err := device.GetPCIeThroughput(ctx, PCIeUtilRXBytes)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2400000), rx)
+
+		replays, err := device.GetPCIeReplayCounter(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, replays)
+	})
+
+	t.Run("downtrained link with replays", func(t *testing.T) {
+		mock := NewMock(2, WithMockPCIeLink(1, 3, 8), WithMockPCIeReplays(1, 250))
+		device, err := mock.GetDeviceByIndex(ctx, 1)
+		require.NoError(t, err)
+
+		pci, err := device.GetPCIInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), pci.CurrentLinkGen)
+		assert.Equal(t, uint32(4), pci.MaxLinkGen)
+		assert.Equal(t, uint32(8), pci.CurrentLinkWidth)
+		assert.Equal(t, uint32(16), pci.MaxLinkWidth)
+
+		replays, err := device.GetPCIeReplayCounter(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(250), replays)
+	})
+}
+
+func TestMockDevice_MemoryRepair(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to row remapping with full capacity", func(t *testing.T) {
+		device, err := NewMock(1).GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		pages, err := device.GetRetiredPages(ctx)
+		require.NoError(t, err)
+		assert.False(t, pages.Supported)
+
+		rows, err := device.GetRemappedRows(ctx)
+		require.NoError(t, err)
+		assert.True(t, rows.Supported)
+		assert.Zero(t, rows.CorrectableRows)
+		assert.Zero(t, rows.UncorrectableRows)
+		assert.False(t, rows.Pending)
+		assert.False(t, rows.Failed)
+		assert.Equal(t, uint32(640), rows.Histogram.Max)
+		assert.Zero(t, rows.Histogram.None)
+	})
+
+	t.Run("remapped rows and histogram", func(t *testing.T) {
+		hist := RowRemapperHistogram{Max: 600, Partial: 38, Low: 1, None: 1}
+		mock := NewMock(2,
+			WithMockRemappedRows(1, 2, 1, true, false),
+			WithMockRowRemapperHistogram(1, hist),
+		)
+		device, err := mock.GetDeviceByIndex(ctx, 1)
+		require.NoError(t, err)
+
+		rows, err := device.GetRemappedRows(ctx)
+		require.NoError(t, err)
+		assert.True(t, rows.Supported)
+		assert.Equal(t, 2, rows.CorrectableRows)
+		assert.Equal(t, 1, rows.UncorrectableRows)
+		assert.True(t, rows.Pending)
+		assert.False(t, rows.Failed)
+		assert.Equal(t, hist, rows.Histogram)
+	})
+
+	t.Run("page retirement replaces row remapping", func(t *testing.T) {
+		mock := NewMock(1, WithMockRetiredPages(0, 3, 1, true))
+		device, err := mock.GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+
+		pages, err := device.GetRetiredPages(ctx)
+		require.NoError(t, err)
+		assert.True(t, pages.Supported)
+		assert.Equal(t, 3, pages.MultipleSingleBitECC)
+		assert.Equal(t, 1, pages.DoubleBitECC)
+		assert.True(t, pages.Pending)
+
+		rows, err := device.GetRemappedRows(ctx)
+		require.NoError(t, err)
+		assert.False(t, rows.Supported)
+	})
+}
+
+func TestMock_InjectedErrors(t *testing.T) {
+	ctx := context.Background()
+	errLost := errors.New("GPU is lost")
+
+	mock := NewMock(2,
+		WithMockError("GetDriverVersion", errLost),
+		WithMockDeviceError(0, "GetTemperature", errLost),
+		WithMockDeviceError(1, "GetDeviceByIndex", errLost),
+	)
+
+	_, err := mock.GetDriverVersion(ctx)
+	assert.ErrorIs(t, err, errLost)
+
+	device, err := mock.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+	_, err = device.GetTemperature(ctx)
+	assert.ErrorIs(t, err, errLost)
+	_, err = device.GetPowerUsage(ctx)
+	assert.NoError(t, err)
+
+	_, err = mock.GetDeviceByIndex(ctx, 1)
+	assert.ErrorIs(t, err, errLost)
+}
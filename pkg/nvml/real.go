@@ -9,6 +9,7 @@ package nvml
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
@@ -211,22 +212,40 @@ func (d *RealDevice) GetPCIInfo(ctx context.Context) (*PCIInfo, error) {
 			nvml.ErrorString(ret))
 	}
 
-	// Convert BusIdLegacy byte array to string
-	busID := string(pciInfo.BusIdLegacy[:])
-	// Trim null bytes
+	info := &PCIInfo{
+		BusID:  pciBusID(pciInfo),
+		Domain: pciInfo.Domain,
+		Bus:    pciInfo.Bus,
+		Device: pciInfo.Device,
+	}
+
+	// Link generation and width are not reported for every form factor
+	// (e.g., SXM modules behind a PCIe switch on some drivers), leave them
+	// zero rather than failing the call
+	if gen, ret := d.device.GetCurrPcieLinkGeneration(); ret == nvml.SUCCESS {
+		info.CurrentLinkGen = uint32(gen)
+	}
+	if gen, ret := d.device.GetMaxPcieLinkGeneration(); ret == nvml.SUCCESS {
+		info.MaxLinkGen = uint32(gen)
+	}
+	if width, ret := d.device.GetCurrPcieLinkWidth(); ret == nvml.SUCCESS {
+		info.CurrentLinkWidth = uint32(width)
+	}
+	if width, ret := d.device.GetMaxPcieLinkWidth(); ret == nvml.SUCCESS {
+		info.MaxLinkWidth = uint32(width)
+	}
+
+	return info, nil
+}
+
+// pciBusID converts the NUL-terminated BusIdLegacy byte array to a string.
+func pciBusID(pciInfo nvml.PciInfo) string {
 	for i, b := range pciInfo.BusIdLegacy {
 		if b == 0 {
-			busID = string(pciInfo.BusIdLegacy[:i])
-			break
+			return string(pciInfo.BusIdLegacy[:i])
 		}
 	}
-
-	return &PCIInfo{
-		BusID:  busID,
-		Domain: pciInfo.Domain,
-		Bus:    pciInfo.Bus,
-		Device: pciInfo.Device,
-	}, nil
+	return string(pciInfo.BusIdLegacy[:])
 }
 
 // GetMemoryInfo returns memory usage information.
@@ -430,6 +449,50 @@ func (d *RealDevice) GetTemperatureThreshold(
 	return temp, nil
 }
 
+// GetPCIeThroughput returns the PCIe throughput in KB/s for the given counter.
+func (d *RealDevice) GetPCIeThroughput(
+	ctx context.Context,
+	counter int,
+) (uint32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+	}
+
+	var nvmlCounter nvml.PcieUtilCounter
+	if counter == PCIeUtilTXBytes {
+		nvmlCounter = nvml.PCIE_UTIL_TX_BYTES
+	} else {
+		nvmlCounter = nvml.PCIE_UTIL_RX_BYTES
+	}
+
+	throughput, ret := d.device.GetPcieThroughput(nvmlCounter)
+	if ret != nvml.SUCCESS {
+		if ret == nvml.ERROR_NOT_SUPPORTED {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get PCIe throughput: %s",
+			nvml.ErrorString(ret))
+	}
+	return throughput, nil
+}
+
+// GetPCIeReplayCounter returns the PCIe replay count since driver load.
+func (d *RealDevice) GetPCIeReplayCounter(ctx context.Context) (uint32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+	}
+
+	replays, ret := d.device.GetPcieReplayCounter()
+	if ret != nvml.SUCCESS {
+		if ret == nvml.ERROR_NOT_SUPPORTED {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get PCIe replay counter: %s",
+			nvml.ErrorString(ret))
+	}
+	return uint32(replays), nil
+}
+
 // GetCudaComputeCapability returns the CUDA compute capability as a string.
 func (d *RealDevice) GetCudaComputeCapability(
 	ctx context.Context,
@@ -445,3 +508,333 @@ func (d *RealDevice) GetCudaComputeCapability(
 	}
 	return fmt.Sprintf("%d.%d", major, minor), nil
 }
+
+// GetRunningProcesses returns the compute and graphics processes running on
+// the device. Processes with both contexts are merged into one entry.
+func (d *RealDevice) GetRunningProcesses(
+	ctx context.Context,
+) ([]ProcessInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+	}
+
+	compute, ret := d.device.GetComputeRunningProcesses()
+	if ret != nvml.SUCCESS && ret != nvml.ERROR_NOT_SUPPORTED {
+		return nil, fmt.Errorf("failed to get compute processes: %s",
+			nvml.ErrorString(ret))
+	}
+
+	graphics, ret := d.device.GetGraphicsRunningProcesses()
+	if ret != nvml.SUCCESS && ret != nvml.ERROR_NOT_SUPPORTED {
+		return nil, fmt.Errorf("failed to get graphics processes: %s",
+			nvml.ErrorString(ret))
+	}
+
+	processes := make([]ProcessInfo, 0, len(compute)+len(graphics))
+	byPID := make(map[uint32]int, len(compute)+len(graphics))
+
+	add := func(infos []nvml.ProcessInfo, processType string) {
+		for _, info := range infos {
+			if i, ok := byPID[info.Pid]; ok {
+				processes[i].Type = ProcessTypeComputeGraphics
+				continue
+			}
+			byPID[info.Pid] = len(processes)
+			processes = append(processes, ProcessInfo{
+				PID:           info.Pid,
+				Name:          processName(info.Pid),
+				Type:          processType,
+				UsedGPUMemory: usedGPUMemory(info.UsedGpuMemory),
+			})
+		}
+	}
+	add(compute, ProcessTypeCompute)
+	add(graphics, ProcessTypeGraphics)
+
+	return processes, nil
+}
+
+// processName resolves a PID to its process name. Returns an empty string
+// if the process exited or is not visible to the driver.
+func processName(pid uint32) string {
+	name, ret := nvml.SystemGetProcessName(int(pid))
+	if ret != nvml.SUCCESS {
+		return ""
+	}
+	return name
+}
+
+// usedGPUMemory maps NVML_VALUE_NOT_AVAILABLE to zero. The driver reports
+// it when per-process accounting is unavailable (e.g., without privileges).
+func usedGPUMemory(value uint64) uint64 {
+	if value == ^uint64(0) {
+		return 0
+	}
+	return value
+}
+
+// GetMigMode returns whether MIG mode is currently enabled and pending.
+func (d *RealDevice) GetMigMode(
+	ctx context.Context,
+) (current, pending bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return false, false, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+	}
+
+	curr, pend, ret := d.device.GetMigMode()
+	if ret != nvml.SUCCESS {
+		// MIG not supported is not an error, just return false
+		if ret == nvml.ERROR_NOT_SUPPORTED {
+			return false, false, nil
+		}
+		return false, false, fmt.Errorf("failed to get MIG mode: %s",
+			nvml.ErrorString(ret))
+	}
+	return curr == nvml.DEVICE_MIG_ENABLE, pend == nvml.DEVICE_MIG_ENABLE, nil
+}
+
+// GetMigDevices returns the MIG devices configured on the device.
+func (d *RealDevice) GetMigDevices(
+	ctx context.Context,
+) ([]MigDeviceInfo, error) {
+	current, _, err := d.GetMigMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !current {
+		return []MigDeviceInfo{}, nil
+	}
+
+	count, ret := d.device.GetMaxMigDeviceCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get max MIG device count: %s",
+			nvml.ErrorString(ret))
+	}
+
+	devices := make([]MigDeviceInfo, 0, count)
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+		}
+
+		mig, ret := d.device.GetMigDeviceHandleByIndex(i)
+		if ret == nvml.ERROR_NOT_FOUND {
+			// Slot not populated by the current MIG geometry
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get MIG device %d: %s",
+				i, nvml.ErrorString(ret))
+		}
+
+		info := MigDeviceInfo{Index: i}
+
+		uuid, ret := mig.GetUUID()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get MIG device %d UUID: %s",
+				i, nvml.ErrorString(ret))
+		}
+		info.UUID = uuid
+
+		if id, ret := mig.GetGpuInstanceId(); ret == nvml.SUCCESS {
+			info.GPUInstanceID = uint32(id)
+		}
+		if id, ret := mig.GetComputeInstanceId(); ret == nvml.SUCCESS {
+			info.ComputeInstanceID = uint32(id)
+		}
+		if name, ret := mig.GetName(); ret == nvml.SUCCESS {
+			info.Profile = migProfileFromName(name)
+		}
+		if attrs, ret := mig.GetAttributes(); ret == nvml.SUCCESS {
+			info.MemoryBytes = attrs.MemorySizeMB * 1024 * 1024
+			info.MultiprocessorCount = attrs.MultiprocessorCount
+		}
+
+		devices = append(devices, info)
+	}
+
+	return devices, nil
+}
+
+// migProfileFromName extracts the profile from a MIG device name such as
+// "NVIDIA A100-SXM4-40GB MIG 1g.5gb". Returns the full name if it does not
+// contain a MIG suffix.
+func migProfileFromName(name string) string {
+	if i := strings.LastIndex(name, "MIG "); i >= 0 {
+		return name[i+len("MIG "):]
+	}
+	return name
+}
+
+// GetNvLinks returns the state, remote peer and error counters of each
+// NVLink on the device.
+func (d *RealDevice) GetNvLinks(
+	ctx context.Context,
+) ([]NvLinkInfo, error) {
+	links := make([]NvLinkInfo, 0)
+	for link := 0; link < nvml.NVLINK_MAX_LINKS; link++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+		}
+
+		state, ret := d.device.GetNvLinkState(link)
+		if ret == nvml.ERROR_NOT_SUPPORTED || ret == nvml.ERROR_INVALID_ARGUMENT {
+			// Link not present on this device
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get NVLink %d state: %s",
+				link, nvml.ErrorString(ret))
+		}
+
+		info := NvLinkInfo{
+			Link:   link,
+			Active: state == nvml.FEATURE_ENABLED,
+		}
+
+		if version, ret := d.device.GetNvLinkVersion(link); ret == nvml.SUCCESS {
+			info.Version = version
+		}
+
+		// Remote endpoint is only reported for trained links
+		if info.Active {
+			d.fillNvLinkRemote(link, &info)
+		}
+
+		// Counters persist across link down events, read them regardless
+		info.CRCErrors = d.nvLinkErrorCounter(link, nvml.NVLINK_ERROR_DL_CRC_FLIT) +
+			d.nvLinkErrorCounter(link, nvml.NVLINK_ERROR_DL_CRC_DATA)
+		info.ReplayErrors = d.nvLinkErrorCounter(link, nvml.NVLINK_ERROR_DL_REPLAY)
+		info.RecoveryErrors = d.nvLinkErrorCounter(link, nvml.NVLINK_ERROR_DL_RECOVERY)
+
+		links = append(links, info)
+	}
+
+	return links, nil
+}
+
+// fillNvLinkRemote sets the remote device type, PCI bus ID and, for peer
+// GPUs, UUID of an active link. Fields that cannot be read are left empty.
+func (d *RealDevice) fillNvLinkRemote(link int, info *NvLinkInfo) {
+	info.RemoteType = NvLinkRemoteUnknown
+	if remoteType, ret := d.device.GetNvLinkRemoteDeviceType(link); ret == nvml.SUCCESS {
+		switch remoteType {
+		case nvml.NVLINK_DEVICE_TYPE_GPU:
+			info.RemoteType = NvLinkRemoteGPU
+		case nvml.NVLINK_DEVICE_TYPE_SWITCH:
+			info.RemoteType = NvLinkRemoteSwitch
+		case nvml.NVLINK_DEVICE_TYPE_IBMNPU:
+			info.RemoteType = NvLinkRemoteIBMNPU
+		}
+	}
+
+	pciInfo, ret := d.device.GetNvLinkRemotePciInfo(link)
+	if ret != nvml.SUCCESS {
+		return
+	}
+	info.RemotePCIBusID = pciBusID(pciInfo)
+
+	if info.RemoteType != NvLinkRemoteGPU {
+		return
+	}
+	peer, ret := nvml.DeviceGetHandleByPciBusId(info.RemotePCIBusID)
+	if ret != nvml.SUCCESS {
+		return
+	}
+	if uuid, ret := peer.GetUUID(); ret == nvml.SUCCESS {
+		info.RemoteUUID = uuid
+	}
+}
+
+// nvLinkErrorCounter returns an NVLink error counter, or 0 if the counter
+// is not supported by the device.
+func (d *RealDevice) nvLinkErrorCounter(
+	link int,
+	counter nvml.NvLinkErrorCounter,
+) uint64 {
+	value, ret := d.device.GetNvLinkErrorCounter(link, counter)
+	if ret != nvml.SUCCESS {
+		return 0
+	}
+	return value
+}
+
+// GetRetiredPages returns the retired page counts and pending status.
+func (d *RealDevice) GetRetiredPages(
+	ctx context.Context,
+) (*RetiredPagesInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+	}
+
+	sbePages, ret := d.device.GetRetiredPages(
+		nvml.PAGE_RETIREMENT_CAUSE_MULTIPLE_SINGLE_BIT_ECC_ERRORS)
+	if ret == nvml.ERROR_NOT_SUPPORTED {
+		// Ampere and newer use row remapping instead
+		return &RetiredPagesInfo{}, nil
+	}
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get retired pages: %s",
+			nvml.ErrorString(ret))
+	}
+
+	dbePages, ret := d.device.GetRetiredPages(
+		nvml.PAGE_RETIREMENT_CAUSE_DOUBLE_BIT_ECC_ERROR)
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get retired pages: %s",
+			nvml.ErrorString(ret))
+	}
+
+	pending, ret := d.device.GetRetiredPagesPendingStatus()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get retired pages pending status: %s",
+			nvml.ErrorString(ret))
+	}
+
+	return &RetiredPagesInfo{
+		Supported:            true,
+		MultipleSingleBitECC: len(sbePages),
+		DoubleBitECC:         len(dbePages),
+		Pending:              pending == nvml.FEATURE_ENABLED,
+	}, nil
+}
+
+// GetRemappedRows returns the row remapping state and histogram.
+func (d *RealDevice) GetRemappedRows(
+	ctx context.Context,
+) (*RemappedRowsInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrContextCancelled, err)
+	}
+
+	corrRows, uncRows, pending, failed, ret := d.device.GetRemappedRows()
+	if ret == nvml.ERROR_NOT_SUPPORTED {
+		// GPUs before Ampere use page retirement instead
+		return &RemappedRowsInfo{}, nil
+	}
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get remapped rows: %s",
+			nvml.ErrorString(ret))
+	}
+
+	info := &RemappedRowsInfo{
+		Supported:         true,
+		CorrectableRows:   corrRows,
+		UncorrectableRows: uncRows,
+		Pending:           pending,
+		Failed:            failed,
+	}
+
+	// The histogram requires a newer driver, leave it empty if unavailable
+	if hist, ret := d.device.GetRowRemapperHistogram(); ret == nvml.SUCCESS {
+		info.Histogram = RowRemapperHistogram{
+			Max:     hist.Max,
+			High:    hist.High,
+			Partial: hist.Partial,
+			Low:     hist.Low,
+			None:    hist.None,
+		}
+	}
+
+	return info, nil
+}
@@ -150,3 +150,58 @@ func (d *RealDevice) GetCudaComputeCapability(
 ) (string, error) {
 	return "", ErrCGORequired
 }
+
+// GetRunningProcesses returns an error indicating CGO is required.
+func (d *RealDevice) GetRunningProcesses(
+	ctx context.Context,
+) ([]ProcessInfo, error) {
+	return nil, ErrCGORequired
+}
+
+// GetMigMode returns an error indicating CGO is required.
+func (d *RealDevice) GetMigMode(
+	ctx context.Context,
+) (current, pending bool, err error) {
+	return false, false, ErrCGORequired
+}
+
+// GetMigDevices returns an error indicating CGO is required.
+func (d *RealDevice) GetMigDevices(
+	ctx context.Context,
+) ([]MigDeviceInfo, error) {
+	return nil, ErrCGORequired
+}
+
+// GetNvLinks returns an error indicating CGO is required.
+func (d *RealDevice) GetNvLinks(
+	ctx context.Context,
+) ([]NvLinkInfo, error) {
+	return nil, ErrCGORequired
+}
+
+// GetPCIeThroughput returns an error indicating CGO is required.
+func (d *RealDevice) GetPCIeThroughput(
+	ctx context.Context,
+	counter int,
+) (uint32, error) {
+	return 0, ErrCGORequired
+}
+
+// GetPCIeReplayCounter returns an error indicating CGO is required.
+func (d *RealDevice) GetPCIeReplayCounter(ctx context.Context) (uint32, error) {
+	return 0, ErrCGORequired
+}
+
+// GetRetiredPages returns an error indicating CGO is required.
+func (d *RealDevice) GetRetiredPages(
+	ctx context.Context,
+) (*RetiredPagesInfo, error) {
+	return nil, ErrCGORequired
+}
+
+// GetRemappedRows returns an error indicating CGO is required.
+func (d *RealDevice) GetRemappedRows(
+	ctx context.Context,
+) (*RemappedRowsInfo, error) {
+	return nil, ErrCGORequired
+}
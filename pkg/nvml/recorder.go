@@ -0,0 +1,363 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvml
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Compile-time interface satisfaction checks.
+var (
+	_ Interface = (*Recorder)(nil)
+	_ Device    = (*recordingDevice)(nil)
+)
+
+// Recorder wraps an NVML implementation and records the result of every
+// distinct call, so what a node reported can be replayed elsewhere with
+// NewReplay. Calls are passed through unchanged.
+type Recorder struct {
+	UnimplementedInterface // Embedded for forward compatibility
+	inner                  Interface
+	path                   string
+
+	mu    sync.Mutex
+	calls map[callKey]RecordedCall
+}
+
+// NewRecorder creates a Recorder around inner. The snapshot is written to
+// path by Save and on Shutdown.
+func NewRecorder(inner Interface, path string) *Recorder {
+	return &Recorder{
+		inner: inner,
+		path:  path,
+		calls: make(map[callKey]RecordedCall),
+	}
+}
+
+// Snapshot returns the calls recorded so far.
+func (r *Recorder) Snapshot() *Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := &Snapshot{
+		RecordedAt: time.Now().UTC(),
+		Calls:      make([]RecordedCall, 0, len(r.calls)),
+	}
+	for _, call := range r.calls {
+		snapshot.Calls = append(snapshot.Calls, call)
+	}
+	sortCalls(snapshot.Calls)
+	return snapshot
+}
+
+// Save writes the calls recorded so far to the snapshot file.
+func (r *Recorder) Save() error {
+	snapshot := r.Snapshot()
+	if err := snapshot.Save(r.path); err != nil {
+		return err
+	}
+	klog.V(2).InfoS("saved NVML snapshot",
+		"path", r.path, "calls", len(snapshot.Calls))
+	return nil
+}
+
+// Capture calls every method of every device, so the snapshot is complete
+// even for queries no tool has made yet. Failed calls are recorded rather
+// than returned; only a failure to list devices is returned.
+func (r *Recorder) Capture(ctx context.Context) error {
+	_, _ = r.GetDriverVersion(ctx)
+	_, _ = r.GetCudaDriverVersion(ctx)
+
+	count, err := r.GetDeviceCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < count; i++ {
+		device, err := r.GetDeviceByIndex(ctx, i)
+		if err != nil {
+			continue
+		}
+
+		_, _ = device.GetName(ctx)
+		_, _ = device.GetUUID(ctx)
+		_, _ = device.GetPCIInfo(ctx)
+		_, _ = device.GetMemoryInfo(ctx)
+		_, _ = device.GetTemperature(ctx)
+		_, _ = device.GetPowerUsage(ctx)
+		_, _ = device.GetUtilizationRates(ctx)
+		_, _ = device.GetPowerManagementLimit(ctx)
+		_, _, _ = device.GetEccMode(ctx)
+		_, _ = device.GetTotalEccErrors(ctx, EccErrorCorrectable)
+		_, _ = device.GetTotalEccErrors(ctx, EccErrorUncorrectable)
+		_, _ = device.GetCurrentClocksThrottleReasons(ctx)
+		_, _ = device.GetClockInfo(ctx, ClockGraphics)
+		_, _ = device.GetClockInfo(ctx, ClockMemory)
+		_, _ = device.GetTemperatureThreshold(ctx, TempThresholdShutdown)
+		_, _ = device.GetTemperatureThreshold(ctx, TempThresholdSlowdown)
+		_, _ = device.GetCudaComputeCapability(ctx)
+		_, _ = device.GetPCIeThroughput(ctx, PCIeUtilTXBytes)
+		_, _ = device.GetPCIeThroughput(ctx, PCIeUtilRXBytes)
+		_, _ = device.GetPCIeReplayCounter(ctx)
+		_, _ = device.GetRunningProcesses(ctx)
+		_, _, _ = device.GetMigMode(ctx)
+		_, _ = device.GetMigDevices(ctx)
+		_, _ = device.GetNvLinks(ctx)
+		_, _ = device.GetRetiredPages(ctx)
+		_, _ = device.GetRemappedRows(ctx)
+	}
+
+	return ctx.Err()
+}
+
+// Init initializes the wrapped NVML library.
+func (r *Recorder) Init(ctx context.Context) error {
+	err := r.inner.Init(ctx)
+	r.record(ctx, callKey{"Init", noValue, noValue}, nil, err)
+	return err
+}
+
+// Shutdown shuts down the wrapped NVML library and saves the snapshot.
+func (r *Recorder) Shutdown(ctx context.Context) error {
+	err := r.inner.Shutdown(ctx)
+	if saveErr := r.Save(); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+	return err
+}
+
+// GetDeviceCount returns the number of GPUs.
+func (r *Recorder) GetDeviceCount(ctx context.Context) (int, error) {
+	count, err := r.inner.GetDeviceCount(ctx)
+	r.record(ctx, callKey{"GetDeviceCount", noValue, noValue}, count, err)
+	return count, err
+}
+
+// GetDeviceByIndex returns a recording handle for the device at idx.
+func (r *Recorder) GetDeviceByIndex(ctx context.Context, idx int) (Device, error) {
+	device, err := r.inner.GetDeviceByIndex(ctx, idx)
+	// The handle itself cannot be serialized; replay only needs success
+	r.record(ctx, callKey{"GetDeviceByIndex", idx, noValue}, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingDevice{inner: device, recorder: r, idx: idx}, nil
+}
+
+// GetDriverVersion returns the driver version.
+func (r *Recorder) GetDriverVersion(ctx context.Context) (string, error) {
+	version, err := r.inner.GetDriverVersion(ctx)
+	r.record(ctx, callKey{"GetDriverVersion", noValue, noValue}, version, err)
+	return version, err
+}
+
+// GetCudaDriverVersion returns the CUDA driver version.
+func (r *Recorder) GetCudaDriverVersion(ctx context.Context) (string, error) {
+	version, err := r.inner.GetCudaDriverVersion(ctx)
+	r.record(ctx, callKey{"GetCudaDriverVersion", noValue, noValue}, version, err)
+	return version, err
+}
+
+// record stores the result of a call, replacing any earlier result.
+// Calls interrupted by the caller's context say nothing about the GPU and
+// are not recorded.
+func (r *Recorder) record(
+	ctx context.Context,
+	key callKey,
+	value interface{},
+	err error,
+) {
+	if ctx.Err() != nil || errors.Is(err, ErrContextCancelled) {
+		return
+	}
+
+	call, encodeErr := newRecordedCall(key, value, err)
+	if encodeErr != nil {
+		klog.ErrorS(encodeErr, "failed to record NVML call", "method", key.method)
+		return
+	}
+
+	r.mu.Lock()
+	r.calls[key] = call
+	r.mu.Unlock()
+}
+
+// recordingDevice records the calls made on a device handle.
+type recordingDevice struct {
+	UnimplementedDevice // Embedded for forward compatibility
+	inner               Device
+	recorder            *Recorder
+	idx                 int
+}
+
+// record stores the result of a device method call.
+func (d *recordingDevice) record(
+	ctx context.Context,
+	method string,
+	arg int,
+	value interface{},
+	err error,
+) {
+	d.recorder.record(ctx, callKey{method, d.idx, arg}, value, err)
+}
+
+// GetName returns the device name.
+func (d *recordingDevice) GetName(ctx context.Context) (string, error) {
+	name, err := d.inner.GetName(ctx)
+	d.record(ctx, "GetName", noValue, name, err)
+	return name, err
+}
+
+// GetUUID returns the device UUID.
+func (d *recordingDevice) GetUUID(ctx context.Context) (string, error) {
+	uuid, err := d.inner.GetUUID(ctx)
+	d.record(ctx, "GetUUID", noValue, uuid, err)
+	return uuid, err
+}
+
+// GetPCIInfo returns PCI bus information.
+func (d *recordingDevice) GetPCIInfo(ctx context.Context) (*PCIInfo, error) {
+	info, err := d.inner.GetPCIInfo(ctx)
+	d.record(ctx, "GetPCIInfo", noValue, info, err)
+	return info, err
+}
+
+// GetMemoryInfo returns memory usage.
+func (d *recordingDevice) GetMemoryInfo(ctx context.Context) (*MemoryInfo, error) {
+	info, err := d.inner.GetMemoryInfo(ctx)
+	d.record(ctx, "GetMemoryInfo", noValue, info, err)
+	return info, err
+}
+
+// GetTemperature returns the GPU temperature.
+func (d *recordingDevice) GetTemperature(ctx context.Context) (uint32, error) {
+	temp, err := d.inner.GetTemperature(ctx)
+	d.record(ctx, "GetTemperature", noValue, temp, err)
+	return temp, err
+}
+
+// GetPowerUsage returns the power draw.
+func (d *recordingDevice) GetPowerUsage(ctx context.Context) (uint32, error) {
+	power, err := d.inner.GetPowerUsage(ctx)
+	d.record(ctx, "GetPowerUsage", noValue, power, err)
+	return power, err
+}
+
+// GetUtilizationRates returns GPU and memory utilization.
+func (d *recordingDevice) GetUtilizationRates(ctx context.Context) (*Utilization, error) {
+	util, err := d.inner.GetUtilizationRates(ctx)
+	d.record(ctx, "GetUtilizationRates", noValue, util, err)
+	return util, err
+}
+
+// GetPowerManagementLimit returns the power limit.
+func (d *recordingDevice) GetPowerManagementLimit(ctx context.Context) (uint32, error) {
+	limit, err := d.inner.GetPowerManagementLimit(ctx)
+	d.record(ctx, "GetPowerManagementLimit", noValue, limit, err)
+	return limit, err
+}
+
+// GetEccMode returns the current and pending ECC mode.
+func (d *recordingDevice) GetEccMode(ctx context.Context) (bool, bool, error) {
+	current, pending, err := d.inner.GetEccMode(ctx)
+	d.record(ctx, "GetEccMode", noValue, modeResult{current, pending}, err)
+	return current, pending, err
+}
+
+// GetTotalEccErrors returns the ECC error count of the given type.
+func (d *recordingDevice) GetTotalEccErrors(ctx context.Context, errorType int) (uint64, error) {
+	count, err := d.inner.GetTotalEccErrors(ctx, errorType)
+	d.record(ctx, "GetTotalEccErrors", errorType, count, err)
+	return count, err
+}
+
+// GetCurrentClocksThrottleReasons returns the throttle reason bitmask.
+func (d *recordingDevice) GetCurrentClocksThrottleReasons(ctx context.Context) (uint64, error) {
+	reasons, err := d.inner.GetCurrentClocksThrottleReasons(ctx)
+	d.record(ctx, "GetCurrentClocksThrottleReasons", noValue, reasons, err)
+	return reasons, err
+}
+
+// GetClockInfo returns the clock speed of the given type.
+func (d *recordingDevice) GetClockInfo(ctx context.Context, clockType int) (uint32, error) {
+	clock, err := d.inner.GetClockInfo(ctx, clockType)
+	d.record(ctx, "GetClockInfo", clockType, clock, err)
+	return clock, err
+}
+
+// GetTemperatureThreshold returns the temperature threshold of the given
+// type.
+func (d *recordingDevice) GetTemperatureThreshold(ctx context.Context, thresholdType int) (uint32, error) {
+	threshold, err := d.inner.GetTemperatureThreshold(ctx, thresholdType)
+	d.record(ctx, "GetTemperatureThreshold", thresholdType, threshold, err)
+	return threshold, err
+}
+
+// GetCudaComputeCapability returns the compute capability.
+func (d *recordingDevice) GetCudaComputeCapability(ctx context.Context) (string, error) {
+	cc, err := d.inner.GetCudaComputeCapability(ctx)
+	d.record(ctx, "GetCudaComputeCapability", noValue, cc, err)
+	return cc, err
+}
+
+// GetPCIeThroughput returns the PCIe throughput of the given counter.
+func (d *recordingDevice) GetPCIeThroughput(ctx context.Context, counter int) (uint32, error) {
+	throughput, err := d.inner.GetPCIeThroughput(ctx, counter)
+	d.record(ctx, "GetPCIeThroughput", counter, throughput, err)
+	return throughput, err
+}
+
+// GetPCIeReplayCounter returns the PCIe replay counter.
+func (d *recordingDevice) GetPCIeReplayCounter(ctx context.Context) (uint32, error) {
+	replays, err := d.inner.GetPCIeReplayCounter(ctx)
+	d.record(ctx, "GetPCIeReplayCounter", noValue, replays, err)
+	return replays, err
+}
+
+// GetRunningProcesses returns the processes using the GPU.
+func (d *recordingDevice) GetRunningProcesses(ctx context.Context) ([]ProcessInfo, error) {
+	processes, err := d.inner.GetRunningProcesses(ctx)
+	d.record(ctx, "GetRunningProcesses", noValue, processes, err)
+	return processes, err
+}
+
+// GetMigMode returns the current and pending MIG mode.
+func (d *recordingDevice) GetMigMode(ctx context.Context) (bool, bool, error) {
+	current, pending, err := d.inner.GetMigMode(ctx)
+	d.record(ctx, "GetMigMode", noValue, modeResult{current, pending}, err)
+	return current, pending, err
+}
+
+// GetMigDevices returns the MIG devices.
+func (d *recordingDevice) GetMigDevices(ctx context.Context) ([]MigDeviceInfo, error) {
+	devices, err := d.inner.GetMigDevices(ctx)
+	d.record(ctx, "GetMigDevices", noValue, devices, err)
+	return devices, err
+}
+
+// GetNvLinks returns the NVLink state and error counters.
+func (d *recordingDevice) GetNvLinks(ctx context.Context) ([]NvLinkInfo, error) {
+	links, err := d.inner.GetNvLinks(ctx)
+	d.record(ctx, "GetNvLinks", noValue, links, err)
+	return links, err
+}
+
+// GetRetiredPages returns the page retirement state.
+func (d *recordingDevice) GetRetiredPages(ctx context.Context) (*RetiredPagesInfo, error) {
+	pages, err := d.inner.GetRetiredPages(ctx)
+	d.record(ctx, "GetRetiredPages", noValue, pages, err)
+	return pages, err
+}
+
+// GetRemappedRows returns the row remapping state.
+func (d *recordingDevice) GetRemappedRows(ctx context.Context) (*RemappedRowsInfo, error) {
+	rows, err := d.inner.GetRemappedRows(ctx)
+	d.record(ctx, "GetRemappedRows", noValue, rows, err)
+	return rows, err
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deviceState collects every value a device reports, so a replayed device
+// can be compared with the recorded one.
+type deviceState struct {
+	Name, UUID, ComputeCapability string
+	PCI                           *PCIInfo
+	Memory                        *MemoryInfo
+	Utilization                   *Utilization
+	Temperature, Power, Limit     uint32
+	Thresholds, Clocks, PCIe      [2]uint32
+	Replays                       uint32
+	ECCEnabled, ECCPending        bool
+	ECC                           [2]uint64
+	Throttle                      uint64
+	Processes                     []ProcessInfo
+	MIGEnabled, MIGPending        bool
+	MIGDevices                    []MigDeviceInfo
+	NvLinks                       []NvLinkInfo
+	RetiredPages                  *RetiredPagesInfo
+	RemappedRows                  *RemappedRowsInfo
+	Errors                        map[string]string
+}
+
+// readDeviceState calls every method of a device. Failures are collected
+// by method name.
+func readDeviceState(ctx context.Context, d Device) deviceState {
+	var s deviceState
+	s.Errors = make(map[string]string)
+	check := func(method string, err error) {
+		if err != nil {
+			s.Errors[method] = err.Error()
+		}
+	}
+
+	var err error
+	s.Name, err = d.GetName(ctx)
+	check("GetName", err)
+	s.UUID, err = d.GetUUID(ctx)
+	check("GetUUID", err)
+	s.ComputeCapability, err = d.GetCudaComputeCapability(ctx)
+	check("GetCudaComputeCapability", err)
+	s.PCI, err = d.GetPCIInfo(ctx)
+	check("GetPCIInfo", err)
+	s.Memory, err = d.GetMemoryInfo(ctx)
+	check("GetMemoryInfo", err)
+	s.Utilization, err = d.GetUtilizationRates(ctx)
+	check("GetUtilizationRates", err)
+	s.Temperature, err = d.GetTemperature(ctx)
+	check("GetTemperature", err)
+	s.Power, err = d.GetPowerUsage(ctx)
+	check("GetPowerUsage", err)
+	s.Limit, err = d.GetPowerManagementLimit(ctx)
+	check("GetPowerManagementLimit", err)
+	for i := 0; i < 2; i++ {
+		s.Thresholds[i], err = d.GetTemperatureThreshold(ctx, i)
+		check("GetTemperatureThreshold", err)
+		s.Clocks[i], err = d.GetClockInfo(ctx, i)
+		check("GetClockInfo", err)
+		s.PCIe[i], err = d.GetPCIeThroughput(ctx, i)
+		check("GetPCIeThroughput", err)
+		s.ECC[i], err = d.GetTotalEccErrors(ctx, i)
+		check("GetTotalEccErrors", err)
+	}
+	s.Replays, err = d.GetPCIeReplayCounter(ctx)
+	check("GetPCIeReplayCounter", err)
+	s.ECCEnabled, s.ECCPending, err = d.GetEccMode(ctx)
+	check("GetEccMode", err)
+	s.Throttle, err = d.GetCurrentClocksThrottleReasons(ctx)
+	check("GetCurrentClocksThrottleReasons", err)
+	s.Processes, err = d.GetRunningProcesses(ctx)
+	check("GetRunningProcesses", err)
+	s.MIGEnabled, s.MIGPending, err = d.GetMigMode(ctx)
+	check("GetMigMode", err)
+	s.MIGDevices, err = d.GetMigDevices(ctx)
+	check("GetMigDevices", err)
+	s.NvLinks, err = d.GetNvLinks(ctx)
+	check("GetNvLinks", err)
+	s.RetiredPages, err = d.GetRetiredPages(ctx)
+	check("GetRetiredPages", err)
+	s.RemappedRows, err = d.GetRemappedRows(ctx)
+	check("GetRemappedRows", err)
+	return s
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	mock := NewMock(3,
+		WithMockMIG(0),
+		WithMockRetiredPages(1, 3, 1, true),
+		WithMockDeviceError(1, "GetPowerUsage",
+			fmt.Errorf("%w: power readings unavailable", ErrNotSupported)),
+		WithMockDeviceError(2, "GetDeviceByIndex", errors.New("GPU is lost")),
+	)
+	recorder := NewRecorder(mock, path)
+	require.NoError(t, recorder.Init(ctx))
+	require.NoError(t, recorder.Capture(ctx))
+	require.NoError(t, recorder.Shutdown(ctx))
+
+	replay, err := NewReplayFromFile(path)
+	require.NoError(t, err)
+	require.NoError(t, replay.Init(ctx))
+
+	count, err := replay.GetDeviceCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	driver, err := replay.GetDriverVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "575.57.08", driver)
+
+	for i := 0; i < 2; i++ {
+		want, err := mock.GetDeviceByIndex(ctx, i)
+		require.NoError(t, err)
+		got, err := replay.GetDeviceByIndex(ctx, i)
+		require.NoError(t, err)
+
+		assert.Equal(t, readDeviceState(ctx, want), readDeviceState(ctx, got),
+			"device %d", i)
+	}
+
+	t.Run("errors keep their kind", func(t *testing.T) {
+		device, err := replay.GetDeviceByIndex(ctx, 1)
+		require.NoError(t, err)
+		_, err = device.GetPowerUsage(ctx)
+		assert.ErrorIs(t, err, ErrNotSupported)
+		assert.EqualError(t, err,
+			"operation not supported: power readings unavailable")
+
+		_, err = replay.GetDeviceByIndex(ctx, 2)
+		assert.EqualError(t, err, "GPU is lost")
+	})
+
+	t.Run("unknown devices and calls", func(t *testing.T) {
+		_, err := replay.GetDeviceByIndex(ctx, 7)
+		assert.ErrorIs(t, err, ErrInvalidDevice)
+
+		// A device that was recorded but never queried
+		empty := NewReplay(&Snapshot{Calls: []RecordedCall{
+			{Method: "GetDeviceByIndex", Device: intPtr(0),
+				Result: json.RawMessage("null")},
+		}})
+		device, err := empty.GetDeviceByIndex(ctx, 0)
+		require.NoError(t, err)
+		_, err = device.GetTemperature(ctx)
+		assert.ErrorIs(t, err, ErrNotRecorded)
+	})
+}
+
+func TestRecorder_LatestResultWins(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMock(1)
+	recorder := NewRecorder(mock, filepath.Join(t.TempDir(), "snapshot.json"))
+
+	device, err := recorder.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+	_, err = device.GetTemperature(ctx)
+	require.NoError(t, err)
+
+	temp := uint32(91)
+	require.NoError(t, mock.UpdateDevice(0, &FixtureDevice{Temperature: &temp}))
+	device, err = recorder.GetDeviceByIndex(ctx, 0)
+	require.NoError(t, err)
+	_, err = device.GetTemperature(ctx)
+	require.NoError(t, err)
+
+	snapshot := recorder.Snapshot()
+	require.Len(t, snapshot.Calls, 2)
+	assert.Equal(t, "GetDeviceByIndex", snapshot.Calls[0].Method)
+	assert.Equal(t, "GetTemperature", snapshot.Calls[1].Method)
+	assert.JSONEq(t, "91", string(snapshot.Calls[1].Result))
+}
+
+func TestRecorder_SkipsCancelledCalls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	recorder := NewRecorder(NewMock(1), filepath.Join(t.TempDir(), "s.json"))
+	_, _ = recorder.GetDeviceCount(ctx)
+	assert.Empty(t, recorder.Snapshot().Calls)
+}
+
+func TestLoadSnapshot_Invalid(t *testing.T) {
+	_, err := LoadSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read snapshot")
+
+	path := writeFixture(t, "bad.json", "{")
+	_, err = LoadSnapshot(path)
+	assert.ErrorContains(t, err, "failed to parse snapshot")
+}
+
+func intPtr(v int) *int {
+	return &v
+}
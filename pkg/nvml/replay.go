@@ -0,0 +1,240 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Compile-time interface satisfaction checks.
+var (
+	_ Interface = (*Replay)(nil)
+	_ Device    = (*replayDevice)(nil)
+)
+
+// Replay is an NVML implementation that returns the results recorded in a
+// snapshot. It needs no GPU or driver, so snapshots taken on a node can be
+// reproduced anywhere. Calls missing from the snapshot fail with
+// ErrNotRecorded.
+type Replay struct {
+	UnimplementedInterface // Embedded for forward compatibility
+	calls                  map[callKey]RecordedCall
+}
+
+// NewReplay creates a replay backend serving the calls in the snapshot.
+func NewReplay(snapshot *Snapshot) *Replay {
+	r := &Replay{calls: make(map[callKey]RecordedCall, len(snapshot.Calls))}
+	for _, call := range snapshot.Calls {
+		r.calls[call.key()] = call
+	}
+	return r
+}
+
+// NewReplayFromFile loads a snapshot file and creates a replay backend
+// from it.
+func NewReplayFromFile(path string) (*Replay, error) {
+	snapshot, err := LoadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplay(snapshot), nil
+}
+
+// Init returns the recorded Init error, if any.
+func (r *Replay) Init(ctx context.Context) error {
+	_, err := replayCall[json.RawMessage](r, callKey{"Init", noValue, noValue})
+	if errors.Is(err, ErrNotRecorded) {
+		// Recordings may start after Init
+		return nil
+	}
+	return err
+}
+
+// Shutdown is a no-op.
+func (r *Replay) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// GetDeviceCount returns the recorded device count.
+func (r *Replay) GetDeviceCount(ctx context.Context) (int, error) {
+	return replayCall[int](r, callKey{"GetDeviceCount", noValue, noValue})
+}
+
+// GetDeviceByIndex returns a handle replaying the calls recorded for the
+// device at idx.
+func (r *Replay) GetDeviceByIndex(ctx context.Context, idx int) (Device, error) {
+	_, err := replayCall[json.RawMessage](r,
+		callKey{"GetDeviceByIndex", idx, noValue})
+	if errors.Is(err, ErrNotRecorded) {
+		if count, countErr := r.GetDeviceCount(ctx); countErr == nil &&
+			(idx < 0 || idx >= count) {
+			return nil, fmt.Errorf("%w: %d (count: %d)",
+				ErrInvalidDevice, idx, count)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &replayDevice{replay: r, idx: idx}, nil
+}
+
+// GetDriverVersion returns the recorded driver version.
+func (r *Replay) GetDriverVersion(ctx context.Context) (string, error) {
+	return replayCall[string](r, callKey{"GetDriverVersion", noValue, noValue})
+}
+
+// GetCudaDriverVersion returns the recorded CUDA driver version.
+func (r *Replay) GetCudaDriverVersion(ctx context.Context) (string, error) {
+	return replayCall[string](r, callKey{"GetCudaDriverVersion", noValue, noValue})
+}
+
+// replayCall decodes the recorded result of a call into T.
+func replayCall[T any](r *Replay, key callKey) (T, error) {
+	var value T
+	call, ok := r.calls[key]
+	if !ok {
+		return value, fmt.Errorf("%s: %w", key.method, ErrNotRecorded)
+	}
+	if call.Error != nil {
+		return value, call.Error.err()
+	}
+	if len(call.Result) > 0 {
+		if err := json.Unmarshal(call.Result, &value); err != nil {
+			return value, fmt.Errorf("%s: invalid recorded result: %w",
+				key.method, err)
+		}
+	}
+	return value, nil
+}
+
+// replayDevice is a device handle served from a snapshot.
+type replayDevice struct {
+	UnimplementedDevice // Embedded for forward compatibility
+	replay              *Replay
+	idx                 int
+}
+
+// key returns the callKey of a method called on the device.
+func (d *replayDevice) key(method string, arg int) callKey {
+	return callKey{method, d.idx, arg}
+}
+
+// GetName returns the recorded device name.
+func (d *replayDevice) GetName(ctx context.Context) (string, error) {
+	return replayCall[string](d.replay, d.key("GetName", noValue))
+}
+
+// GetUUID returns the recorded device UUID.
+func (d *replayDevice) GetUUID(ctx context.Context) (string, error) {
+	return replayCall[string](d.replay, d.key("GetUUID", noValue))
+}
+
+// GetPCIInfo returns the recorded PCI bus information.
+func (d *replayDevice) GetPCIInfo(ctx context.Context) (*PCIInfo, error) {
+	return replayCall[*PCIInfo](d.replay, d.key("GetPCIInfo", noValue))
+}
+
+// GetMemoryInfo returns the recorded memory usage.
+func (d *replayDevice) GetMemoryInfo(ctx context.Context) (*MemoryInfo, error) {
+	return replayCall[*MemoryInfo](d.replay, d.key("GetMemoryInfo", noValue))
+}
+
+// GetTemperature returns the recorded GPU temperature.
+func (d *replayDevice) GetTemperature(ctx context.Context) (uint32, error) {
+	return replayCall[uint32](d.replay, d.key("GetTemperature", noValue))
+}
+
+// GetPowerUsage returns the recorded power draw.
+func (d *replayDevice) GetPowerUsage(ctx context.Context) (uint32, error) {
+	return replayCall[uint32](d.replay, d.key("GetPowerUsage", noValue))
+}
+
+// GetUtilizationRates returns the recorded GPU and memory utilization.
+func (d *replayDevice) GetUtilizationRates(ctx context.Context) (*Utilization, error) {
+	return replayCall[*Utilization](d.replay, d.key("GetUtilizationRates", noValue))
+}
+
+// GetPowerManagementLimit returns the recorded power limit.
+func (d *replayDevice) GetPowerManagementLimit(ctx context.Context) (uint32, error) {
+	return replayCall[uint32](d.replay, d.key("GetPowerManagementLimit", noValue))
+}
+
+// GetEccMode returns the recorded current and pending ECC mode.
+func (d *replayDevice) GetEccMode(ctx context.Context) (bool, bool, error) {
+	mode, err := replayCall[modeResult](d.replay, d.key("GetEccMode", noValue))
+	return mode.Current, mode.Pending, err
+}
+
+// GetTotalEccErrors returns the recorded ECC error count of the given type.
+func (d *replayDevice) GetTotalEccErrors(ctx context.Context, errorType int) (uint64, error) {
+	return replayCall[uint64](d.replay, d.key("GetTotalEccErrors", errorType))
+}
+
+// GetCurrentClocksThrottleReasons returns the recorded throttle reasons.
+func (d *replayDevice) GetCurrentClocksThrottleReasons(ctx context.Context) (uint64, error) {
+	return replayCall[uint64](d.replay,
+		d.key("GetCurrentClocksThrottleReasons", noValue))
+}
+
+// GetClockInfo returns the recorded clock speed of the given type.
+func (d *replayDevice) GetClockInfo(ctx context.Context, clockType int) (uint32, error) {
+	return replayCall[uint32](d.replay, d.key("GetClockInfo", clockType))
+}
+
+// GetTemperatureThreshold returns the recorded temperature threshold of
+// the given type.
+func (d *replayDevice) GetTemperatureThreshold(ctx context.Context, thresholdType int) (uint32, error) {
+	return replayCall[uint32](d.replay,
+		d.key("GetTemperatureThreshold", thresholdType))
+}
+
+// GetCudaComputeCapability returns the recorded compute capability.
+func (d *replayDevice) GetCudaComputeCapability(ctx context.Context) (string, error) {
+	return replayCall[string](d.replay, d.key("GetCudaComputeCapability", noValue))
+}
+
+// GetPCIeThroughput returns the recorded PCIe throughput of the given
+// counter.
+func (d *replayDevice) GetPCIeThroughput(ctx context.Context, counter int) (uint32, error) {
+	return replayCall[uint32](d.replay, d.key("GetPCIeThroughput", counter))
+}
+
+// GetPCIeReplayCounter returns the recorded PCIe replay counter.
+func (d *replayDevice) GetPCIeReplayCounter(ctx context.Context) (uint32, error) {
+	return replayCall[uint32](d.replay, d.key("GetPCIeReplayCounter", noValue))
+}
+
+// GetRunningProcesses returns the recorded processes using the GPU.
+func (d *replayDevice) GetRunningProcesses(ctx context.Context) ([]ProcessInfo, error) {
+	return replayCall[[]ProcessInfo](d.replay, d.key("GetRunningProcesses", noValue))
+}
+
+// GetMigMode returns the recorded current and pending MIG mode.
+func (d *replayDevice) GetMigMode(ctx context.Context) (bool, bool, error) {
+	mode, err := replayCall[modeResult](d.replay, d.key("GetMigMode", noValue))
+	return mode.Current, mode.Pending, err
+}
+
+// GetMigDevices returns the recorded MIG devices.
+func (d *replayDevice) GetMigDevices(ctx context.Context) ([]MigDeviceInfo, error) {
+	return replayCall[[]MigDeviceInfo](d.replay, d.key("GetMigDevices", noValue))
+}
+
+// GetNvLinks returns the recorded NVLink state and error counters.
+func (d *replayDevice) GetNvLinks(ctx context.Context) ([]NvLinkInfo, error) {
+	return replayCall[[]NvLinkInfo](d.replay, d.key("GetNvLinks", noValue))
+}
+
+// GetRetiredPages returns the recorded page retirement state.
+func (d *replayDevice) GetRetiredPages(ctx context.Context) (*RetiredPagesInfo, error) {
+	return replayCall[*RetiredPagesInfo](d.replay, d.key("GetRetiredPages", noValue))
+}
+
+// GetRemappedRows returns the recorded row remapping state.
+func (d *replayDevice) GetRemappedRows(ctx context.Context) (*RemappedRowsInfo, error) {
+	return replayCall[*RemappedRowsInfo](d.replay, d.key("GetRemappedRows", noValue))
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Snapshot holds the NVML calls captured by a Recorder. It is written as
+// JSON so it can be attached to bug reports and replayed with NewReplay on
+// machines without GPUs.
+type Snapshot struct {
+	// RecordedAt is when the snapshot was saved
+	RecordedAt time.Time `json:"recorded_at"`
+	// Calls holds the most recent result of each distinct call
+	Calls []RecordedCall `json:"calls"`
+}
+
+// RecordedCall is the result of one NVML call. Device and Arg are omitted
+// for Interface methods and methods without an argument.
+type RecordedCall struct {
+	Method string `json:"method"`
+	// Device is the index of the device the method was called on
+	Device *int `json:"device,omitempty"`
+	// Arg is the integer argument of the call (e.g., the ECC error type)
+	Arg *int `json:"arg,omitempty"`
+	// Result is the JSON-encoded return value of a successful call
+	Result json.RawMessage `json:"result,omitempty"`
+	// Error is the error returned by a failed call
+	Error *RecordedError `json:"error,omitempty"`
+}
+
+// RecordedError is an error returned by a recorded call. Kind names the
+// sentinel error it wraps, so errors.Is keeps working on replay.
+type RecordedError struct {
+	Message string `json:"message"`
+	Kind    string `json:"kind,omitempty"`
+}
+
+// modeResult is the recorded result of methods returning the current and
+// pending state of a mode (GetEccMode, GetMigMode).
+type modeResult struct {
+	Current bool `json:"current"`
+	Pending bool `json:"pending"`
+}
+
+// recordedErrorKinds maps RecordedError kinds to sentinel errors. Order
+// matters when classifying errors that wrap several sentinels.
+var recordedErrorKinds = []struct {
+	kind string
+	err  error
+}{
+	{"not_supported", ErrNotSupported},
+	{"not_initialized", ErrNotInitialized},
+	{"not_implemented", ErrNotImplemented},
+	{"invalid_device", ErrInvalidDevice},
+	{"cgo_required", ErrCGORequired},
+}
+
+// callKey identifies a distinct call. Device and Arg are -1 when unused.
+type callKey struct {
+	method string
+	device int
+	arg    int
+}
+
+// noValue marks an unused callKey field.
+const noValue = -1
+
+// LoadSnapshot reads a snapshot written by Recorder.Save.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// Save writes the snapshot to path as indented JSON. The file is replaced
+// atomically so readers never see a partial snapshot.
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// key returns the callKey of a recorded call.
+func (c *RecordedCall) key() callKey {
+	k := callKey{method: c.Method, device: noValue, arg: noValue}
+	if c.Device != nil {
+		k.device = *c.Device
+	}
+	if c.Arg != nil {
+		k.arg = *c.Arg
+	}
+	return k
+}
+
+// newRecordedCall encodes the result of a call.
+func newRecordedCall(k callKey, value interface{}, err error) (RecordedCall, error) {
+	call := RecordedCall{Method: k.method}
+	if k.device != noValue {
+		device := k.device
+		call.Device = &device
+	}
+	if k.arg != noValue {
+		arg := k.arg
+		call.Arg = &arg
+	}
+
+	if err != nil {
+		call.Error = &RecordedError{Message: err.Error()}
+		for _, kind := range recordedErrorKinds {
+			if errors.Is(err, kind.err) {
+				call.Error.Kind = kind.kind
+				break
+			}
+		}
+		return call, nil
+	}
+
+	result, encodeErr := json.Marshal(value)
+	if encodeErr != nil {
+		return call, fmt.Errorf("failed to encode %s result: %w",
+			k.method, encodeErr)
+	}
+	call.Result = result
+	return call, nil
+}
+
+// sortCalls orders calls by device, method and argument so snapshots of
+// the same state are identical.
+func sortCalls(calls []RecordedCall) {
+	sort.Slice(calls, func(i, j int) bool {
+		a, b := calls[i].key(), calls[j].key()
+		if a.device != b.device {
+			return a.device < b.device
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.arg < b.arg
+	})
+}
+
+// replayedError is a recorded error returned on replay. It keeps the
+// recorded message and wraps the recorded sentinel error.
+type replayedError struct {
+	message string
+	kind    error
+}
+
+func (e *replayedError) Error() string {
+	return e.message
+}
+
+func (e *replayedError) Unwrap() error {
+	return e.kind
+}
+
+// err returns the recorded error for replay.
+func (e *RecordedError) err() error {
+	replayed := &replayedError{message: e.Message}
+	for _, kind := range recordedErrorKinds {
+		if kind.kind == e.Kind {
+			replayed.kind = kind.err
+			break
+		}
+	}
+	return replayed
+}
@@ -164,3 +164,58 @@ func (UnimplementedDevice) GetCudaComputeCapability(
 ) (string, error) {
 	return "", ErrNotImplemented
 }
+
+// GetRunningProcesses returns ErrNotImplemented.
+func (UnimplementedDevice) GetRunningProcesses(
+	_ context.Context,
+) ([]ProcessInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// GetMigMode returns ErrNotImplemented.
+func (UnimplementedDevice) GetMigMode(
+	_ context.Context,
+) (current, pending bool, err error) {
+	return false, false, ErrNotImplemented
+}
+
+// GetMigDevices returns ErrNotImplemented.
+func (UnimplementedDevice) GetMigDevices(
+	_ context.Context,
+) ([]MigDeviceInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// GetNvLinks returns ErrNotImplemented.
+func (UnimplementedDevice) GetNvLinks(
+	_ context.Context,
+) ([]NvLinkInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// GetPCIeThroughput returns ErrNotImplemented.
+func (UnimplementedDevice) GetPCIeThroughput(
+	_ context.Context,
+	_ int,
+) (uint32, error) {
+	return 0, ErrNotImplemented
+}
+
+// GetPCIeReplayCounter returns ErrNotImplemented.
+func (UnimplementedDevice) GetPCIeReplayCounter(_ context.Context) (uint32, error) {
+	return 0, ErrNotImplemented
+}
+
+// GetRetiredPages returns ErrNotImplemented.
+func (UnimplementedDevice) GetRetiredPages(
+	_ context.Context,
+) (*RetiredPagesInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// GetRemappedRows returns ErrNotImplemented.
+func (UnimplementedDevice) GetRemappedRows(
+	_ context.Context,
+) (*RemappedRowsInfo, error) {
+	return nil, ErrNotImplemented
+}
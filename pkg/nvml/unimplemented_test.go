@@ -57,6 +57,14 @@ func TestUnimplementedDevice_ReturnsErrNotImplemented(t *testing.T) {
 		{"GetClockInfo", func() error { _, err := dev.GetClockInfo(ctx, 0); return err }},
 		{"GetTemperatureThreshold", func() error { _, err := dev.GetTemperatureThreshold(ctx, 0); return err }},
 		{"GetCudaComputeCapability", func() error { _, err := dev.GetCudaComputeCapability(ctx); return err }},
+		{"GetRunningProcesses", func() error { _, err := dev.GetRunningProcesses(ctx); return err }},
+		{"GetMigMode", func() error { _, _, err := dev.GetMigMode(ctx); return err }},
+		{"GetMigDevices", func() error { _, err := dev.GetMigDevices(ctx); return err }},
+		{"GetNvLinks", func() error { _, err := dev.GetNvLinks(ctx); return err }},
+		{"GetPCIeThroughput", func() error { _, err := dev.GetPCIeThroughput(ctx, 0); return err }},
+		{"GetPCIeReplayCounter", func() error { _, err := dev.GetPCIeReplayCounter(ctx); return err }},
+		{"GetRetiredPages", func() error { _, err := dev.GetRetiredPages(ctx); return err }},
+		{"GetRemappedRows", func() error { _, err := dev.GetRemappedRows(ctx); return err }},
 	}
 
 	for _, tt := range tests {
@@ -70,7 +70,7 @@ Please analyze NVIDIA XID errors from the last {{time_range}}.
 ### Workflow
 
 1. **Error Collection**
-   - Use the ` + "`analyze_xid_errors`" + ` tool to parse kernel logs
+   - Use the ` + "`analyze_xid_errors`" + ` tool with ` + "`time_range: \"{{time_range}}\"`" + `
    - Collect all XID errors with timestamps
 
 2. **Error Classification**
@@ -260,6 +260,8 @@ func TestDiagnoseXIDErrorsPrompt(t *testing.T) {
 	t.Run("custom time_range", func(t *testing.T) {
 		result := p.RenderTemplate(map[string]string{"time_range": "7d"})
 		assert.Contains(t, result, "7d")
+		// The range is passed on to the tool
+		assert.Contains(t, result, `time_range: "7d"`)
 	})
 }
 
@@ -0,0 +1,250 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
+	"k8s.io/klog/v2"
+)
+
+// Engine plays a scenario against a mock NVML backend and a fake kernel
+// log. Time only moves when Advance is called, so tests can step through
+// a scenario deterministically; Run advances it in real time.
+type Engine struct {
+	scenario *Scenario
+	mock     *nvml.Mock
+	kmsgPath string
+	started  time.Time
+
+	mu      sync.Mutex
+	elapsed time.Duration
+	next    int          // first event not yet applied
+	ramps   []activeRamp // ramps still in progress
+	busIDs  []string     // PCI bus IDs reported in XID lines
+	kmsgSeq uint64       // sequence number of the next kmsg record
+}
+
+// activeRamp is a ramp that has started but not reached its end value.
+type activeRamp struct {
+	gpu   int
+	ramp  *Ramp
+	start time.Duration
+}
+
+// New creates an engine for the scenario. The kernel log at kmsgPath is
+// created (or truncated) and events at offset zero are applied.
+func New(s *Scenario, kmsgPath string) (*Engine, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	mock, err := nvml.NewMockFromFixture(&s.Fixture)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(kmsgPath, nil, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to create kernel log: %w", err)
+	}
+
+	e := &Engine{
+		scenario: s,
+		mock:     mock,
+		kmsgPath: kmsgPath,
+		started:  time.Now(),
+		busIDs:   make([]string, len(s.Fixture.Devices)),
+	}
+
+	// Record bus IDs up front: a GPU that falls off the bus can no
+	// longer be looked up, but its XIDs still name it
+	ctx := context.Background()
+	for i := range e.busIDs {
+		device, err := mock.GetDeviceByIndex(ctx, i)
+		if err != nil {
+			continue
+		}
+		if pci, err := device.GetPCIInfo(ctx); err == nil {
+			e.busIDs[i] = pci.BusID
+		}
+	}
+
+	if err := e.Advance(0); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NVML returns the mock NVML backend driven by the scenario.
+func (e *Engine) NVML() *nvml.Mock {
+	return e.mock
+}
+
+// KmsgPath returns the path of the fake kernel log in /dev/kmsg format.
+func (e *Engine) KmsgPath() string {
+	return e.kmsgPath
+}
+
+// StartedAt returns the wall-clock time of scenario time zero, which the
+// kernel log timestamps count from.
+func (e *Engine) StartedAt() time.Time {
+	return e.started
+}
+
+// Elapsed returns the scenario time.
+func (e *Engine) Elapsed() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.elapsed
+}
+
+// Done reports whether all events have been applied and all ramps have
+// finished.
+func (e *Engine) Done() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.next == len(e.scenario.Events) && len(e.ramps) == 0
+}
+
+// Advance moves the scenario time forward by d, applying the events that
+// became due and updating ramps in progress.
+func (e *Engine) Advance(d time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.elapsed += d
+	events := e.scenario.Events
+	for e.next < len(events) && events[e.next].At.Duration <= e.elapsed {
+		event := &events[e.next]
+		e.next++
+		if err := e.apply(event); err != nil {
+			return err
+		}
+	}
+	return e.updateRamps()
+}
+
+// Run advances the scenario in real time, checking for due events every
+// tick, until the scenario is done or ctx is cancelled.
+func (e *Engine) Run(ctx context.Context, tick time.Duration) error {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for !e.Done() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			if err := e.Advance(now.Sub(last)); err != nil {
+				return err
+			}
+			last = now
+		}
+	}
+
+	klog.InfoS("scenario finished", "scenario", e.scenario.Name,
+		"elapsed", e.Elapsed())
+	return nil
+}
+
+// apply performs the actions of an event.
+func (e *Engine) apply(event *Event) error {
+	klog.V(2).InfoS("applying scenario event",
+		"scenario", e.scenario.Name, "at", event.At.Duration, "gpu", event.GPU)
+
+	if event.Set != nil {
+		if err := e.mock.UpdateDevice(event.GPU, event.Set); err != nil {
+			return fmt.Errorf("event at %s: %w", event.At.Duration, err)
+		}
+		if event.Set.PCIBusID != nil {
+			e.busIDs[event.GPU] = *event.Set.PCIBusID
+		}
+	}
+
+	if event.Ramp != nil {
+		e.ramps = append(e.ramps, activeRamp{
+			gpu:   event.GPU,
+			ramp:  event.Ramp,
+			start: event.At.Duration,
+		})
+	}
+
+	if x := event.XID; x != nil {
+		pid, process := "'<unknown>'", "<unknown>"
+		if x.PID != 0 {
+			pid = fmt.Sprintf("%d", x.PID)
+		}
+		if x.Process != "" {
+			process = x.Process
+		}
+		message := x.Message
+		if message == "" {
+			message = xid.LookupOrUnknown(x.Code).Name
+		}
+		line := fmt.Sprintf("NVRM: Xid (PCI:%s): %d, pid=%s, name=%s, %s",
+			e.busIDs[event.GPU], x.Code, pid, process, message)
+		if err := e.writeKmsg(event.At.Duration, 3, line); err != nil {
+			return err
+		}
+	}
+
+	if event.Kmsg != "" {
+		if err := e.writeKmsg(event.At.Duration, 4, event.Kmsg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateRamps sets every ramp in progress to its value at the current
+// time and drops the ramps that have finished.
+func (e *Engine) updateRamps() error {
+	active := e.ramps[:0]
+	for _, r := range e.ramps {
+		progress := 1.0
+		if r.ramp.Over.Duration > 0 {
+			progress = float64(e.elapsed-r.start) / float64(r.ramp.Over.Duration)
+			progress = min(max(progress, 0), 1)
+		}
+
+		value := r.ramp.From + (r.ramp.To-r.ramp.From)*progress
+		patch, err := r.ramp.patch(value)
+		if err != nil {
+			return err
+		}
+		if err := e.mock.UpdateDevice(r.gpu, patch); err != nil {
+			return fmt.Errorf("ramp of %s: %w", r.ramp.Field, err)
+		}
+
+		if progress < 1 {
+			active = append(active, r)
+		}
+	}
+	e.ramps = active
+	return nil
+}
+
+// writeKmsg appends a record in /dev/kmsg format
+// ("priority,sequence,timestamp_usec,flags;message") to the kernel log.
+func (e *Engine) writeKmsg(at time.Duration, priority int, message string) error {
+	file, err := os.OpenFile(e.kmsgPath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open kernel log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	_, err = fmt.Fprintf(file, "%d,%d,%d,-;%s\n",
+		priority, e.kmsgSeq, at.Microseconds(), message)
+	if err != nil {
+		return fmt.Errorf("failed to write kernel log: %w", err)
+	}
+	e.kmsgSeq++
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEngine loads a scenario and creates an engine writing its kernel
+// log into a temporary directory.
+func newTestEngine(t *testing.T, content string) *Engine {
+	t.Helper()
+
+	s, err := Load(writeScenario(t, content))
+	require.NoError(t, err)
+	engine, err := New(s, filepath.Join(t.TempDir(), "kmsg"))
+	require.NoError(t, err)
+	return engine
+}
+
+// callTool invokes a tool handler and decodes its JSON response.
+func callTool(
+	t *testing.T,
+	handle func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error),
+	response interface{},
+) {
+	t.Helper()
+
+	result, err := handle(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "unexpected tool error: %v", result.Content)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), response))
+}
+
+// temperature returns the current temperature of a GPU.
+func temperature(t *testing.T, m nvml.Interface, idx int) uint32 {
+	t.Helper()
+
+	device, err := m.GetDeviceByIndex(context.Background(), idx)
+	require.NoError(t, err)
+	temp, err := device.GetTemperature(context.Background())
+	require.NoError(t, err)
+	return temp
+}
+
+func TestEngine_Advance(t *testing.T) {
+	engine := newTestEngine(t, `
+fixture:
+  devices: [{}, {}]
+events:
+  - at: 0s
+    gpu: 0
+    set: {temperature: 60}
+  - at: 10s
+    gpu: 1
+    ramp: {field: temperature, from: 50, to: 90, over: 40s}
+  - at: 30s
+    gpu: 0
+    set:
+      errors: {GetDeviceByIndex: ERROR_GPU_IS_LOST}
+`)
+	mock := engine.NVML()
+	ctx := context.Background()
+
+	// Events at offset zero are applied on creation
+	assert.Equal(t, uint32(60), temperature(t, mock, 0))
+	assert.Equal(t, uint32(50), temperature(t, mock, 1))
+
+	t.Run("ramp interpolates", func(t *testing.T) {
+		require.NoError(t, engine.Advance(20*time.Second))
+		assert.Equal(t, 20*time.Second, engine.Elapsed())
+		assert.Equal(t, uint32(60), temperature(t, mock, 1))
+	})
+
+	t.Run("GPU disappears", func(t *testing.T) {
+		require.NoError(t, engine.Advance(10*time.Second))
+		_, err := mock.GetDeviceByIndex(ctx, 0)
+		assert.EqualError(t, err, "GetDeviceByIndex: GPU is lost")
+		assert.False(t, engine.Done())
+	})
+
+	t.Run("ramp stops at its end value", func(t *testing.T) {
+		require.NoError(t, engine.Advance(time.Minute))
+		assert.Equal(t, uint32(90), temperature(t, mock, 1))
+		assert.True(t, engine.Done())
+	})
+}
+
+func TestEngine_Kmsg(t *testing.T) {
+	engine := newTestEngine(t, `
+fixture:
+  devices: [{}, {pci_bus_id: "0000:3b:00.0"}]
+events:
+  - at: 2s
+    gpu: 1
+    xid: {code: 48, pid: 4242, process: torchrun}
+  - at: 5s
+    gpu: 1
+    xid: {code: 79}
+    kmsg: "NVRM: GPU 0000:3b:00.0: GPU has fallen off the bus."
+`)
+
+	data, err := os.ReadFile(engine.KmsgPath())
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	require.NoError(t, engine.Advance(5*time.Second))
+	data, err = os.ReadFile(engine.KmsgPath())
+	require.NoError(t, err)
+	assert.Equal(t,
+		"3,0,2000000,-;NVRM: Xid (PCI:0000:3b:00.0): 48, pid=4242, name=torchrun, Double Bit ECC Error\n"+
+			"3,1,5000000,-;NVRM: Xid (PCI:0000:3b:00.0): 79, pid='<unknown>', name=<unknown>, GPU Fallen Off Bus\n"+
+			"4,2,5000000,-;NVRM: GPU 0000:3b:00.0: GPU has fallen off the bus.\n",
+		string(data))
+}
+
+func TestEngine_Run(t *testing.T) {
+	engine := newTestEngine(t, `
+fixture:
+  devices: [{}]
+events:
+  - at: 20ms
+    gpu: 0
+    set: {temperature: 70}
+`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, engine.Run(ctx, 5*time.Millisecond))
+	assert.True(t, engine.Done())
+	assert.Equal(t, uint32(70), temperature(t, engine.NVML(), 0))
+
+	t.Run("cancelled", func(t *testing.T) {
+		engine := newTestEngine(t, `
+fixture:
+  devices: [{}]
+events:
+  - {at: 1h, gpu: 0, kmsg: "NVRM: never"}
+`)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, engine.Run(ctx, time.Millisecond), context.Canceled)
+	})
+}
+
+// TestEngine_Tools checks that get_gpu_health and analyze_xid_errors follow
+// the scenario as it plays.
+func TestEngine_Tools(t *testing.T) {
+	engine := newTestEngine(t, `
+fixture:
+  devices: [{}, {}]
+events:
+  - at: 1m
+    gpu: 1
+    ramp: {field: temperature, from: 50, to: 88, over: 1m}
+  - at: 2m
+    gpu: 1
+    set: {throttle_reasons: 0x60}
+  - at: 3m
+    gpu: 0
+    set:
+      ecc: {uncorrectable: 1}
+    xid: {code: 48, pid: 4242, process: torchrun}
+`)
+	health := tools.NewGPUHealthHandler(engine.NVML())
+	xids := tools.NewAnalyzeXIDHandlerWithKmsgPath(
+		engine.NVML(), engine.KmsgPath(), engine.StartedAt())
+
+	var before tools.GPUHealthResponse
+	callTool(t, health.Handle, &before)
+	assert.Equal(t, "healthy", before.Status)
+
+	var noXIDs tools.AnalyzeXIDResponse
+	callTool(t, xids.Handle, &noXIDs)
+	assert.Equal(t, 0, noXIDs.ErrorCount)
+
+	require.NoError(t, engine.Advance(2*time.Minute))
+	var hot tools.GPUHealthResponse
+	callTool(t, health.Handle, &hot)
+	assert.Equal(t, uint32(88), hot.GPUs[1].Temperature.Current)
+	assert.NotEqual(t, "healthy", hot.GPUs[1].Status)
+	assert.True(t, hot.GPUs[1].Throttling.Active)
+	assert.Less(t, hot.GPUs[1].HealthScore, before.GPUs[1].HealthScore)
+	assert.Equal(t, "healthy", hot.GPUs[0].Status)
+
+	require.NoError(t, engine.Advance(time.Minute))
+	var withXID tools.AnalyzeXIDResponse
+	callTool(t, xids.Handle, &withXID)
+	require.Equal(t, 1, withXID.ErrorCount)
+	assert.Equal(t, 48, withXID.Errors[0].XIDCode)
+	assert.Equal(t, 0, withXID.Errors[0].GPUIndex)
+	assert.Equal(t, "torchrun", withXID.Errors[0].ProcessName)
+
+	var eccFailed tools.GPUHealthResponse
+	callTool(t, health.Handle, &eccFailed)
+	assert.Equal(t, uint64(1), eccFailed.GPUs[0].ECCErrors.TotalUncorrectableErrors)
+	assert.NotEqual(t, "healthy", eccFailed.GPUs[0].Status)
+}
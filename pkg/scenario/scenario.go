@@ -0,0 +1,162 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scenario drives time-evolving faults on mock GPUs. A scenario
+// starts from an NVML fixture and applies a timeline of events to it:
+// field changes, gradual ramps (e.g., temperature climbing to slowdown)
+// and XID lines written to a fake kernel log, so tools can be observed
+// reacting as conditions change.
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Scenario is a fixture plus a timeline of events applied to it.
+type Scenario struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Fixture describes the GPUs at the start of the scenario
+	Fixture nvml.Fixture `json:"fixture"`
+	// Events are applied in order of their offset from the start
+	Events []Event `json:"events"`
+}
+
+// Event changes one GPU at a point in the timeline. An event may combine
+// several actions; they are applied in field order.
+type Event struct {
+	// At is the offset from the start of the scenario (e.g., "90s")
+	At metav1.Duration `json:"at"`
+	// GPU is the index of the affected GPU
+	GPU int `json:"gpu"`
+	// Set patches the GPU; only the fields set change. An empty error
+	// code removes an injected error.
+	Set *nvml.FixtureDevice `json:"set,omitempty"`
+	// Ramp moves a numeric field linearly from one value to another
+	Ramp *Ramp `json:"ramp,omitempty"`
+	// XID writes an NVRM XID line for the GPU to the kernel log
+	XID *XID `json:"xid,omitempty"`
+	// Kmsg writes a raw message to the kernel log
+	Kmsg string `json:"kmsg,omitempty"`
+}
+
+// Ramp moves a numeric device field from one value to another over a
+// duration. Field is the fixture path of the field, with nested fields
+// separated by dots (e.g., "temperature" or "ecc.correctable").
+type Ramp struct {
+	Field string          `json:"field"`
+	From  float64         `json:"from"`
+	To    float64         `json:"to"`
+	Over  metav1.Duration `json:"over"`
+}
+
+// XID describes an XID error reported by the driver.
+type XID struct {
+	Code int `json:"code"`
+	// PID and Process identify the process that triggered the error
+	// (default "<unknown>")
+	PID     int    `json:"pid,omitempty"`
+	Process string `json:"process,omitempty"`
+	// Message is the text after the process (default: the XID name)
+	Message string `json:"message,omitempty"`
+}
+
+// Load reads a scenario from a YAML or JSON file and validates it.
+// Unknown fields are rejected so typos do not silently do nothing.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var s Scenario
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Validate checks that every event targets an existing GPU and carries a
+// valid action, and sorts the events by offset.
+func (s *Scenario) Validate() error {
+	if len(s.Fixture.Devices) == 0 {
+		return fmt.Errorf("fixture has no devices")
+	}
+
+	for i, event := range s.Events {
+		if event.GPU < 0 || event.GPU >= len(s.Fixture.Devices) {
+			return fmt.Errorf("event %d: gpu %d out of range (count: %d)",
+				i, event.GPU, len(s.Fixture.Devices))
+		}
+		if event.At.Duration < 0 {
+			return fmt.Errorf("event %d: negative offset %s", i, event.At.Duration)
+		}
+		if event.Set == nil && event.Ramp == nil && event.XID == nil &&
+			event.Kmsg == "" {
+			return fmt.Errorf("event %d: no action (set, ramp, xid or kmsg)", i)
+		}
+		if r := event.Ramp; r != nil {
+			if r.Over.Duration < 0 {
+				return fmt.Errorf("event %d: negative ramp duration %s",
+					i, r.Over.Duration)
+			}
+			// Both ends must produce a valid patch
+			for _, value := range []float64{r.From, r.To} {
+				if _, err := r.patch(value); err != nil {
+					return fmt.Errorf("event %d: %w", i, err)
+				}
+			}
+		}
+		if event.XID != nil && event.XID.Code <= 0 {
+			return fmt.Errorf("event %d: invalid XID code %d", i, event.XID.Code)
+		}
+	}
+
+	sort.SliceStable(s.Events, func(i, j int) bool {
+		return s.Events[i].At.Duration < s.Events[j].At.Duration
+	})
+	return nil
+}
+
+// patch returns a fixture patch setting the ramp field to value, rounded
+// to the nearest integer.
+func (r *Ramp) patch(value float64) (*nvml.FixtureDevice, error) {
+	if r.Field == "" {
+		return nil, fmt.Errorf("ramp has no field")
+	}
+
+	// Build {"ecc": {"correctable": 1200}} and decode it into a patch,
+	// which rejects unknown and non-numeric fields
+	keys := strings.Split(r.Field, ".")
+	var node interface{} = math.Round(value)
+	for i := len(keys) - 1; i >= 0; i-- {
+		node = map[string]interface{}{keys[i]: node}
+	}
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ramp value for %s: %w", r.Field, err)
+	}
+
+	// Decode as JSON: YAML decoding would turn numbers into strings
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var patch nvml.FixtureDevice
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, fmt.Errorf("cannot ramp field %q to %v: %w",
+			r.Field, value, err)
+	}
+	return &patch, nil
+}
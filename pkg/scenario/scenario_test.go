@@ -0,0 +1,140 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScenario writes a scenario file into a temporary directory.
+func writeScenario(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeScenario(t, `
+name: test
+fixture:
+  devices: [{}, {}]
+events:
+  - at: 1m
+    gpu: 1
+    xid: {code: 79}
+  - at: 10s
+    gpu: 0
+    ramp: {field: ecc.correctable, from: 0, to: 100, over: 30s}
+`)
+
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "test", s.Name)
+	require.Len(t, s.Events, 2)
+
+	// Events are sorted by offset
+	assert.Equal(t, 10*time.Second, s.Events[0].At.Duration)
+	assert.Equal(t, "ecc.correctable", s.Events[0].Ramp.Field)
+	assert.Equal(t, time.Minute, s.Events[1].At.Duration)
+	assert.Equal(t, 79, s.Events[1].XID.Code)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no devices",
+			content: "events: []\n",
+			wantErr: "no devices",
+		},
+		{
+			name:    "unknown field",
+			content: "fixture: {devices: [{}]}\nevents:\n  - {at: 1s, gpu: 0, kmesg: x}\n",
+			wantErr: "kmesg",
+		},
+		{
+			name:    "GPU out of range",
+			content: "fixture: {devices: [{}]}\nevents:\n  - {at: 1s, gpu: 1, kmsg: x}\n",
+			wantErr: "event 0: gpu 1 out of range",
+		},
+		{
+			name:    "no action",
+			content: "fixture: {devices: [{}]}\nevents:\n  - {at: 1s, gpu: 0}\n",
+			wantErr: "no action",
+		},
+		{
+			name: "unknown ramp field",
+			content: "fixture: {devices: [{}]}\nevents:\n" +
+				"  - {at: 1s, gpu: 0, ramp: {field: temp, from: 1, to: 2, over: 1s}}\n",
+			wantErr: `cannot ramp field "temp"`,
+		},
+		{
+			name: "non-numeric ramp field",
+			content: "fixture: {devices: [{}]}\nevents:\n" +
+				"  - {at: 1s, gpu: 0, ramp: {field: name, from: 1, to: 2, over: 1s}}\n",
+			wantErr: `cannot ramp field "name"`,
+		},
+		{
+			name: "negative ramp value",
+			content: "fixture: {devices: [{}]}\nevents:\n" +
+				"  - {at: 1s, gpu: 0, ramp: {field: temperature, from: -5, to: 2, over: 1s}}\n",
+			wantErr: `cannot ramp field "temperature"`,
+		},
+		{
+			name:    "invalid XID code",
+			content: "fixture: {devices: [{}]}\nevents:\n  - {at: 1s, gpu: 0, xid: {code: 0}}\n",
+			wantErr: "invalid XID code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeScenario(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRamp_patch(t *testing.T) {
+	ramp := &Ramp{Field: "ecc.correctable"}
+
+	patch, err := ramp.patch(1199.6)
+	require.NoError(t, err)
+	require.NotNil(t, patch.ECC)
+	require.NotNil(t, patch.ECC.Correctable)
+	assert.Equal(t, uint64(1200), *patch.ECC.Correctable)
+	assert.Nil(t, patch.ECC.Enabled)
+	assert.Nil(t, patch.Temperature)
+}
+
+func TestExampleScenarios(t *testing.T) {
+	paths, err := filepath.Glob("../../examples/scenarios/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			s, err := Load(path)
+			require.NoError(t, err)
+
+			// Play the whole scenario
+			engine, err := New(s, filepath.Join(t.TempDir(), "kmsg"))
+			require.NoError(t, err)
+			require.NoError(t, engine.Advance(time.Hour))
+			assert.True(t, engine.Done())
+		})
+	}
+}
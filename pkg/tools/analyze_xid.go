@@ -4,10 +4,12 @@
 package tools
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
@@ -34,15 +36,77 @@ func NewAnalyzeXIDHandler(nvmlClient nvml.Interface) *AnalyzeXIDHandler {
 	}
 }
 
+// NewAnalyzeXIDHandlerWithKmsgPath creates a XID analysis handler that reads
+// kernel logs in /dev/kmsg format from the given file instead of the host
+// kernel, as used by simulated nodes. bootTime is the wall-clock time the
+// log timestamps count from; zero uses the host boot time.
+func NewAnalyzeXIDHandlerWithKmsgPath(
+	nvmlClient nvml.Interface,
+	path string,
+	bootTime time.Time,
+) *AnalyzeXIDHandler {
+	parser := xid.NewParserWithKmsgPath(path)
+	if !bootTime.IsZero() {
+		parser.SetBootTime(bootTime)
+	}
+	return &AnalyzeXIDHandler{
+		nvmlClient: nvmlClient,
+		parser:     parser,
+	}
+}
+
+// NewAnalyzeXIDHandlerWithJournal creates a XID analysis handler that reads
+// XID events from the journal of follower, which holds events the kernel
+// ring buffer has already rotated out. While the follower is not running
+// the journal misses new events, so they are read from the kernel log.
+func NewAnalyzeXIDHandlerWithJournal(
+	nvmlClient nvml.Interface,
+	follower *xid.Follower,
+) *AnalyzeXIDHandler {
+	return &AnalyzeXIDHandler{
+		nvmlClient: nvmlClient,
+		parser:     journalParser{follower: follower},
+	}
+}
+
+// journalParser serves XID events from the journal of a follower, or from
+// the kernel log the follower reads when it is not running.
+type journalParser struct {
+	follower *xid.Follower
+}
+
+// ParseKernelLogs implements xidParser.
+func (p journalParser) ParseKernelLogs(ctx context.Context) ([]xid.XIDEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+	if !p.follower.Running() {
+		klog.V(2).InfoS("XID follower not running, reading the kernel log")
+		return p.follower.Parser().ParseKernelLogs(ctx)
+	}
+	return p.follower.Journal().Events(), nil
+}
+
 // EnrichedXIDError represents an XID error enriched with GPU metadata and
 // error information.
 type EnrichedXIDError struct {
-	XIDCode     int    `json:"xid"`
+	// Timestamp is when the error was logged, if known
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Kind is "xid", "sxid" (NVSwitch) or "nvrm" (driver failure without
+	// an XID)
+	Kind string `json:"kind"`
+	// XIDCode is the XID or SXid code, 0 for NVRM events
+	XIDCode int `json:"xid"`
+	// Event is the ID of an NVRM event
+	Event string `json:"event,omitempty"`
+	// Device is the NVSwitch an SXid was logged for
+	Device      string `json:"device,omitempty"`
 	Name        string `json:"name"`
 	Severity    string `json:"severity"`
 	Description string `json:"description"`
 	SREAction   string `json:"sre_action"`
 	Category    string `json:"category"`
+	HealthCheck string `json:"health_check,omitempty"`
 	GPUIndex    int    `json:"gpu_index"`
 	GPUName     string `json:"gpu_name"`
 	GPUUUID     string `json:"gpu_uuid"`
@@ -63,11 +127,16 @@ type SeveritySummary struct {
 // AnalyzeXIDResponse is the structured response from the analyze_xid_errors
 // tool.
 type AnalyzeXIDResponse struct {
-	Status         string             `json:"status"`
-	ErrorCount     int                `json:"error_count"`
-	Errors         []EnrichedXIDError `json:"errors"`
-	Summary        SeveritySummary    `json:"summary"`
-	Recommendation string             `json:"recommendation"`
+	Status string `json:"status"`
+	// ErrorCount counts all matching errors, also those dropped by limit
+	ErrorCount int                `json:"error_count"`
+	Errors     []EnrichedXIDError `json:"errors"`
+	// Truncated is set when limit dropped older errors
+	Truncated      bool            `json:"truncated,omitempty"`
+	Summary        SeveritySummary `json:"summary"`
+	Recommendation string          `json:"recommendation"`
+	// Filters echoes the filters applied, if any
+	Filters *XIDFilter `json:"filters,omitempty"`
 }
 
 // Handle processes the analyze_xid_errors tool request.
@@ -84,6 +153,15 @@ func (h *AnalyzeXIDHandler) Handle(
 			fmt.Sprintf("operation cancelled: %s", err)), nil
 	}
 
+	filter, err := parseXIDFilter(request.GetArguments(), time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var appliedFilter *XIDFilter
+	if filter.active() {
+		appliedFilter = &filter
+	}
+
 	// Parse kernel logs for XID events (prefers /dev/kmsg, falls back to dmesg)
 	events, err := h.parser.ParseKernelLogs(ctx)
 	if err != nil {
@@ -94,6 +172,15 @@ func (h *AnalyzeXIDHandler) Handle(
 
 	klog.V(4).InfoS("parsed kernel logs", "events", len(events))
 
+	// Time and code filters need no GPU lookup
+	matched := make([]xid.XIDEvent, 0, len(events))
+	for _, event := range events {
+		if filter.matchEvent(event) {
+			matched = append(matched, event)
+		}
+	}
+	events = matched
+
 	// If no errors found, return success immediately
 	if len(events) == 0 {
 		response := AnalyzeXIDResponse{
@@ -102,6 +189,7 @@ func (h *AnalyzeXIDHandler) Handle(
 			Errors:         []EnrichedXIDError{},
 			Summary:        SeveritySummary{},
 			Recommendation: "No XID errors detected. GPU health is good.",
+			Filters:        appliedFilter,
 		}
 		return h.marshalResponse(response)
 	}
@@ -114,6 +202,14 @@ func (h *AnalyzeXIDHandler) Handle(
 			fmt.Sprintf("failed to enrich error data: %s", err)), nil
 	}
 
+	filtered := enrichedErrors[:0]
+	for _, e := range enrichedErrors {
+		if filter.matchError(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	enrichedErrors = filtered
+
 	// Create summary by severity
 	summary := h.createSummary(enrichedErrors)
 
@@ -130,6 +226,13 @@ func (h *AnalyzeXIDHandler) Handle(
 		Errors:         enrichedErrors,
 		Summary:        summary,
 		Recommendation: recommendation,
+		Filters:        appliedFilter,
+	}
+
+	// Kernel logs are oldest first, so the most recent errors are last
+	if filter.Limit > 0 && len(enrichedErrors) > filter.Limit {
+		response.Errors = enrichedErrors[len(enrichedErrors)-filter.Limit:]
+		response.Truncated = true
 	}
 
 	klog.InfoS("analyze_xid_errors completed",
@@ -152,19 +255,31 @@ func (h *AnalyzeXIDHandler) enrichEvents(
 				err)
 		}
 
-		// Lookup XID error information
-		info := xid.LookupOrUnknown(event.XIDCode)
+		// Lookup XID, SXid or NVRM event information
+		info := xid.Classify(event)
+		kind := event.Kind
+		if kind == "" {
+			kind = xid.KindXID
+		}
 
-		// Find GPU by PCI bus ID
-		gpuIndex, gpuInfo := h.findGPUByPCI(ctx, event.PCIBusID)
+		// Find GPU by PCI bus ID; SXids are logged for NVSwitches
+		gpuIndex, gpuInfo := -1, gpuLookupResult{}
+		if kind != xid.KindSXid && event.PCIBusID != "" {
+			gpuIndex, gpuInfo = h.findGPUByPCI(ctx, event.PCIBusID)
+		}
 
 		enriched := EnrichedXIDError{
+			Timestamp:   xidTimestamp(event.Timestamp),
+			Kind:        kind,
 			XIDCode:     event.XIDCode,
+			Event:       event.EventID,
+			Device:      event.Device,
 			Name:        info.Name,
 			Severity:    info.Severity,
 			Description: info.Description,
 			SREAction:   info.Action,
 			Category:    info.Category,
+			HealthCheck: info.HealthCheck,
 			GPUIndex:    gpuIndex,
 			GPUName:     gpuInfo.Name,
 			GPUUUID:     gpuInfo.UUID,
@@ -180,6 +295,15 @@ func (h *AnalyzeXIDHandler) enrichEvents(
 	return enrichedErrors, nil
 }
 
+// xidTimestamp returns the UTC time of an event, or nil if it is unknown.
+func xidTimestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
 // gpuLookupResult holds GPU information found by PCI bus ID.
 type gpuLookupResult struct {
 	Name string
@@ -296,8 +420,7 @@ func (h *AnalyzeXIDHandler) generateRecommendation(
 		for _, err := range errors {
 			if err.Severity == "fatal" {
 				recommendations = append(recommendations,
-					fmt.Sprintf("- GPU %d: XID %d (%s)",
-						err.GPUIndex, err.XIDCode, err.Name))
+					"- "+describeXIDError(err))
 			}
 		}
 	}
@@ -325,6 +448,27 @@ func (h *AnalyzeXIDHandler) generateRecommendation(
 	return "XID errors detected. Review detailed error information above."
 }
 
+// describeXIDError names an error and where it was logged, e.g.
+// "GPU 0: XID 79 (GPU Fallen Off Bus)".
+func describeXIDError(e EnrichedXIDError) string {
+	switch e.Kind {
+	case xid.KindSXid:
+		return fmt.Sprintf("%s: SXid %d (%s)",
+			cmp.Or(e.Device, "NVSwitch "+e.PCIBusID), e.XIDCode, e.Name)
+	case xid.KindNVRM:
+		switch {
+		case e.GPUIndex >= 0:
+			return fmt.Sprintf("GPU %d: %s", e.GPUIndex, e.Name)
+		case e.PCIBusID != "":
+			return fmt.Sprintf("GPU %s: %s", e.PCIBusID, e.Name)
+		default:
+			return e.Name
+		}
+	default:
+		return fmt.Sprintf("GPU %d: XID %d (%s)", e.GPUIndex, e.XIDCode, e.Name)
+	}
+}
+
 // marshalResponse marshals the response to JSON and returns as tool result.
 func (h *AnalyzeXIDHandler) marshalResponse(
 	response AnalyzeXIDResponse,
@@ -346,9 +490,20 @@ func GetAnalyzeXIDTool() mcp.Tool {
 			"Analyze NVIDIA GPU XID (eXception ID) errors from kernel logs. "+
 				"XID errors are hardware failures logged by the NVIDIA driver "+
 				"indicating issues like memory corruption, bus failures, or "+
-				"thermal problems. Returns structured error data with severity "+
+				"thermal problems. Also reports NVSwitch SXid errors (kind "+
+				"sxid) and driver failures logged without an XID, such as a "+
+				"GPU fallen off the bus (kind nvrm). "+
+				"Returns structured error data with severity "+
 				"classifications and SRE-actionable recommendations. "+
+				"Filter by time (since or time_range), GPU, minimum "+
+				"severity and XID code, and cap the result with limit. "+
+				"In gateway mode, node_name, node_selector and gpu_model "+
+				"restrict the nodes queried. "+
 				"Note: May require elevated permissions to read kernel logs.",
 		),
+		withXIDFilters(),
+		withNodeTargeting(),
+		withCacheControl(),
+		withCallBudget(),
 	)
 }
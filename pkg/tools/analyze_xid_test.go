@@ -6,7 +6,10 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
@@ -187,6 +190,214 @@ func TestAnalyzeXIDHandler_Handle_WithMultipleErrors(t *testing.T) {
 	assert.Contains(t, response.Recommendation, "critical")
 }
 
+func TestAnalyzeXIDHandler_Handle_Filters(t *testing.T) {
+	now := time.Now().UTC()
+	handler := NewAnalyzeXIDHandler(nvml.NewMock(2))
+	handler.parser = &mockXIDParser{
+		events: []xid.XIDEvent{
+			{Timestamp: now.Add(-48 * time.Hour), XIDCode: 48, PCIBusID: "0000:01:00.0"},
+			{Timestamp: now.Add(-2 * time.Hour), XIDCode: 31, PCIBusID: "0000:01:00.0"},
+			{Timestamp: now.Add(-30 * time.Minute), XIDCode: 45, PCIBusID: "0000:02:00.0"},
+			{Timestamp: now.Add(-10 * time.Minute), XIDCode: 79, PCIBusID: "0000:02:00.0"},
+			// No kernel timestamp
+			{XIDCode: 13, PCIBusID: "0000:01:00.0"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		wantXIDs      []int
+		wantCount     int
+		wantTruncated bool
+		wantErr       string
+	}{
+		{
+			name:      "no filters",
+			args:      map[string]interface{}{},
+			wantXIDs:  []int{48, 31, 45, 79, 13},
+			wantCount: 5,
+		},
+		{
+			name:      "time_range",
+			args:      map[string]interface{}{"time_range": "1h"},
+			wantXIDs:  []int{45, 79},
+			wantCount: 2,
+		},
+		{
+			name:      "time_range in days",
+			args:      map[string]interface{}{"time_range": "1d"},
+			wantXIDs:  []int{31, 45, 79},
+			wantCount: 3,
+		},
+		{
+			name: "since",
+			args: map[string]interface{}{
+				"since": now.Add(-3 * time.Hour).Format(time.RFC3339),
+			},
+			wantXIDs:  []int{31, 45, 79},
+			wantCount: 3,
+		},
+		{
+			name:      "gpu_index",
+			args:      map[string]interface{}{"gpu_index": float64(1)},
+			wantXIDs:  []int{45, 79},
+			wantCount: 2,
+		},
+		{
+			name:      "min_severity",
+			args:      map[string]interface{}{"min_severity": "fatal"},
+			wantXIDs:  []int{48, 79},
+			wantCount: 2,
+		},
+		{
+			name: "xid_codes",
+			args: map[string]interface{}{
+				"xid_codes": []interface{}{float64(13), float64(31)},
+			},
+			wantXIDs:  []int{31, 13},
+			wantCount: 2,
+		},
+		{
+			name:          "limit keeps the most recent",
+			args:          map[string]interface{}{"limit": float64(2)},
+			wantXIDs:      []int{79, 13},
+			wantCount:     5,
+			wantTruncated: true,
+		},
+		{
+			name: "since and time_range",
+			args: map[string]interface{}{
+				"since": now.Format(time.RFC3339), "time_range": "1h",
+			},
+			wantErr: "either since or time_range",
+		},
+		{
+			name:    "invalid time_range",
+			args:    map[string]interface{}{"time_range": "yesterday"},
+			wantErr: "invalid time_range",
+		},
+		{
+			name:    "invalid min_severity",
+			args:    map[string]interface{}{"min_severity": "severe"},
+			wantErr: "invalid min_severity",
+		},
+		{
+			name:    "invalid limit",
+			args:    map[string]interface{}{"limit": float64(0)},
+			wantErr: "invalid limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = tt.args
+
+			result, err := handler.Handle(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent, ok := mcp.AsTextContent(result.Content[0])
+			require.True(t, ok)
+			if tt.wantErr != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tt.wantErr)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var response AnalyzeXIDResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			xids := make([]int, 0, len(response.Errors))
+			for _, e := range response.Errors {
+				xids = append(xids, e.XIDCode)
+			}
+			assert.Equal(t, tt.wantXIDs, xids)
+			assert.Equal(t, tt.wantCount, response.ErrorCount)
+			assert.Equal(t, tt.wantTruncated, response.Truncated)
+			assert.Equal(t, len(tt.args) > 0, response.Filters != nil)
+		})
+	}
+}
+
+func TestAnalyzeXIDHandler_Handle_Journal(t *testing.T) {
+	dir := t.TempDir()
+	journal, err := xid.OpenJournal(xid.JournalConfig{
+		Path: filepath.Join(dir, "xid-journal.jsonl"),
+	})
+	require.NoError(t, err)
+	defer func() { _ = journal.Close() }()
+
+	// An event the kernel ring buffer may no longer hold
+	logged := time.Now().Add(-3 * time.Hour).UTC().Truncate(time.Second)
+	_, err = journal.Append("boot-1", 42, xid.XIDEvent{
+		Timestamp:  logged,
+		XIDCode:    79,
+		PCIBusID:   "0000:01:00.0",
+		RawMessage: "NVRM: Xid (PCI:0000:01:00.0): 79",
+		GPUIndex:   -1,
+	})
+	require.NoError(t, err)
+
+	kmsgPath := filepath.Join(dir, "kmsg")
+	require.NoError(t, os.WriteFile(kmsgPath, []byte(
+		"4,0,2000000,-;NVRM: Xid (PCI:0000:01:00.0): 48, pid=1234, name=python3\n",
+	), 0o644))
+	parser := xid.NewParserWithKmsgPath(kmsgPath)
+	parser.SetBootTime(time.Now().Add(-time.Hour))
+	follower := xid.NewFollower(parser, journal)
+
+	handler := NewAnalyzeXIDHandlerWithJournal(nvml.NewMock(1), follower)
+	analyze := func() AnalyzeXIDResponse {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]interface{}{"time_range": "24h"}
+		result, err := handler.Handle(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		textContent, ok := mcp.AsTextContent(result.Content[0])
+		require.True(t, ok)
+		var response AnalyzeXIDResponse
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		return response
+	}
+
+	// Until the follower runs, the journal misses new events and the
+	// kernel log is read instead
+	response := analyze()
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, 48, response.Errors[0].XIDCode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, follower.Start(ctx))
+	require.Eventually(t, func() bool {
+		return len(journal.Events()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	response = analyze()
+	require.Len(t, response.Errors, 2)
+	var journaled *EnrichedXIDError
+	for i := range response.Errors {
+		if response.Errors[i].XIDCode == 79 {
+			journaled = &response.Errors[i]
+		}
+	}
+	require.NotNil(t, journaled)
+	assert.Equal(t, 0, journaled.GPUIndex)
+	require.NotNil(t, journaled.Timestamp)
+	assert.True(t, logged.Equal(*journaled.Timestamp))
+
+	// A stopped follower falls back to the kernel log again
+	cancel()
+	require.Eventually(t, func() bool {
+		return !follower.Running()
+	}, 5*time.Second, 10*time.Millisecond)
+	response = analyze()
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, 48, response.Errors[0].XIDCode)
+}
+
 func TestAnalyzeXIDHandler_Handle_ContextCancellation(t *testing.T) {
 	mockClient := nvml.NewMock(1)
 	handler := NewAnalyzeXIDHandler(mockClient)
@@ -483,6 +694,15 @@ func TestGetAnalyzeXIDTool(t *testing.T) {
 	assert.Contains(t, tool.Description, "XID")
 	assert.Contains(t, tool.Description, "kernel logs")
 	assert.Contains(t, tool.Description, "severity")
+
+	for _, arg := range []string{
+		"node_name", "node_selector", "gpu_model", "max_age", "no_cache",
+		"timeout_seconds", "since", "time_range", "gpu_uuid", "gpu_index",
+		"min_severity", "xid_codes", "limit",
+	} {
+		assert.Contains(t, tool.InputSchema.Properties, arg)
+	}
+	assert.Empty(t, tool.InputSchema.Required)
 }
 
 func TestAnalyzeXIDHandler_enrichEvents(t *testing.T) {
@@ -519,6 +739,26 @@ func TestAnalyzeXIDHandler_enrichEvents(t *testing.T) {
 	assert.Equal(t, "python3", e.ProcessName)
 }
 
+func TestAnalyzeXIDHandler_enrichEvents_MemoryRepairXID(t *testing.T) {
+	mockClient := nvml.NewMock(1)
+	handler := NewAnalyzeXIDHandler(mockClient)
+
+	events := []xid.XIDEvent{
+		{
+			XIDCode:    64,
+			PCIBusID:   "0000:01:00.0", // Mock GPU 0
+			RawMessage: "[100.0] NVRM: Xid (PCI:0000:01:00.0): 64",
+		},
+	}
+
+	enriched, err := handler.enrichEvents(context.Background(), events)
+
+	require.NoError(t, err)
+	require.Len(t, enriched, 1)
+	assert.Equal(t, "memory", enriched[0].Category)
+	assert.Contains(t, enriched[0].HealthCheck, "get_gpu_health")
+}
+
 func TestAnalyzeXIDHandler_enrichEvents_ContextCancellation(t *testing.T) {
 	mockClient := nvml.NewMock(1)
 	handler := NewAnalyzeXIDHandler(mockClient)
@@ -618,3 +858,60 @@ func TestAnalyzeXIDResponse_JSONSerialization(t *testing.T) {
 	assert.Equal(t, response.ErrorCount, decoded.ErrorCount)
 	assert.Equal(t, response.Summary, decoded.Summary)
 }
+
+func TestAnalyzeXIDHandler_enrichEvents_SXidAndNVRM(t *testing.T) {
+	mockClient := nvml.NewMock(1)
+	handler := NewAnalyzeXIDHandler(mockClient)
+
+	events := []xid.XIDEvent{
+		{
+			Kind:       xid.KindSXid,
+			XIDCode:    20034,
+			PCIBusID:   "0000:05:00.0",
+			Device:     "nvidia-nvswitch0",
+			Fatal:      true,
+			RawMessage: "nvidia-nvswitch0: SXid (PCI:0000:05:00.0): 20034, Fatal, Link 30 LTSSM Fault Up",
+		},
+		{
+			Kind:       xid.KindNVRM,
+			EventID:    "gpu_fallen_off_bus",
+			PCIBusID:   "0000:01:00.0", // Mock GPU 0
+			RawMessage: "NVRM: GPU 0000:01:00.0: GPU has fallen off the bus.",
+		},
+		{
+			Kind:       xid.KindNVRM,
+			EventID:    "api_mismatch",
+			RawMessage: "NVRM: API mismatch: the client has the version 535.104.05, but",
+		},
+	}
+
+	enriched, err := handler.enrichEvents(context.Background(), events)
+	require.NoError(t, err)
+	require.Len(t, enriched, 3)
+
+	// NVSwitches are not GPUs
+	sxid := enriched[0]
+	assert.Equal(t, xid.KindSXid, sxid.Kind)
+	assert.Equal(t, 20034, sxid.XIDCode)
+	assert.Equal(t, "nvidia-nvswitch0", sxid.Device)
+	assert.Equal(t, "fatal", sxid.Severity)
+	assert.Equal(t, "nvswitch", sxid.Category)
+	assert.Equal(t, -1, sxid.GPUIndex)
+	assert.Empty(t, sxid.GPUUUID)
+
+	fallen := enriched[1]
+	assert.Equal(t, xid.KindNVRM, fallen.Kind)
+	assert.Equal(t, "gpu_fallen_off_bus", fallen.Event)
+	assert.Equal(t, "fatal", fallen.Severity)
+	assert.Equal(t, 0, fallen.GPUIndex)
+	assert.NotEmpty(t, fallen.GPUUUID)
+
+	mismatch := enriched[2]
+	assert.Equal(t, "driver", mismatch.Category)
+	assert.Equal(t, -1, mismatch.GPUIndex)
+
+	recommendation := handler.generateRecommendation(enriched,
+		handler.createSummary(enriched))
+	assert.Contains(t, recommendation, "nvidia-nvswitch0: SXid 20034")
+	assert.Contains(t, recommendation, "GPU 0: GPU Fallen Off Bus")
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultProcRoot is the procfs mount used to inspect host processes.
+const defaultProcRoot = "/proc"
+
+// podUIDPattern matches the pod UID embedded in kubelet cgroup paths.
+// The cgroupfs driver uses dashes, the systemd driver uses underscores:
+//   - /kubepods/burstable/pod0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b/<id>
+//   - /kubepods.slice/.../kubepods-besteffort-pod0f1e2d3c_4b5a_..._5b.slice
+var podUIDPattern = regexp.MustCompile(
+	`pod([0-9a-fA-F]{8}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{4}` +
+		`[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{12})`)
+
+// containerIDPattern matches the container ID in the last cgroup path
+// element. The cgroupfs driver uses the bare ID, the systemd driver wraps
+// it in a runtime-prefixed scope:
+//   - <64 hex>
+//   - cri-containerd-<64 hex>.scope, crio-<64 hex>.scope, docker-<64 hex>.scope
+var containerIDPattern = regexp.MustCompile(
+	`^(?:[a-z-]+-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+// ProcessCgroup identifies the pod and container owning a host process.
+type ProcessCgroup struct {
+	PID           int    `json:"pid"`
+	PodUID        string `json:"pod_uid"`
+	ContainerID   string `json:"container_id,omitempty"`
+	CgroupVersion int    `json:"cgroup_version"`
+}
+
+// ProcessResolver maps host PIDs to pods by inspecting /proc/<pid>/cgroup.
+// The procfs root is configurable so the agent can read the host's procfs
+// mounted at a different path (e.g., /host/proc) without sharing the host
+// PID namespace.
+type ProcessResolver struct {
+	procRoot string
+}
+
+// NewProcessResolver creates a resolver reading from procRoot.
+// An empty procRoot defaults to /proc.
+func NewProcessResolver(procRoot string) *ProcessResolver {
+	if procRoot == "" {
+		procRoot = defaultProcRoot
+	}
+	return &ProcessResolver{procRoot: procRoot}
+}
+
+// Resolve returns the pod UID and container ID of the given process.
+// Returns an error if the process does not exist or is not running inside
+// a Kubernetes pod. ContainerID is empty for pod-level cgroups (e.g., the
+// sandbox) where no container scope is present.
+func (r *ProcessResolver) Resolve(pid int) (*ProcessCgroup, error) {
+	cgroupPath := filepath.Join(r.procRoot, strconv.Itoa(pid), "cgroup")
+	data, err := os.ReadFile(cgroupPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("process %d not found in %s "+
+				"(agent may not share the host PID namespace)", pid, r.procRoot)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", cgroupPath, err)
+	}
+
+	// Each line is "hierarchy-ID:controller-list:cgroup-path". cgroup v2
+	// has a single "0::<path>" line; v1 has one line per hierarchy, all
+	// pointing at the same pod cgroup for kubelet-managed processes.
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		m := podUIDPattern.FindStringSubmatch(fields[2])
+		if len(m) < 2 {
+			continue
+		}
+
+		version := 1
+		if fields[0] == "0" && fields[1] == "" {
+			version = 2
+		}
+
+		result := &ProcessCgroup{
+			PID:           pid,
+			PodUID:        strings.ReplaceAll(strings.ToLower(m[1]), "_", "-"),
+			CgroupVersion: version,
+		}
+		if c := containerIDPattern.FindStringSubmatch(
+			path.Base(fields[2])); len(c) >= 2 {
+			result.ContainerID = c[1]
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("process %d is not running in a Kubernetes pod", pid)
+}
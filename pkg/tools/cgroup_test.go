@@ -0,0 +1,122 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPodUID      = "0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b"
+	testPodUIDUnder = "0f1e2d3c_4b5a_6978_8a9b_0c1d2e3f4a5b"
+	testContainerID = "3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c" +
+		"9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a"
+)
+
+// writeCgroupFile creates <procRoot>/<pid>/cgroup with the given content.
+func writeCgroupFile(t *testing.T, procRoot string, pid int, content string) {
+	t.Helper()
+	dir := filepath.Join(procRoot, fmt.Sprintf("%d", pid))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "cgroup"), []byte(content), 0o644))
+}
+
+func TestNewProcessResolver(t *testing.T) {
+	assert.Equal(t, "/proc", NewProcessResolver("").procRoot)
+	assert.Equal(t, "/host/proc", NewProcessResolver("/host/proc").procRoot)
+}
+
+func TestProcessResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name            string
+		cgroup          string
+		wantContainerID string
+		wantVersion     int
+		wantErr         string
+	}{
+		{
+			name: "cgroup v1 cgroupfs driver",
+			cgroup: "12:memory:/kubepods/burstable/pod" + testPodUID +
+				"/" + testContainerID + "\n" +
+				"11:devices:/kubepods/burstable/pod" + testPodUID +
+				"/" + testContainerID + "\n",
+			wantContainerID: testContainerID,
+			wantVersion:     1,
+		},
+		{
+			name: "cgroup v1 systemd driver with docker",
+			cgroup: "4:cpu,cpuacct:/kubepods.slice/kubepods-pod" +
+				testPodUIDUnder + ".slice/docker-" + testContainerID +
+				".scope\n",
+			wantContainerID: testContainerID,
+			wantVersion:     1,
+		},
+		{
+			name: "cgroup v2 systemd driver with containerd",
+			cgroup: "0::/kubepods.slice/kubepods-besteffort.slice/" +
+				"kubepods-besteffort-pod" + testPodUIDUnder + ".slice/" +
+				"cri-containerd-" + testContainerID + ".scope\n",
+			wantContainerID: testContainerID,
+			wantVersion:     2,
+		},
+		{
+			name: "cgroup v2 cgroupfs driver with cri-o",
+			cgroup: "0::/kubepods/burstable/pod" + testPodUID +
+				"/crio-" + testContainerID + "\n",
+			wantContainerID: testContainerID,
+			wantVersion:     2,
+		},
+		{
+			name: "hybrid hierarchy skips empty unified line",
+			cgroup: "0::/\n" +
+				"3:memory:/kubepods/pod" + testPodUID + "/" +
+				testContainerID + "\n",
+			wantContainerID: testContainerID,
+			wantVersion:     1,
+		},
+		{
+			name: "pod-level cgroup has no container",
+			cgroup: "0::/kubepods.slice/kubepods-pod" + testPodUIDUnder +
+				".slice\n",
+			wantVersion: 2,
+		},
+		{
+			name:    "host process",
+			cgroup:  "0::/system.slice/sshd.service\n",
+			wantErr: "not running in a Kubernetes pod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			procRoot := t.TempDir()
+			writeCgroupFile(t, procRoot, 100, tt.cgroup)
+
+			got, err := NewProcessResolver(procRoot).Resolve(100)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 100, got.PID)
+			assert.Equal(t, testPodUID, got.PodUID)
+			assert.Equal(t, tt.wantContainerID, got.ContainerID)
+			assert.Equal(t, tt.wantVersion, got.CgroupVersion)
+		})
+	}
+}
+
+func TestProcessResolver_ResolveMissingProcess(t *testing.T) {
+	_, err := NewProcessResolver(t.TempDir()).Resolve(999999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "process 999999 not found")
+}
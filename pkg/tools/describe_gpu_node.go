@@ -7,8 +7,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
@@ -17,10 +19,19 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// GPUHardwareSource provides the GPU hardware details of a node when no
+// local NVML client is available. In gateway mode it queries the node's
+// agent.
+type GPUHardwareSource interface {
+	DescribeGPUHardware(ctx context.Context, nodeName string) (
+		DriverInfo, []GPUDescription, error)
+}
+
 // DescribeGPUNodeHandler handles the describe_gpu_node tool.
 type DescribeGPUNodeHandler struct {
 	clientset  kubernetes.Interface
 	nvmlClient nvml.Interface
+	hardware   GPUHardwareSource
 }
 
 // NewDescribeGPUNodeHandler creates a new describe GPU node handler.
@@ -34,14 +45,28 @@ func NewDescribeGPUNodeHandler(
 	}
 }
 
+// NewDescribeGPUNodeHandlerWithHardware creates a describe GPU node handler
+// that reads GPU hardware from a remote source instead of local NVML.
+func NewDescribeGPUNodeHandlerWithHardware(
+	clientset kubernetes.Interface,
+	hardware GPUHardwareSource,
+) *DescribeGPUNodeHandler {
+	return &DescribeGPUNodeHandler{
+		clientset: clientset,
+		hardware:  hardware,
+	}
+}
+
 // GPUNodeDescription represents the full node description.
 type GPUNodeDescription struct {
-	Status  string           `json:"status"`
-	Node    NodeInfo         `json:"node"`
-	Driver  DriverInfo       `json:"driver,omitempty"`
-	GPUs    []GPUDescription `json:"gpus,omitempty"`
-	Pods    []PodGPUSummary  `json:"pods"`
-	Summary GPUNodeSummary   `json:"summary"`
+	Status string           `json:"status"`
+	Node   NodeInfo         `json:"node"`
+	Driver DriverInfo       `json:"driver,omitempty"`
+	GPUs   []GPUDescription `json:"gpus,omitempty"`
+	// HardwareError explains why driver and GPU details are missing
+	HardwareError string          `json:"hardware_error,omitempty"`
+	Pods          []PodGPUSummary `json:"pods"`
+	Summary       GPUNodeSummary  `json:"summary"`
 }
 
 // NodeInfoPartial is used when K8s API access fails but NVML data is available.
@@ -73,6 +98,8 @@ type ResourceInfo struct {
 	CPU       string `json:"cpu"`
 	Memory    string `json:"memory"`
 	NvidiaGPU string `json:"nvidia.com/gpu,omitempty"`
+	// NvidiaMIG holds nvidia.com/mig-* resources keyed by profile
+	NvidiaMIG map[string]string `json:"nvidia.com/mig,omitempty"`
 }
 
 // DriverInfo contains GPU driver information.
@@ -94,10 +121,11 @@ type GPUDescription struct {
 
 // PodGPUSummary is a simplified pod GPU summary for the node description.
 type PodGPUSummary struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	GPUCount  int64  `json:"gpu_count"`
-	Status    string `json:"status"`
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace"`
+	GPUCount       int64  `json:"gpu_count"`
+	MIGDeviceCount int64  `json:"mig_device_count,omitempty"`
+	Status         string `json:"status"`
 }
 
 // GPUNodeSummary provides summary statistics for the GPU node.
@@ -106,6 +134,10 @@ type GPUNodeSummary struct {
 	AllocatedGPUs int64  `json:"allocated_gpus"`
 	AvailableGPUs int64  `json:"available_gpus"`
 	OverallHealth string `json:"overall_health"`
+	// AllocatedMIGDevices and AvailableMIGDevices count the nvidia.com/mig-*
+	// devices of the "mixed" MIG strategy keyed by profile
+	AllocatedMIGDevices map[string]int64 `json:"allocated_mig_devices,omitempty"`
+	AvailableMIGDevices map[string]int64 `json:"available_mig_devices,omitempty"`
 }
 
 // gpuLabelPrefixes are the prefixes for GPU-related labels.
@@ -166,11 +198,20 @@ func (h *DescribeGPUNodeHandler) Handle(
 
 	klog.V(4).InfoS("describing node", "node", nodeName)
 
-	// Get GPU hardware info first (NVML data is always available locally)
+	// Get GPU hardware info first, from local NVML or the node's agent
 	var driverInfo DriverInfo
 	var gpus []GPUDescription
+	var hardwareError string
 	if h.nvmlClient != nil {
 		driverInfo, gpus = h.collectGPUInfo(ctx)
+	} else if h.hardware != nil {
+		var err error
+		driverInfo, gpus, err = h.hardware.DescribeGPUHardware(ctx, nodeName)
+		if err != nil {
+			klog.V(2).InfoS("GPU hardware unavailable",
+				"node", nodeName, "error", err)
+			hardwareError = fmt.Sprintf("GPU hardware unavailable: %s", err)
+		}
 	}
 
 	// Try to get Kubernetes node info, but don't fail if RBAC denies access
@@ -213,9 +254,10 @@ func (h *DescribeGPUNodeHandler) Handle(
 	// Get pods with GPU allocations on this node (graceful on failure)
 	var pods []PodGPUSummary
 	var allocatedGPUs int64
+	var allocatedMIG map[string]int64
 	if h.clientset != nil {
 		var err error
-		pods, allocatedGPUs, err = h.getPodsSummary(ctx, nodeName)
+		pods, allocatedGPUs, allocatedMIG, err = h.getPodsSummary(ctx, nodeName)
 		if err != nil {
 			klog.V(2).InfoS("failed to get pods summary", "node", nodeName, "error", err)
 			// Context cancellation is fatal
@@ -232,7 +274,7 @@ func (h *DescribeGPUNodeHandler) Handle(
 	totalGPUs := len(gpus)
 	if totalGPUs == 0 && node != nil {
 		// Fall back to K8s capacity if no NVML data
-		if gpuCap, ok := node.Status.Capacity[nvidiaGPUResource]; ok {
+		if gpuCap, ok := node.Status.Capacity[k8s.GPUResourceName]; ok {
 			totalGPUs = int(gpuCap.Value())
 		}
 	}
@@ -243,21 +285,33 @@ func (h *DescribeGPUNodeHandler) Handle(
 		AvailableGPUs: int64(totalGPUs) - allocatedGPUs,
 		OverallHealth: h.calculateOverallHealth(gpus),
 	}
+	if node != nil {
+		for profile, count := range MIGResourceCounts(node.Status.Allocatable) {
+			if summary.AvailableMIGDevices == nil {
+				summary.AvailableMIGDevices = make(map[string]int64)
+			}
+			summary.AvailableMIGDevices[profile] = count - allocatedMIG[profile]
+		}
+	}
+	if len(allocatedMIG) > 0 {
+		summary.AllocatedMIGDevices = allocatedMIG
+	}
 
 	// Determine status based on data availability
 	status := "success"
-	if k8sError != "" {
+	if k8sError != "" || hardwareError != "" {
 		status = "partial"
 	}
 
 	// Create response
 	response := GPUNodeDescription{
-		Status:  status,
-		Node:    nodeInfo,
-		Driver:  driverInfo,
-		GPUs:    gpus,
-		Pods:    pods,
-		Summary: summary,
+		Status:        status,
+		Node:          nodeInfo,
+		Driver:        driverInfo,
+		GPUs:          gpus,
+		HardwareError: hardwareError,
+		Pods:          pods,
+		Summary:       summary,
 	}
 
 	// Marshal to JSON
@@ -308,17 +362,19 @@ func (h *DescribeGPUNodeHandler) extractNodeInfo(node *corev1.Node) NodeInfo {
 		CPU:    node.Status.Capacity.Cpu().String(),
 		Memory: node.Status.Capacity.Memory().String(),
 	}
-	if gpuCap, ok := node.Status.Capacity[nvidiaGPUResource]; ok {
+	if gpuCap, ok := node.Status.Capacity[k8s.GPUResourceName]; ok {
 		capacity.NvidiaGPU = gpuCap.String()
 	}
+	capacity.NvidiaMIG = migResources(node.Status.Capacity)
 
 	allocatable := ResourceInfo{
 		CPU:    node.Status.Allocatable.Cpu().String(),
 		Memory: node.Status.Allocatable.Memory().String(),
 	}
-	if gpuAlloc, ok := node.Status.Allocatable[nvidiaGPUResource]; ok {
+	if gpuAlloc, ok := node.Status.Allocatable[k8s.GPUResourceName]; ok {
 		allocatable.NvidiaGPU = gpuAlloc.String()
 	}
+	allocatable.NvidiaMIG = migResources(node.Status.Allocatable)
 
 	return NodeInfo{
 		Name:        node.Name,
@@ -330,6 +386,19 @@ func (h *DescribeGPUNodeHandler) extractNodeInfo(node *corev1.Node) NodeInfo {
 	}
 }
 
+// migResources returns the nvidia.com/mig-* resources in the list keyed by
+// profile, or nil if there are none.
+func migResources(resources corev1.ResourceList) map[string]string {
+	var mig map[string]string
+	for profile, count := range MIGResourceCounts(resources) {
+		if mig == nil {
+			mig = make(map[string]string)
+		}
+		mig[profile] = strconv.FormatInt(count, 10)
+	}
+	return mig
+}
+
 // collectGPUInfo gathers GPU hardware information via NVML.
 func (h *DescribeGPUNodeHandler) collectGPUInfo(
 	ctx context.Context,
@@ -435,14 +504,16 @@ func (h *DescribeGPUNodeHandler) calculateGPUHealthScore(
 	return score
 }
 
-// getPodsSummary gets pods with GPU allocations on the node.
+// getPodsSummary gets pods with GPU allocations on the node, the number of
+// GPUs they request and the number of MIG devices keyed by profile.
 // Returns an error if the context is cancelled during enumeration.
 func (h *DescribeGPUNodeHandler) getPodsSummary(
 	ctx context.Context,
 	nodeName string,
-) ([]PodGPUSummary, int64, error) {
+) ([]PodGPUSummary, int64, map[string]int64, error) {
 	pods := make([]PodGPUSummary, 0)
 	var totalGPUs int64
+	var totalMIG map[string]int64
 
 	// List pods on the node
 	podList, err := h.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
@@ -450,7 +521,7 @@ func (h *DescribeGPUNodeHandler) getPodsSummary(
 	})
 	if err != nil {
 		klog.V(2).InfoS("failed to list pods", "node", nodeName, "error", err)
-		return pods, totalGPUs, nil // Non-fatal, return empty list
+		return pods, totalGPUs, totalMIG, nil // Non-fatal, return empty list
 	}
 
 	for _, pod := range podList.Items {
@@ -458,7 +529,7 @@ func (h *DescribeGPUNodeHandler) getPodsSummary(
 		select {
 		case <-ctx.Done():
 			klog.InfoS("context cancelled during pod summary enumeration")
-			return pods, totalGPUs, ctx.Err()
+			return pods, totalGPUs, totalMIG, ctx.Err()
 		default:
 		}
 
@@ -467,25 +538,33 @@ func (h *DescribeGPUNodeHandler) getPodsSummary(
 			continue
 		}
 
-		var gpuCount int64
+		var gpuCount, migCount int64
 		for _, container := range pod.Spec.Containers {
-			if req, ok := container.Resources.Requests[nvidiaGPUResource]; ok {
+			if req, ok := container.Resources.Requests[k8s.GPUResourceName]; ok {
 				gpuCount += req.Value()
 			}
+			for profile, count := range ContainerMIGDevices(&container) {
+				migCount += count
+				if totalMIG == nil {
+					totalMIG = make(map[string]int64)
+				}
+				totalMIG[profile] += count
+			}
 		}
 
-		if gpuCount > 0 {
+		if gpuCount > 0 || migCount > 0 {
 			pods = append(pods, PodGPUSummary{
-				Name:      pod.Name,
-				Namespace: pod.Namespace,
-				GPUCount:  gpuCount,
-				Status:    string(pod.Status.Phase),
+				Name:           pod.Name,
+				Namespace:      pod.Namespace,
+				GPUCount:       gpuCount,
+				MIGDeviceCount: migCount,
+				Status:         string(pod.Status.Phase),
 			})
 			totalGPUs += gpuCount
 		}
 	}
 
-	return pods, totalGPUs, nil
+	return pods, totalGPUs, totalMIG, nil
 }
 
 // calculateOverallHealth determines the overall health status.
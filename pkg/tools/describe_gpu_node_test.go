@@ -6,8 +6,10 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
@@ -56,12 +58,12 @@ func makeGPUNode(name string, gpuCount int64) *corev1.Node {
 			Capacity: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse("4"),
 				corev1.ResourceMemory: resource.MustParse("16Gi"),
-				nvidiaGPUResource:     *resource.NewQuantity(gpuCount, resource.DecimalSI),
+				k8s.GPUResourceName:   *resource.NewQuantity(gpuCount, resource.DecimalSI),
 			},
 			Allocatable: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse("3920m"),
 				corev1.ResourceMemory: resource.MustParse("15Gi"),
-				nvidiaGPUResource:     *resource.NewQuantity(gpuCount, resource.DecimalSI),
+				k8s.GPUResourceName:   *resource.NewQuantity(gpuCount, resource.DecimalSI),
 			},
 		},
 	}
@@ -369,6 +371,55 @@ func TestDescribeGPUNodeHandler_GPUSummary(t *testing.T) {
 	assert.Equal(t, "healthy", response.Summary.OverallHealth)
 }
 
+func TestDescribeGPUNodeHandler_MIG(t *testing.T) {
+	node := makeGPUNode("gpu-node-1", 0)
+	migResource := corev1.ResourceName("nvidia.com/mig-1g.5gb")
+	node.Status.Capacity[migResource] = resource.MustParse("7")
+	node.Status.Allocatable[migResource] = resource.MustParse("7")
+
+	pod := makePodWithGPU("inference", "ml", "gpu-node-1", 0)
+	pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		migResource: resource.MustParse("2"),
+	}
+
+	//nolint:staticcheck // NewSimpleClientset used for testing
+	clientset := fake.NewSimpleClientset(node, &pod)
+	handler := NewDescribeGPUNodeHandler(clientset, nil)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"node_name": "gpu-node-1",
+	}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response GPUNodeDescription
+	err = json.Unmarshal([]byte(textContent.Text), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"1g.5gb": "7"},
+		response.Node.Capacity.NvidiaMIG)
+	assert.Equal(t, map[string]string{"1g.5gb": "7"},
+		response.Node.Allocatable.NvidiaMIG)
+
+	// MIG-only pods are listed even without whole-GPU requests
+	require.Len(t, response.Pods, 1)
+	assert.Equal(t, "inference", response.Pods[0].Name)
+	assert.Equal(t, int64(0), response.Pods[0].GPUCount)
+	assert.Equal(t, int64(2), response.Pods[0].MIGDeviceCount)
+
+	// MIG devices are accounted per profile, not as whole GPUs
+	assert.Equal(t, int64(0), response.Summary.AllocatedGPUs)
+	assert.Equal(t, map[string]int64{"1g.5gb": 2},
+		response.Summary.AllocatedMIGDevices)
+	assert.Equal(t, map[string]int64{"1g.5gb": 5},
+		response.Summary.AvailableMIGDevices)
+}
+
 func TestDescribeGPUNodeHandler_WithoutNVML(t *testing.T) {
 	node := makeGPUNode("gpu-node-1", 4)
 
@@ -485,3 +536,92 @@ func TestGetDescribeGPUNodeTool(t *testing.T) {
 	assert.Contains(t, tool.Description, "Kubernetes metadata")
 	assert.Contains(t, tool.Description, "NVML")
 }
+
+// fakeHardwareSource returns fixed GPU hardware details.
+type fakeHardwareSource struct {
+	driver DriverInfo
+	gpus   []GPUDescription
+	err    error
+	nodes  []string
+}
+
+func (f *fakeHardwareSource) DescribeGPUHardware(
+	_ context.Context,
+	nodeName string,
+) (DriverInfo, []GPUDescription, error) {
+	f.nodes = append(f.nodes, nodeName)
+	return f.driver, f.gpus, f.err
+}
+
+func TestDescribeGPUNodeHandler_WithHardwareSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        *fakeHardwareSource
+		wantStatus    string
+		wantGPUs      int
+		wantTotal     int
+		wantHealth    string
+		wantHWError   string
+		wantDriverVer string
+	}{
+		{
+			name: "agent hardware merged",
+			source: &fakeHardwareSource{
+				driver: DriverInfo{Version: "575.57.08", CudaVersion: "12.9"},
+				gpus: []GPUDescription{
+					{Index: 0, Name: "Tesla T4", UUID: "GPU-0", HealthScore: 100},
+					{Index: 1, Name: "Tesla T4", UUID: "GPU-1", HealthScore: 60},
+				},
+			},
+			wantStatus:    "success",
+			wantGPUs:      2,
+			wantTotal:     2,
+			wantHealth:    "degraded",
+			wantDriverVer: "575.57.08",
+		},
+		{
+			name: "agent unavailable",
+			source: &fakeHardwareSource{
+				err: errors.New("agent on node gpu-node-1 is not ready"),
+			},
+			wantStatus:  "partial",
+			wantTotal:   4,
+			wantHealth:  "unknown",
+			wantHWError: "GPU hardware unavailable: agent on node gpu-node-1 is not ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//nolint:staticcheck // NewSimpleClientset used for testing
+			clientset := fake.NewSimpleClientset(makeGPUNode("gpu-node-1", 4))
+			handler := NewDescribeGPUNodeHandlerWithHardware(clientset, tt.source)
+
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = map[string]interface{}{
+				"node_name": "gpu-node-1",
+			}
+
+			result, err := handler.Handle(context.Background(), request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent, ok := mcp.AsTextContent(result.Content[0])
+			require.True(t, ok)
+
+			var response GPUNodeDescription
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			assert.Equal(t, []string{"gpu-node-1"}, tt.source.nodes)
+			assert.Equal(t, tt.wantStatus, response.Status)
+			assert.Len(t, response.GPUs, tt.wantGPUs)
+			assert.Equal(t, tt.wantTotal, response.Summary.TotalGPUs)
+			assert.Equal(t, tt.wantHealth, response.Summary.OverallHealth)
+			assert.Equal(t, tt.wantHWError, response.HardwareError)
+			assert.Equal(t, tt.wantDriverVer, response.Driver.Version)
+			// K8s data is kept either way
+			assert.Equal(t, "Tesla-T4", response.Node.Labels["nvidia.com/gpu.product"])
+			assert.Len(t, response.Node.Taints, 1)
+		})
+	}
+}
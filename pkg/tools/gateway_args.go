@@ -0,0 +1,83 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// The options below add arguments that only the gateway reads. Agents
+// ignore them, and the gateway does not forward them to agents.
+
+// withOptions combines several tool options into one.
+func withOptions(opts ...mcp.ToolOption) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		for _, opt := range opts {
+			opt(t)
+		}
+	}
+}
+
+// withNodeTargeting adds the arguments that choose which node agents
+// receive the call.
+func withNodeTargeting() mcp.ToolOption {
+	return withOptions(
+		mcp.WithString("node_name",
+			mcp.Description(
+				"Gateway mode only: query only this node. "+
+					"Ignored in agent mode.",
+			),
+		),
+		mcp.WithString("node_selector",
+			mcp.Description(
+				"Gateway mode only: query only nodes matching this "+
+					"Kubernetes label selector "+
+					"(e.g., \"topology.kubernetes.io/zone=us-west-2a\"). "+
+					"Ignored in agent mode.",
+			),
+		),
+		mcp.WithString("gpu_model",
+			mcp.Description(
+				"Gateway mode only: query only nodes whose "+
+					"nvidia.com/gpu.product label contains this model "+
+					"as whole words (case-insensitive, e.g., \"A100\" "+
+					"matches NVIDIA-A100-SXM4-80GB but \"A10\" does not). "+
+					"Ignored in agent mode.",
+			),
+		),
+	)
+}
+
+// withCacheControl adds the arguments that decide whether a recent
+// aggregated response may be reused.
+func withCacheControl() mcp.ToolOption {
+	return withOptions(
+		mcp.WithNumber("max_age",
+			mcp.Description(
+				"Gateway mode only: oldest cached response to accept, "+
+					"in seconds. 0 forces fresh data. Ignored in agent mode.",
+			),
+			mcp.Min(0),
+		),
+		mcp.WithBoolean("no_cache",
+			mcp.Description(
+				"Gateway mode only: skip the response cache and query "+
+					"the agents. Ignored in agent mode.",
+			),
+		),
+	)
+}
+
+// withCallBudget adds the argument bounding how long the gateway waits for
+// node agents.
+func withCallBudget() mcp.ToolOption {
+	return mcp.WithNumber("timeout_seconds",
+		mcp.Description(
+			"Gateway mode only: total time to wait for node agents, "+
+				"retries included, in seconds (default: server setting, "+
+				"max 80). Nodes that have not answered by then are "+
+				"reported as timed out. Ignored in agent mode.",
+		),
+		mcp.Min(1),
+		mcp.Max(80),
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -51,6 +52,7 @@ type GPUHealthStatus struct {
 	Power       PowerHealth       `json:"power"`
 	Throttling  ThrottlingStatus  `json:"throttling"`
 	ECCErrors   ECCHealth         `json:"ecc_errors"`
+	PCIe        PCIeHealth        `json:"pcie"`
 	Performance PerformanceHealth `json:"performance"`
 	Issues      []HealthIssue     `json:"issues,omitempty"`
 }
@@ -89,12 +91,59 @@ type ThrottlingStatus struct {
 	Status  string   `json:"status"`
 }
 
-// ECCHealth tracks ECC memory error counts.
+// ECCHealth tracks ECC memory error counts and the memory repair state.
+// GPUs before Ampere retire faulty pages, Ampere and newer remap faulty
+// rows; only the mechanism used by the GPU is reported.
 type ECCHealth struct {
-	Enabled                  bool   `json:"enabled"`
-	TotalCorrectableErrors   uint64 `json:"total_correctable_errors"`
-	TotalUncorrectableErrors uint64 `json:"total_uncorrectable_errors"`
-	Status                   string `json:"status"`
+	Enabled                  bool                `json:"enabled"`
+	TotalCorrectableErrors   uint64              `json:"total_correctable_errors"`
+	TotalUncorrectableErrors uint64              `json:"total_uncorrectable_errors"`
+	RetiredPages             *RetiredPagesHealth `json:"retired_pages,omitempty"`
+	RowRemapping             *RowRemappingHealth `json:"row_remapping,omitempty"`
+	ResetRequired            bool                `json:"reset_required"`
+	ReplaceRequired          bool                `json:"replace_required"`
+	RelatedXIDs              []int               `json:"related_xids,omitempty"`
+	Reasons                  []string            `json:"reasons,omitempty"`
+	Status                   string              `json:"status"`
+}
+
+// RetiredPagesHealth reports the pages retired by cause.
+type RetiredPagesHealth struct {
+	MultipleSingleBitECC int  `json:"multiple_single_bit_ecc"`
+	DoubleBitECC         int  `json:"double_bit_ecc"`
+	Total                int  `json:"total"`
+	Pending              bool `json:"pending"`
+}
+
+// RowRemappingHealth reports remapped rows and the remaining spare rows.
+type RowRemappingHealth struct {
+	CorrectableRows   int               `json:"correctable_rows"`
+	UncorrectableRows int               `json:"uncorrectable_rows"`
+	Pending           bool              `json:"pending"`
+	Failed            bool              `json:"failed"`
+	Histogram         RowRemapHistogram `json:"histogram"`
+}
+
+// RowRemapHistogram counts memory banks by remaining spare rows.
+type RowRemapHistogram struct {
+	Max     uint32 `json:"max"`
+	High    uint32 `json:"high"`
+	Partial uint32 `json:"partial"`
+	Low     uint32 `json:"low"`
+	None    uint32 `json:"none"`
+}
+
+// PCIeHealth tracks the PCIe link width, generation, and replay errors.
+type PCIeHealth struct {
+	CurrentGen   uint32   `json:"current_gen"`
+	MaxGen       uint32   `json:"max_gen"`
+	CurrentWidth uint32   `json:"current_width"`
+	MaxWidth     uint32   `json:"max_width"`
+	TxKBps       uint32   `json:"tx_kbps"`
+	RxKBps       uint32   `json:"rx_kbps"`
+	ReplayCount  uint32   `json:"replay_count"`
+	Reasons      []string `json:"reasons,omitempty"`
+	Status       string   `json:"status"`
 }
 
 // PerformanceHealth tracks GPU utilization and clock frequencies.
@@ -203,6 +252,7 @@ func (h *GPUHealthHandler) collectGPUHealth(
 	pciInfo, err := device.GetPCIInfo(ctx)
 	if err != nil {
 		klog.V(2).InfoS("failed to get PCI info", "index", index, "error", err)
+		pciInfo = nil
 	} else {
 		health.PCIBusID = pciInfo.BusID
 	}
@@ -221,6 +271,7 @@ func (h *GPUHealthHandler) collectGPUHealth(
 	health.Throttling = h.checkThrottling(ctx, device)
 	health.ECCErrors = h.checkECCErrors(ctx, device)
 	health.Performance = h.checkPerformance(ctx, device)
+	health.PCIe = h.checkPCIe(ctx, device, pciInfo, health.Performance.GPUUtil)
 
 	// Calculate health score (0-100)
 	health.HealthScore = h.calculateHealthScore(&health)
@@ -516,12 +567,187 @@ func (h *GPUHealthHandler) checkECCErrors(
 		status = "healthy"
 	}
 
-	return ECCHealth{
+	health := ECCHealth{
 		Enabled:                  true,
 		TotalCorrectableErrors:   correctable,
 		TotalUncorrectableErrors: uncorrectable,
 		Status:                   status,
 	}
+	h.checkMemoryRepair(ctx, device, &health)
+
+	return health
+}
+
+// Memory repair reasons reported in ECCHealth.Reasons.
+const (
+	eccReasonRemapFailed       = "row_remap_failed"
+	eccReasonRemapPending      = "row_remap_pending"
+	eccReasonRemapExhausted    = "row_remap_capacity_exhausted"
+	eccReasonRetirementPending = "page_retirement_pending"
+	eccReasonRetiredPagesLimit = "retired_pages_limit"
+)
+
+// retiredPagesReplaceThreshold is the total number of retired pages at
+// which NVIDIA considers a GPU eligible for replacement.
+const retiredPagesReplaceThreshold = 60
+
+// checkMemoryRepair adds the page retirement or row remapping state to the
+// ECC health and escalates its status:
+//   - critical: a row remapping failed, or the retired pages reached
+//     retiredPagesReplaceThreshold; the GPU must be replaced
+//   - warning: a remapping or retirement is pending and only takes effect
+//     after a GPU reset, or a memory bank has no spare rows left
+//
+// The XIDs the driver logs for these events are listed in RelatedXIDs so
+// they can be correlated with analyze_xid_errors.
+func (h *GPUHealthHandler) checkMemoryRepair(
+	ctx context.Context,
+	device nvml.Device,
+	health *ECCHealth,
+) {
+	xids := make(map[int]bool)
+
+	if pages, err := device.GetRetiredPages(ctx); err != nil {
+		klog.V(2).InfoS("failed to get retired pages", "error", err)
+	} else if pages.Supported {
+		health.RetiredPages = &RetiredPagesHealth{
+			MultipleSingleBitECC: pages.MultipleSingleBitECC,
+			DoubleBitECC:         pages.DoubleBitECC,
+			Total:                pages.MultipleSingleBitECC + pages.DoubleBitECC,
+			Pending:              pages.Pending,
+		}
+		if pages.DoubleBitECC > 0 {
+			xids[48] = true
+		}
+		if pages.Pending {
+			health.ResetRequired = true
+			health.Reasons = append(health.Reasons, eccReasonRetirementPending)
+			xids[63] = true
+		}
+		if health.RetiredPages.Total >= retiredPagesReplaceThreshold {
+			health.ReplaceRequired = true
+			health.Reasons = append(health.Reasons, eccReasonRetiredPagesLimit)
+		}
+	}
+
+	if rows, err := device.GetRemappedRows(ctx); err != nil {
+		klog.V(2).InfoS("failed to get remapped rows", "error", err)
+	} else if rows.Supported {
+		health.RowRemapping = &RowRemappingHealth{
+			CorrectableRows:   rows.CorrectableRows,
+			UncorrectableRows: rows.UncorrectableRows,
+			Pending:           rows.Pending,
+			Failed:            rows.Failed,
+			Histogram: RowRemapHistogram{
+				Max:     rows.Histogram.Max,
+				High:    rows.Histogram.High,
+				Partial: rows.Histogram.Partial,
+				Low:     rows.Histogram.Low,
+				None:    rows.Histogram.None,
+			},
+		}
+		if rows.UncorrectableRows > 0 {
+			xids[94] = true
+			xids[95] = true
+		}
+		if rows.Pending {
+			health.ResetRequired = true
+			health.Reasons = append(health.Reasons, eccReasonRemapPending)
+			xids[63] = true
+		}
+		if rows.Failed {
+			health.ReplaceRequired = true
+			health.Reasons = append(health.Reasons, eccReasonRemapFailed)
+			xids[64] = true
+		}
+		if rows.Histogram.None > 0 {
+			health.Reasons = append(health.Reasons, eccReasonRemapExhausted)
+		}
+	}
+
+	for code := range xids {
+		health.RelatedXIDs = append(health.RelatedXIDs, code)
+	}
+	sort.Ints(health.RelatedXIDs)
+
+	switch {
+	case health.ReplaceRequired:
+		health.Status = "critical"
+	case len(health.Reasons) > 0 && health.Status != "critical":
+		health.Status = "warning"
+	}
+}
+
+// PCIe link thresholds.
+const (
+	// pcieReplayThreshold is the replay count above which the link is
+	// flagged. Replays retransmit packets after a link-level CRC error; a
+	// few since driver load are normal.
+	pcieReplayThreshold uint32 = 100
+	// pcieGenCheckMinUtil is the GPU utilization (%) above which a link
+	// generation below the maximum is flagged. Idle GPUs drop to a lower
+	// generation to save power, so only width is checked when idle.
+	pcieGenCheckMinUtil uint32 = 10
+)
+
+// checkPCIe evaluates whether the PCIe link trained below its maximum
+// generation or width and whether it is retransmitting.
+func (h *GPUHealthHandler) checkPCIe(
+	ctx context.Context,
+	device nvml.Device,
+	pciInfo *nvml.PCIInfo,
+	gpuUtil uint32,
+) PCIeHealth {
+	if pciInfo == nil || pciInfo.MaxLinkGen == 0 || pciInfo.MaxLinkWidth == 0 {
+		return PCIeHealth{
+			Status: "unknown",
+		}
+	}
+
+	pcie := PCIeHealth{
+		CurrentGen:   pciInfo.CurrentLinkGen,
+		MaxGen:       pciInfo.MaxLinkGen,
+		CurrentWidth: pciInfo.CurrentLinkWidth,
+		MaxWidth:     pciInfo.MaxLinkWidth,
+		Status:       "healthy",
+	}
+
+	// Get throughput and replays; log errors, use 0 as fallback
+	if val, err := device.GetPCIeThroughput(ctx, nvml.PCIeUtilTXBytes); err != nil {
+		klog.V(2).InfoS("failed to get PCIe TX throughput", "error", err)
+	} else {
+		pcie.TxKBps = val
+	}
+	if val, err := device.GetPCIeThroughput(ctx, nvml.PCIeUtilRXBytes); err != nil {
+		klog.V(2).InfoS("failed to get PCIe RX throughput", "error", err)
+	} else {
+		pcie.RxKBps = val
+	}
+	if val, err := device.GetPCIeReplayCounter(ctx); err != nil {
+		klog.V(2).InfoS("failed to get PCIe replay counter", "error", err)
+	} else {
+		pcie.ReplayCount = val
+	}
+
+	if pcie.ReplayCount > pcieReplayThreshold {
+		pcie.Reasons = append(pcie.Reasons, "replays")
+		pcie.Status = "warning"
+	}
+
+	downgraded := false
+	if pcie.CurrentWidth < pcie.MaxWidth {
+		pcie.Reasons = append(pcie.Reasons, "width_downgrade")
+		downgraded = true
+	}
+	if pcie.CurrentGen < pcie.MaxGen && gpuUtil >= pcieGenCheckMinUtil {
+		pcie.Reasons = append(pcie.Reasons, "gen_downgrade")
+		downgraded = true
+	}
+	if downgraded {
+		pcie.Status = "degraded"
+	}
+
+	return pcie
 }
 
 // checkPerformance evaluates GPU utilization and performance state.
@@ -674,6 +900,34 @@ func (h *GPUHealthHandler) calculateHealthScore(health *GPUHealthStatus) int {
 		})
 	}
 
+	// Memory repair impact (max -30 points)
+	score -= h.memoryRepairImpact(health)
+
+	// PCIe link impact (max -20 points)
+	if health.PCIe.Status == "degraded" {
+		score -= 15
+		health.Issues = append(health.Issues, HealthIssue{
+			Severity:  "warning",
+			Component: "pcie",
+			Message: fmt.Sprintf(
+				"PCIe link degraded: running Gen%d x%d, capable of Gen%d x%d",
+				health.PCIe.CurrentGen, health.PCIe.CurrentWidth,
+				health.PCIe.MaxGen, health.PCIe.MaxWidth),
+			Suggestion: "Reseat the GPU or check the riser and slot; " +
+				"verify BIOS PCIe settings",
+		})
+	}
+	if health.PCIe.ReplayCount > pcieReplayThreshold {
+		score -= 5
+		health.Issues = append(health.Issues, HealthIssue{
+			Severity:  "warning",
+			Component: "pcie",
+			Message: fmt.Sprintf("%d PCIe replays since driver load",
+				health.PCIe.ReplayCount),
+			Suggestion: "Check PCIe signal integrity (riser, slot, cabling)",
+		})
+	}
+
 	if score < 0 {
 		score = 0
 	}
@@ -681,6 +935,56 @@ func (h *GPUHealthHandler) calculateHealthScore(health *GPUHealthStatus) int {
 	return score
 }
 
+// memoryRepairImpact adds issues for the page retirement and row remapping
+// state and returns the score penalty.
+func (h *GPUHealthHandler) memoryRepairImpact(health *GPUHealthStatus) int {
+	ecc := health.ECCErrors
+	pages, rows := ecc.RetiredPages, ecc.RowRemapping
+
+	if ecc.ReplaceRequired {
+		message := "Row remapping failed: a memory bank has no spare " +
+			"rows left (XID 64)"
+		if pages != nil && pages.Total >= retiredPagesReplaceThreshold {
+			message = fmt.Sprintf(
+				"%d retired memory pages reached the replacement threshold of %d",
+				pages.Total, retiredPagesReplaceThreshold)
+		}
+		health.Issues = append(health.Issues, HealthIssue{
+			Severity:   "critical",
+			Component:  "ecc",
+			Message:    message,
+			Suggestion: "Drain node and replace the GPU (RMA)",
+		})
+		return 30
+	}
+
+	penalty := 0
+	if ecc.ResetRequired {
+		penalty += 10
+		health.Issues = append(health.Issues, HealthIssue{
+			Severity:  "warning",
+			Component: "ecc",
+			Message: "Memory page retirement or row remapping pending " +
+				"(XID 63)",
+			Suggestion: "Drain workloads and reset the GPU to apply the " +
+				"repair",
+		})
+	}
+	if rows != nil && rows.Histogram.None > 0 {
+		penalty += 5
+		health.Issues = append(health.Issues, HealthIssue{
+			Severity:  "warning",
+			Component: "ecc",
+			Message: fmt.Sprintf(
+				"%d memory banks have no spare rows left for remapping",
+				rows.Histogram.None),
+			Suggestion: "Another uncorrectable error in these banks " +
+				"cannot be repaired; plan GPU replacement",
+		})
+	}
+	return penalty
+}
+
 // determineStatus classifies health based on score and issues.
 func (h *GPUHealthHandler) determineStatus(
 	score int,
@@ -805,9 +1109,15 @@ func GetGPUHealthTool() mcp.Tool {
 	return mcp.NewTool("get_gpu_health",
 		mcp.WithDescription(
 			"Analyze GPU operational health including temperature, "+
-				"throttling, ECC errors, memory usage, and power consumption. "+
+				"throttling, ECC errors, retired pages and row remapping "+
+				"(reset/replace required), PCIe link width/generation and "+
+				"replays, memory usage, and power consumption. "+
 				"Returns overall health score (0-100) with status assessment "+
-				"and recommendations.",
+				"and recommendations. In gateway mode, node_name, "+
+				"node_selector and gpu_model restrict the nodes queried.",
 		),
+		withNodeTargeting(),
+		withCacheControl(),
+		withCallBudget(),
 	)
 }
@@ -404,6 +404,88 @@ func TestGPUHealthHandler_calculateHealthScore(t *testing.T) {
 			},
 			wantScore: 75,
 		},
+		{
+			name: "pcie_link_degraded",
+			health: GPUHealthStatus{
+				Temperature: TemperatureHealth{Status: "normal"},
+				Memory:      MemoryHealth{Status: "normal"},
+				Power:       PowerHealth{Status: "normal"},
+				Throttling:  ThrottlingStatus{Status: "none"},
+				ECCErrors:   ECCHealth{Status: "healthy"},
+				PCIe: PCIeHealth{Status: "degraded", CurrentGen: 3,
+					MaxGen: 4, CurrentWidth: 8, MaxWidth: 16},
+				Issues: []HealthIssue{},
+			},
+			wantScore: 85,
+		},
+		{
+			name: "pcie_degraded_with_replays",
+			health: GPUHealthStatus{
+				Temperature: TemperatureHealth{Status: "normal"},
+				Memory:      MemoryHealth{Status: "normal"},
+				Power:       PowerHealth{Status: "normal"},
+				Throttling:  ThrottlingStatus{Status: "none"},
+				ECCErrors:   ECCHealth{Status: "healthy"},
+				PCIe: PCIeHealth{Status: "degraded", CurrentGen: 4,
+					MaxGen: 4, CurrentWidth: 8, MaxWidth: 16,
+					ReplayCount: 500},
+				Issues: []HealthIssue{},
+			},
+			wantScore: 80,
+		},
+		{
+			name: "row_remap_failed",
+			health: GPUHealthStatus{
+				Temperature: TemperatureHealth{Status: "normal"},
+				Memory:      MemoryHealth{Status: "normal"},
+				Power:       PowerHealth{Status: "normal"},
+				Throttling:  ThrottlingStatus{Status: "none"},
+				ECCErrors: ECCHealth{Status: "critical", ReplaceRequired: true,
+					RowRemapping: &RowRemappingHealth{Failed: true}},
+				Issues: []HealthIssue{},
+			},
+			wantScore: 70,
+		},
+		{
+			name: "retired_pages_limit",
+			health: GPUHealthStatus{
+				Temperature: TemperatureHealth{Status: "normal"},
+				Memory:      MemoryHealth{Status: "normal"},
+				Power:       PowerHealth{Status: "normal"},
+				Throttling:  ThrottlingStatus{Status: "none"},
+				ECCErrors: ECCHealth{Status: "critical", ReplaceRequired: true,
+					RetiredPages: &RetiredPagesHealth{Total: 64}},
+				Issues: []HealthIssue{},
+			},
+			wantScore: 70,
+		},
+		{
+			name: "memory_repair_pending",
+			health: GPUHealthStatus{
+				Temperature: TemperatureHealth{Status: "normal"},
+				Memory:      MemoryHealth{Status: "normal"},
+				Power:       PowerHealth{Status: "normal"},
+				Throttling:  ThrottlingStatus{Status: "none"},
+				ECCErrors: ECCHealth{Status: "warning", ResetRequired: true,
+					RowRemapping: &RowRemappingHealth{Pending: true}},
+				Issues: []HealthIssue{},
+			},
+			wantScore: 90,
+		},
+		{
+			name: "remap_capacity_exhausted",
+			health: GPUHealthStatus{
+				Temperature: TemperatureHealth{Status: "normal"},
+				Memory:      MemoryHealth{Status: "normal"},
+				Power:       PowerHealth{Status: "normal"},
+				Throttling:  ThrottlingStatus{Status: "none"},
+				ECCErrors: ECCHealth{Status: "warning",
+					RowRemapping: &RowRemappingHealth{
+						Histogram: RowRemapHistogram{Max: 638, None: 2}}},
+				Issues: []HealthIssue{},
+			},
+			wantScore: 95,
+		},
 		{
 			name: "multiple_issues",
 			health: GPUHealthStatus{
@@ -435,6 +517,258 @@ func TestGPUHealthHandler_calculateHealthScore(t *testing.T) {
 	}
 }
 
+func TestGPUHealthHandler_checkPCIe(t *testing.T) {
+	handler := &GPUHealthHandler{}
+
+	gen4x16 := &nvml.PCIInfo{CurrentLinkGen: 4, MaxLinkGen: 4,
+		CurrentLinkWidth: 16, MaxLinkWidth: 16}
+
+	tests := []struct {
+		name        string
+		pciInfo     *nvml.PCIInfo
+		replays     uint32
+		gpuUtil     uint32
+		wantStatus  string
+		wantReasons []string
+	}{
+		{
+			name:       "full_link",
+			pciInfo:    gen4x16,
+			gpuUtil:    80,
+			wantStatus: "healthy",
+		},
+		{
+			name: "width_downgrade",
+			pciInfo: &nvml.PCIInfo{CurrentLinkGen: 4, MaxLinkGen: 4,
+				CurrentLinkWidth: 8, MaxLinkWidth: 16},
+			gpuUtil:     0,
+			wantStatus:  "degraded",
+			wantReasons: []string{"width_downgrade"},
+		},
+		{
+			name: "gen_downgrade_under_load",
+			pciInfo: &nvml.PCIInfo{CurrentLinkGen: 3, MaxLinkGen: 4,
+				CurrentLinkWidth: 16, MaxLinkWidth: 16},
+			gpuUtil:     80,
+			wantStatus:  "degraded",
+			wantReasons: []string{"gen_downgrade"},
+		},
+		{
+			name: "idle_gen_downshift_ignored",
+			pciInfo: &nvml.PCIInfo{CurrentLinkGen: 1, MaxLinkGen: 4,
+				CurrentLinkWidth: 16, MaxLinkWidth: 16},
+			gpuUtil:    0,
+			wantStatus: "healthy",
+		},
+		{
+			name:        "replays_above_threshold",
+			pciInfo:     gen4x16,
+			replays:     101,
+			gpuUtil:     80,
+			wantStatus:  "warning",
+			wantReasons: []string{"replays"},
+		},
+		{
+			name: "downgrade_and_replays",
+			pciInfo: &nvml.PCIInfo{CurrentLinkGen: 3, MaxLinkGen: 4,
+				CurrentLinkWidth: 8, MaxLinkWidth: 16},
+			replays:    500,
+			gpuUtil:    50,
+			wantStatus: "degraded",
+			wantReasons: []string{"replays", "width_downgrade",
+				"gen_downgrade"},
+		},
+		{
+			name:       "link_not_reported",
+			pciInfo:    &nvml.PCIInfo{BusID: "0000:00:1E.0"},
+			wantStatus: "unknown",
+		},
+		{
+			name:       "no_pci_info",
+			wantStatus: "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := &mockDeviceWithPCIe{replays: tt.replays}
+
+			result := handler.checkPCIe(
+				context.Background(), device, tt.pciInfo, tt.gpuUtil)
+
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantReasons, result.Reasons)
+			assert.Equal(t, tt.replays, result.ReplayCount)
+			if tt.wantStatus != "unknown" {
+				assert.Equal(t, uint32(1000), result.TxKBps)
+				assert.Equal(t, uint32(2000), result.RxKBps)
+			}
+		})
+	}
+}
+
+func TestGPUHealthHandler_Handle_PCIeDegraded(t *testing.T) {
+	mockClient := nvml.NewMock(2, nvml.WithMockPCIeLink(1, 4, 8))
+	handler := NewGPUHealthHandler(mockClient)
+
+	result, err := handler.Handle(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response GPUHealthResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Len(t, response.GPUs, 2)
+
+	assert.Equal(t, "healthy", response.GPUs[0].PCIe.Status)
+
+	gpu := response.GPUs[1]
+	assert.Equal(t, "degraded", gpu.PCIe.Status)
+	assert.Equal(t, uint32(8), gpu.PCIe.CurrentWidth)
+	assert.Equal(t, uint32(16), gpu.PCIe.MaxWidth)
+
+	var pcieIssue *HealthIssue
+	for i := range gpu.Issues {
+		if gpu.Issues[i].Component == "pcie" {
+			pcieIssue = &gpu.Issues[i]
+		}
+	}
+	require.NotNil(t, pcieIssue)
+	assert.Equal(t, "warning", pcieIssue.Severity)
+	assert.Contains(t, pcieIssue.Message, "Gen4 x8, capable of Gen4 x16")
+	assert.Less(t, gpu.HealthScore, response.GPUs[0].HealthScore)
+}
+
+func TestGPUHealthHandler_checkECCErrors_MemoryRepair(t *testing.T) {
+	handler := &GPUHealthHandler{}
+
+	tests := []struct {
+		name        string
+		opts        []nvml.MockOption
+		wantStatus  string
+		wantReset   bool
+		wantReplace bool
+		wantXIDs    []int
+		wantReasons []string
+	}{
+		{
+			name:       "healthy_row_remapper",
+			wantStatus: "healthy",
+		},
+		{
+			name: "remap_pending",
+			opts: []nvml.MockOption{
+				nvml.WithMockRemappedRows(0, 0, 1, true, false),
+			},
+			wantStatus:  "warning",
+			wantReset:   true,
+			wantXIDs:    []int{63, 94, 95},
+			wantReasons: []string{"row_remap_pending"},
+		},
+		{
+			name: "remap_failed",
+			opts: []nvml.MockOption{
+				nvml.WithMockRemappedRows(0, 0, 3, false, true),
+				nvml.WithMockRowRemapperHistogram(0,
+					nvml.RowRemapperHistogram{Max: 639, None: 1}),
+			},
+			wantStatus:  "critical",
+			wantReplace: true,
+			wantXIDs:    []int{64, 94, 95},
+			wantReasons: []string{"row_remap_failed",
+				"row_remap_capacity_exhausted"},
+		},
+		{
+			name: "remap_capacity_exhausted",
+			opts: []nvml.MockOption{
+				nvml.WithMockRowRemapperHistogram(0,
+					nvml.RowRemapperHistogram{Max: 638, None: 2}),
+			},
+			wantStatus:  "warning",
+			wantReasons: []string{"row_remap_capacity_exhausted"},
+		},
+		{
+			name: "retirement_pending",
+			opts: []nvml.MockOption{
+				nvml.WithMockRetiredPages(0, 2, 1, true),
+			},
+			wantStatus:  "warning",
+			wantReset:   true,
+			wantXIDs:    []int{48, 63},
+			wantReasons: []string{"page_retirement_pending"},
+		},
+		{
+			name: "retired_pages_limit",
+			opts: []nvml.MockOption{
+				nvml.WithMockRetiredPages(0, 58, 2, false),
+			},
+			wantStatus:  "critical",
+			wantReplace: true,
+			wantXIDs:    []int{48},
+			wantReasons: []string{"retired_pages_limit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, err := nvml.NewMock(1, tt.opts...).GetDeviceByIndex(
+				context.Background(), 0)
+			require.NoError(t, err)
+
+			result := handler.checkECCErrors(context.Background(), device)
+
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantReset, result.ResetRequired)
+			assert.Equal(t, tt.wantReplace, result.ReplaceRequired)
+			assert.Equal(t, tt.wantXIDs, result.RelatedXIDs)
+			assert.Equal(t, tt.wantReasons, result.Reasons)
+
+			// Only the repair mechanism used by the GPU is reported
+			assert.True(t, (result.RetiredPages == nil) !=
+				(result.RowRemapping == nil))
+		})
+	}
+}
+
+func TestGPUHealthHandler_Handle_RowRemapFailed(t *testing.T) {
+	mockClient := nvml.NewMock(2,
+		nvml.WithMockRemappedRows(1, 0, 2, false, true))
+	handler := NewGPUHealthHandler(mockClient)
+
+	result, err := handler.Handle(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response GPUHealthResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Len(t, response.GPUs, 2)
+
+	assert.Equal(t, "healthy", response.GPUs[0].ECCErrors.Status)
+	require.NotNil(t, response.GPUs[0].ECCErrors.RowRemapping)
+	assert.Equal(t, uint32(640),
+		response.GPUs[0].ECCErrors.RowRemapping.Histogram.Max)
+
+	gpu := response.GPUs[1]
+	assert.Equal(t, "critical", gpu.Status)
+	assert.True(t, gpu.ECCErrors.ReplaceRequired)
+	require.NotNil(t, gpu.ECCErrors.RowRemapping)
+	assert.True(t, gpu.ECCErrors.RowRemapping.Failed)
+	assert.Equal(t, 2, gpu.ECCErrors.RowRemapping.UncorrectableRows)
+
+	var eccIssue *HealthIssue
+	for i := range gpu.Issues {
+		if gpu.Issues[i].Component == "ecc" {
+			eccIssue = &gpu.Issues[i]
+		}
+	}
+	require.NotNil(t, eccIssue)
+	assert.Equal(t, "critical", eccIssue.Severity)
+	assert.Contains(t, eccIssue.Message, "XID 64")
+}
+
 func TestGPUHealthHandler_determineStatus(t *testing.T) {
 	handler := &GPUHealthHandler{}
 
@@ -618,6 +952,14 @@ func TestGetGPUHealthTool(t *testing.T) {
 	assert.Contains(t, tool.Description, "health")
 	assert.Contains(t, tool.Description, "temperature")
 	assert.Contains(t, tool.Description, "score")
+
+	for _, arg := range []string{
+		"node_name", "node_selector", "gpu_model", "max_age", "no_cache",
+		"timeout_seconds",
+	} {
+		assert.Contains(t, tool.InputSchema.Properties, arg)
+	}
+	assert.Empty(t, tool.InputSchema.Required)
 }
 
 func TestGPUHealthResponse_JSONSerialization(t *testing.T) {
@@ -710,6 +1052,27 @@ func TestGPUHealthStatus_IssuesOmittedWhenEmpty(t *testing.T) {
 
 // Mock devices for specific test scenarios
 
+type mockDeviceWithPCIe struct {
+	nvml.Device
+	replays uint32
+}
+
+func (d *mockDeviceWithPCIe) GetPCIeThroughput(
+	ctx context.Context,
+	counter int,
+) (uint32, error) {
+	if counter == nvml.PCIeUtilTXBytes {
+		return 1000, nil
+	}
+	return 2000, nil
+}
+
+func (d *mockDeviceWithPCIe) GetPCIeReplayCounter(
+	ctx context.Context,
+) (uint32, error) {
+	return d.replays, nil
+}
+
 type mockDeviceWithTemp struct {
 	nvml.Device
 	temp uint32
@@ -826,7 +1189,11 @@ func (d *mockHealthyDevice) GetUUID(ctx context.Context) (string, error) {
 	return "GPU-12345678-0000-0000-0000-000000000000", nil
 }
 func (d *mockHealthyDevice) GetPCIInfo(ctx context.Context) (*nvml.PCIInfo, error) {
-	return &nvml.PCIInfo{BusID: "0000:00:1E.0", Domain: 0, Bus: 0, Device: 30}, nil
+	return &nvml.PCIInfo{
+		BusID: "0000:00:1E.0", Domain: 0, Bus: 0, Device: 30,
+		CurrentLinkGen: 3, MaxLinkGen: 3, // T4 is PCIe Gen3 x16
+		CurrentLinkWidth: 16, MaxLinkWidth: 16,
+	}, nil
 }
 func (d *mockHealthyDevice) GetMemoryInfo(ctx context.Context) (*nvml.MemoryInfo, error) {
 	return &nvml.MemoryInfo{
@@ -890,6 +1257,47 @@ func (d *mockHealthyDevice) GetCudaComputeCapability(
 ) (string, error) {
 	return "7.5", nil // Turing (T4)
 }
+func (d *mockHealthyDevice) GetPCIeThroughput(
+	ctx context.Context,
+	counter int,
+) (uint32, error) {
+	return 0, nil // Idle
+}
+func (d *mockHealthyDevice) GetPCIeReplayCounter(
+	ctx context.Context,
+) (uint32, error) {
+	return 0, nil
+}
+func (d *mockHealthyDevice) GetRunningProcesses(
+	ctx context.Context,
+) ([]nvml.ProcessInfo, error) {
+	return nil, nil // Idle
+}
+func (d *mockHealthyDevice) GetMigMode(
+	ctx context.Context,
+) (current, pending bool, err error) {
+	return false, false, nil // T4 doesn't support MIG
+}
+func (d *mockHealthyDevice) GetMigDevices(
+	ctx context.Context,
+) ([]nvml.MigDeviceInfo, error) {
+	return []nvml.MigDeviceInfo{}, nil
+}
+func (d *mockHealthyDevice) GetNvLinks(
+	ctx context.Context,
+) ([]nvml.NvLinkInfo, error) {
+	return []nvml.NvLinkInfo{}, nil // PCIe T4 has no NVLink
+}
+func (d *mockHealthyDevice) GetRetiredPages(
+	ctx context.Context,
+) (*nvml.RetiredPagesInfo, error) {
+	return &nvml.RetiredPagesInfo{Supported: true}, nil // Turing retires pages
+}
+func (d *mockHealthyDevice) GetRemappedRows(
+	ctx context.Context,
+) (*nvml.RemappedRowsInfo, error) {
+	return &nvml.RemappedRowsInfo{}, nil
+}
 
 // mockEmptyNVML returns 0 devices
 type mockEmptyNVML struct{}
@@ -209,6 +209,25 @@ func (h *GPUInventoryHandler) collectDeviceInfo(
 		info.ECC = eccSpec
 	}
 
+	// Collect MIG status (optional - only reported for MIG-enabled GPUs)
+	if current, pending, err := device.GetMigMode(ctx); err == nil &&
+		(current || pending) {
+		migSpec := &nvml.MIGSpec{
+			Enabled:        current,
+			PendingEnabled: pending,
+			Devices:        []nvml.MigDeviceInfo{},
+		}
+		if current {
+			if migDevices, err := device.GetMigDevices(ctx); err != nil {
+				klog.V(2).InfoS("failed to get MIG devices",
+					"index", index, "error", err)
+			} else {
+				migSpec.Devices = migDevices
+			}
+		}
+		info.MIG = migSpec
+	}
+
 	return info, nil
 }
 
@@ -221,9 +240,12 @@ func GetGPUInventoryTool() mcp.Tool {
 				"In gateway mode: returns cluster-wide inventory with "+
 				"summary (total nodes, GPUs, types) and per-node GPU list. "+
 				"Includes model, UUID, memory, temperature, and utilization. "+
+				"MIG-enabled GPUs also list their MIG devices with profile, "+
+				"memory, and MIG UUID. "+
 				"When include_k8s_metadata is true (default in gateway mode), "+
 				"also includes Kubernetes node labels, conditions, and GPU "+
-				"resource allocation counts.",
+				"resource allocation counts. In gateway mode, node_name, "+
+				"node_selector and gpu_model restrict the nodes queried.",
 		),
 		mcp.WithBoolean("include_k8s_metadata",
 			mcp.Description(
@@ -232,5 +254,8 @@ func GetGPUInventoryTool() mcp.Tool {
 					"ignored in agent mode.",
 			),
 		),
+		withNodeTargeting(),
+		withCacheControl(),
+		withCallBudget(),
 	)
 }
@@ -190,6 +190,37 @@ func TestGPUInventoryHandler_collectDeviceInfo(t *testing.T) {
 	// Verify ECC spec (mock has ECC enabled)
 	require.NotNil(t, info.ECC)
 	assert.True(t, info.ECC.Enabled)
+
+	// MIG spec is omitted for GPUs without MIG enabled
+	assert.Nil(t, info.MIG)
+}
+
+func TestGPUInventoryHandler_MIG(t *testing.T) {
+	mockClient := nvml.NewMock(2, nvml.WithMockMIG(1))
+	handler := NewGPUInventoryHandler(mockClient)
+
+	result, err := handler.Handle(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response struct {
+		Devices []nvml.GPUInfo `json:"devices"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Len(t, response.Devices, 2)
+
+	assert.Nil(t, response.Devices[0].MIG)
+
+	mig := response.Devices[1].MIG
+	require.NotNil(t, mig)
+	assert.True(t, mig.Enabled)
+	require.Len(t, mig.Devices, 4)
+	assert.Equal(t, "3g.20gb", mig.Devices[0].Profile)
+	assert.Contains(t, mig.Devices[0].UUID, "MIG-")
+	assert.Greater(t, mig.Devices[0].MemoryBytes, uint64(0))
 }
 
 func TestGPUInventoryHandler_NestedStructures(t *testing.T) {
@@ -229,4 +260,12 @@ func TestGetGPUInventoryTool(t *testing.T) {
 
 	assert.Equal(t, "get_gpu_inventory", tool.Name)
 	assert.NotEmpty(t, tool.Description)
+
+	for _, arg := range []string{
+		"node_name", "node_selector", "gpu_model", "max_age", "no_cache",
+		"timeout_seconds",
+	} {
+		assert.Contains(t, tool.InputSchema.Properties, arg)
+	}
+	assert.Empty(t, tool.InputSchema.Required)
 }
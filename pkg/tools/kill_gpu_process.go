@@ -0,0 +1,327 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// KillGPUProcessHandler handles the kill_gpu_process tool.
+// The tool is only registered in operator mode. It never signals processes
+// directly: the owning pod is evicted through the Eviction API so that
+// PodDisruptionBudgets and graceful termination are honoured.
+type KillGPUProcessHandler struct {
+	clientset kubernetes.Interface
+	nodeName  string
+	resolver  *ProcessResolver
+}
+
+// NewKillGPUProcessHandler creates a new kill GPU process handler.
+// nodeName is the node the agent runs on and scopes pod resolution.
+// resolver maps PIDs to pods; nil uses the default /proc root.
+func NewKillGPUProcessHandler(
+	clientset kubernetes.Interface,
+	nodeName string,
+	resolver *ProcessResolver,
+) *KillGPUProcessHandler {
+	if resolver == nil {
+		resolver = NewProcessResolver("")
+	}
+	return &KillGPUProcessHandler{
+		clientset: clientset,
+		nodeName:  nodeName,
+		resolver:  resolver,
+	}
+}
+
+// KillGPUProcessResponse is the response for kill_gpu_process.
+type KillGPUProcessResponse struct {
+	Status   string        `json:"status"`
+	NodeName string        `json:"node_name"`
+	Target   KillTarget    `json:"target"`
+	Pod      *EvictionInfo `json:"pod,omitempty"`
+	DryRun   bool          `json:"dry_run"`
+	Message  string        `json:"message"`
+	Error    string        `json:"error,omitempty"`
+	Hint     string        `json:"hint,omitempty"`
+}
+
+// KillTarget identifies what the caller asked to terminate.
+type KillTarget struct {
+	PID     int    `json:"pid,omitempty"`
+	GPUUUID string `json:"gpu_uuid,omitempty"`
+}
+
+// EvictionInfo identifies the pod selected for eviction.
+type EvictionInfo struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+}
+
+// Handle processes the kill_gpu_process tool request.
+func (h *KillGPUProcessHandler) Handle(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	klog.InfoS("kill_gpu_process invoked")
+
+	// Guard against nil clientset - eviction requires K8s access
+	if h.clientset == nil {
+		return mcp.NewToolResultError(
+			"K8s client not configured - this tool requires cluster access"), nil
+	}
+
+	if h.nodeName == "" {
+		return mcp.NewToolResultError(
+			"agent node name unknown - set NODE_NAME via the downward API"), nil
+	}
+
+	args := request.GetArguments()
+
+	// Exactly one of pid or gpu_uuid must be provided
+	var target KillTarget
+	if v, ok := args["pid"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return mcp.NewToolResultError(
+				"invalid pid: must be a positive integer"), nil
+		}
+		target.PID = int(v)
+	}
+	if v, ok := args["gpu_uuid"].(string); ok {
+		target.GPUUUID = strings.TrimSpace(v)
+	}
+	if (target.PID == 0) == (target.GPUUUID == "") {
+		return mcp.NewToolResultError(
+			"exactly one of pid or gpu_uuid is required"), nil
+	}
+
+	dryRun := false
+	if v, ok := args["dry_run"].(bool); ok {
+		dryRun = v
+	}
+
+	var gracePeriod *int64
+	if v, ok := args["grace_period_seconds"].(float64); ok {
+		if v < 0 {
+			return mcp.NewToolResultError(
+				"invalid grace_period_seconds: must be >= 0"), nil
+		}
+		seconds := int64(v)
+		gracePeriod = &seconds
+	}
+
+	// Resolve the target to exactly one pod on this node
+	pod, err := h.resolvePod(ctx, target)
+	if err != nil {
+		klog.ErrorS(err, "failed to resolve target pod",
+			"pid", target.PID, "gpuUUID", target.GPUUUID)
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to resolve target pod: %s", err)), nil
+	}
+
+	response := KillGPUProcessResponse{
+		NodeName: h.nodeName,
+		Target:   target,
+		Pod: &EvictionInfo{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			UID:       string(pod.UID),
+		},
+		DryRun: dryRun,
+	}
+
+	if dryRun {
+		response.Status = "dry_run"
+		response.Message = fmt.Sprintf("pod %s/%s would be evicted",
+			pod.Namespace, pod.Name)
+		return h.marshalResponse(response)
+	}
+
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriod,
+		},
+	}
+
+	err = h.clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+	switch {
+	case err == nil:
+		response.Status = "evicted"
+		response.Message = fmt.Sprintf("eviction of pod %s/%s accepted",
+			pod.Namespace, pod.Name)
+	case apierrors.IsTooManyRequests(err):
+		// The Eviction API returns 429 when a PodDisruptionBudget would
+		// be violated. This is expected behaviour, not a failure.
+		response.Status = "blocked"
+		response.Message = fmt.Sprintf("eviction of pod %s/%s blocked by "+
+			"PodDisruptionBudget", pod.Namespace, pod.Name)
+		response.Error = err.Error()
+		response.Hint = "Retry later or scale the workload so the " +
+			"PodDisruptionBudget allows a disruption"
+	default:
+		klog.ErrorS(err, "failed to evict pod",
+			"pod", pod.Name, "namespace", pod.Namespace)
+		response.Status = "error"
+		response.Message = fmt.Sprintf("failed to evict pod %s/%s",
+			pod.Namespace, pod.Name)
+		response.Error = err.Error()
+		response.Hint = "Agent may lack RBAC permissions. Apply " +
+			"deployment/rbac/agent-rbac-operator.yaml"
+	}
+
+	klog.InfoS("kill_gpu_process completed",
+		"pod", pod.Name, "namespace", pod.Namespace,
+		"status", response.Status)
+
+	return h.marshalResponse(response)
+}
+
+// resolvePod maps a PID or GPU UUID to the single pod on this node that
+// owns it.
+func (h *KillGPUProcessHandler) resolvePod(
+	ctx context.Context,
+	target KillTarget,
+) (*corev1.Pod, error) {
+	// Resolve PID to pod UID before listing to fail fast on host processes
+	var podUID string
+	if target.PID != 0 {
+		cgroup, err := h.resolver.Resolve(target.PID)
+		if err != nil {
+			return nil, err
+		}
+		podUID = cgroup.PodUID
+	}
+
+	pods, err := h.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", h.nodeName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w",
+			h.nodeName, err)
+	}
+
+	var matches []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		// Client-side node filter (FieldSelector backup for fake clients)
+		if pod.Spec.NodeName != h.nodeName {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded ||
+			pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		if podUID != "" {
+			if string(pod.UID) == podUID {
+				return pod, nil
+			}
+			continue
+		}
+
+		if podUsesGPU(pod, target.GPUUUID) {
+			matches = append(matches, pod)
+		}
+	}
+
+	if podUID != "" {
+		return nil, fmt.Errorf("pod with UID %s (pid %d) not found on node %s",
+			podUID, target.PID, h.nodeName)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no running pod on node %s is assigned GPU %s",
+			h.nodeName, target.GPUUUID)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, pod := range matches {
+			names = append(names, pod.Namespace+"/"+pod.Name)
+		}
+		return nil, fmt.Errorf("GPU %s is shared by %d pods (%s); "+
+			"specify a pid instead", target.GPUUUID, len(matches),
+			strings.Join(names, ", "))
+	}
+}
+
+// podUsesGPU reports whether the device plugin assigned the GPU to the pod.
+func podUsesGPU(pod *corev1.Pod, gpuUUID string) bool {
+	uuids, ok := pod.Annotations[gpuDeviceAnnotation]
+	if !ok || uuids == "" {
+		return false
+	}
+	for _, uuid := range strings.Split(uuids, ",") {
+		if strings.EqualFold(strings.TrimSpace(uuid), gpuUUID) {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalResponse marshals the response to JSON and returns as tool result.
+func (h *KillGPUProcessHandler) marshalResponse(
+	response KillGPUProcessResponse,
+) (*mcp.CallToolResult, error) {
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		klog.ErrorS(err, "failed to marshal response")
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to marshal response: %s", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// GetKillGPUProcessTool returns the MCP tool definition for kill_gpu_process.
+func GetKillGPUProcessTool() mcp.Tool {
+	return mcp.NewTool("kill_gpu_process",
+		mcp.WithDescription(
+			"Terminates a GPU workload by evicting the Kubernetes pod that "+
+				"owns it. The target is resolved from a host PID (via the "+
+				"process cgroup) or from a GPU UUID (via the NVIDIA device "+
+				"plugin annotation). Uses the Eviction API, so "+
+				"PodDisruptionBudgets and graceful termination are honoured. "+
+				"Only available when the agent runs in operator mode.",
+		),
+		mcp.WithNumber("pid",
+			mcp.Description("Host PID of the GPU process to terminate"),
+		),
+		mcp.WithString("gpu_uuid",
+			mcp.Description(
+				"GPU UUID whose assigned pod should be evicted "+
+					"(e.g., GPU-12345678-...). Mutually exclusive with pid.",
+			),
+		),
+		mcp.WithNumber("grace_period_seconds",
+			mcp.Description(
+				"Termination grace period override (default: pod spec value)",
+			),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description(
+				"Resolve the target pod without evicting it (default: false)",
+			),
+		),
+		mcp.WithDestructiveHintAnnotation(true),
+	)
+}
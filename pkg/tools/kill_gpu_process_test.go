@@ -0,0 +1,226 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newKillTestHandler creates a handler backed by a fake clientset seeded
+// with the given pods and a temporary procfs root.
+func newKillTestHandler(
+	t *testing.T,
+	pods ...corev1.Pod,
+) (*KillGPUProcessHandler, *fake.Clientset) {
+	t.Helper()
+
+	//nolint:staticcheck // NewSimpleClientset used for testing
+	clientset := fake.NewSimpleClientset()
+	for _, pod := range pods {
+		_, err := clientset.CoreV1().Pods(pod.Namespace).
+			Create(context.Background(), &pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	handler := NewKillGPUProcessHandler(clientset, "gpu-node-1",
+		NewProcessResolver(t.TempDir()))
+	return handler, clientset
+}
+
+// callKill invokes the handler and decodes a successful response.
+func callKill(
+	t *testing.T,
+	handler *KillGPUProcessHandler,
+	args map[string]interface{},
+) KillGPUProcessResponse {
+	t.Helper()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.False(t, result.IsError, "unexpected tool error: %v", result.Content)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response KillGPUProcessResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	return response
+}
+
+func TestKillGPUProcessHandler_EvictByGPUUUID(t *testing.T) {
+	pod := makePodWithGPU("training-job", "ml", "gpu-node-1", 2)
+	handler, clientset := newKillTestHandler(t, pod)
+
+	response := callKill(t, handler, map[string]interface{}{
+		"gpu_uuid": "GPU-uuid-2",
+	})
+
+	assert.Equal(t, "evicted", response.Status)
+	require.NotNil(t, response.Pod)
+	assert.Equal(t, "training-job", response.Pod.Name)
+	assert.Equal(t, "ml", response.Pod.Namespace)
+
+	// Verify an eviction was submitted to the API
+	var evicted bool
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "create" &&
+			action.GetSubresource() == "eviction" {
+			evicted = true
+		}
+	}
+	assert.True(t, evicted, "expected eviction create action")
+}
+
+func TestKillGPUProcessHandler_EvictByPID(t *testing.T) {
+	pod := makePodWithGPU("training-job", "ml", "gpu-node-1", 1)
+	pod.UID = types.UID("0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b")
+	handler, _ := newKillTestHandler(t, pod)
+
+	writeCgroupFile(t, handler.resolver.procRoot, 4242,
+		"0::/kubepods.slice/kubepods-burstable.slice/"+
+			"kubepods-burstable-pod0f1e2d3c_4b5a_6978_8a9b_0c1d2e3f4a5b.slice/"+
+			"cri-containerd-abc123.scope\n")
+
+	response := callKill(t, handler, map[string]interface{}{
+		"pid": float64(4242),
+	})
+
+	assert.Equal(t, "evicted", response.Status)
+	assert.Equal(t, 4242, response.Target.PID)
+	require.NotNil(t, response.Pod)
+	assert.Equal(t, "training-job", response.Pod.Name)
+}
+
+func TestKillGPUProcessHandler_DryRun(t *testing.T) {
+	pod := makePodWithGPU("training-job", "ml", "gpu-node-1", 1)
+	handler, clientset := newKillTestHandler(t, pod)
+
+	response := callKill(t, handler, map[string]interface{}{
+		"gpu_uuid": "GPU-uuid-1",
+		"dry_run":  true,
+	})
+
+	assert.Equal(t, "dry_run", response.Status)
+	assert.True(t, response.DryRun)
+	for _, action := range clientset.Actions() {
+		assert.NotEqual(t, "eviction", action.GetSubresource(),
+			"dry run must not evict")
+	}
+}
+
+func TestKillGPUProcessHandler_BlockedByPDB(t *testing.T) {
+	pod := makePodWithGPU("training-job", "ml", "gpu-node-1", 1)
+	handler, clientset := newKillTestHandler(t, pod)
+
+	clientset.PrependReactor("create", "pods",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			return true, nil, apierrors.NewTooManyRequests(
+				"Cannot evict pod as it would violate the pod's "+
+					"disruption budget.", 10)
+		})
+
+	response := callKill(t, handler, map[string]interface{}{
+		"gpu_uuid": "GPU-uuid-1",
+	})
+
+	assert.Equal(t, "blocked", response.Status)
+	assert.Contains(t, response.Message, "PodDisruptionBudget")
+	assert.NotEmpty(t, response.Hint)
+}
+
+func TestKillGPUProcessHandler_Errors(t *testing.T) {
+	shared1 := makePodWithGPU("job-a", "ml", "gpu-node-1", 1)
+	shared2 := makePodWithGPU("job-b", "ml", "gpu-node-1", 1)
+	other := makePodWithGPU("remote", "ml", "gpu-node-2", 1)
+
+	tests := []struct {
+		name    string
+		pods    []corev1.Pod
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "missing target",
+			args:    map[string]interface{}{},
+			wantErr: "exactly one of pid or gpu_uuid is required",
+		},
+		{
+			name: "both targets",
+			args: map[string]interface{}{
+				"pid": float64(1), "gpu_uuid": "GPU-uuid-1",
+			},
+			wantErr: "exactly one of pid or gpu_uuid is required",
+		},
+		{
+			name:    "invalid pid",
+			args:    map[string]interface{}{"pid": float64(-5)},
+			wantErr: "invalid pid",
+		},
+		{
+			name:    "unknown gpu",
+			pods:    []corev1.Pod{other},
+			args:    map[string]interface{}{"gpu_uuid": "GPU-uuid-1"},
+			wantErr: "no running pod on node gpu-node-1",
+		},
+		{
+			name:    "shared gpu is ambiguous",
+			pods:    []corev1.Pod{shared1, shared2},
+			args:    map[string]interface{}{"gpu_uuid": "GPU-uuid-1"},
+			wantErr: "shared by 2 pods",
+		},
+		{
+			name:    "pid not found",
+			args:    map[string]interface{}{"pid": float64(999999)},
+			wantErr: "process 999999 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newKillTestHandler(t, tt.pods...)
+
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = tt.args
+
+			result, err := handler.Handle(context.Background(), request)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+
+			textContent, ok := mcp.AsTextContent(result.Content[0])
+			require.True(t, ok)
+			assert.Contains(t, textContent.Text, tt.wantErr)
+		})
+	}
+}
+
+func TestKillGPUProcessHandler_NilClientset(t *testing.T) {
+	handler := NewKillGPUProcessHandler(nil, "gpu-node-1", nil)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"gpu_uuid": "GPU-1"}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
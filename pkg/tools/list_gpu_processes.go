@@ -0,0 +1,313 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// ListGPUProcessesHandler handles the list_gpu_processes tool.
+type ListGPUProcessesHandler struct {
+	nvmlClient  nvml.Interface
+	resolver    *ProcessResolver
+	allocations *PodGPUAllocationHandler
+	nodeName    string
+}
+
+// NewListGPUProcessesHandler creates a new GPU process listing handler.
+// When resolver is non-nil, each process is mapped to its pod and
+// container via cgroup inspection. clientset and nodeName are optional and
+// add pod names and GPU allocation to the mapping.
+func NewListGPUProcessesHandler(
+	nvmlClient nvml.Interface,
+	resolver *ProcessResolver,
+	clientset kubernetes.Interface,
+	nodeName string,
+) *ListGPUProcessesHandler {
+	return &ListGPUProcessesHandler{
+		nvmlClient:  nvmlClient,
+		resolver:    resolver,
+		allocations: NewPodGPUAllocationHandler(clientset),
+		nodeName:    nodeName,
+	}
+}
+
+// GPUProcessesResponse is the response for list_gpu_processes.
+type GPUProcessesResponse struct {
+	Status          string             `json:"status"`
+	DeviceCount     int                `json:"device_count"`
+	ProcessCount    int                `json:"process_count"`
+	GPUs            []GPUProcessStatus `json:"gpus"`
+	PodMappingError string             `json:"pod_mapping_error,omitempty"`
+}
+
+// GPUProcessStatus lists the processes running on a single GPU.
+type GPUProcessStatus struct {
+	Index           int          `json:"index"`
+	Name            string       `json:"name"`
+	UUID            string       `json:"uuid"`
+	PCIBusID        string       `json:"pci_bus_id"`
+	UsedMemoryBytes uint64       `json:"used_memory_bytes"`
+	Processes       []GPUProcess `json:"processes"`
+	Error           string       `json:"error,omitempty"`
+}
+
+// GPUProcess describes a process with an active context on a GPU.
+type GPUProcess struct {
+	PID             uint32        `json:"pid"`
+	Name            string        `json:"process_name,omitempty"`
+	Type            string        `json:"type"`
+	UsedMemoryBytes uint64        `json:"used_memory_bytes"`
+	Pod             *ProcessOwner `json:"pod,omitempty"`
+}
+
+// Handle processes the list_gpu_processes tool request.
+func (h *ListGPUProcessesHandler) Handle(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	klog.InfoS("list_gpu_processes invoked")
+
+	if err := ctx.Err(); err != nil {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("operation cancelled: %s", err)), nil
+	}
+
+	args := request.GetArguments()
+
+	// Optional filters: gpu_index or gpu_uuid
+	gpuIndex := -1
+	if v, ok := args["gpu_index"].(float64); ok {
+		if v < 0 || v != float64(int(v)) {
+			return mcp.NewToolResultError(
+				"invalid gpu_index: must be a non-negative integer"), nil
+		}
+		gpuIndex = int(v)
+	}
+	gpuUUID := ""
+	if v, ok := args["gpu_uuid"].(string); ok {
+		gpuUUID = strings.TrimSpace(v)
+	}
+
+	count, err := h.nvmlClient.GetDeviceCount(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to get device count")
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to get device count: %s", err)), nil
+	}
+
+	if gpuIndex >= count {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("invalid gpu_index %d: node has %d GPUs",
+				gpuIndex, count)), nil
+	}
+
+	response := GPUProcessesResponse{
+		Status: "success",
+		GPUs:   make([]GPUProcessStatus, 0, count),
+	}
+
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			klog.InfoS("context cancelled during enumeration")
+			return mcp.NewToolResultError(
+				fmt.Sprintf("operation cancelled: %s", err)), nil
+		}
+
+		if gpuIndex >= 0 && i != gpuIndex {
+			continue
+		}
+
+		device, err := h.nvmlClient.GetDeviceByIndex(ctx, i)
+		if err != nil {
+			klog.ErrorS(err, "failed to get device", "index", i)
+			continue
+		}
+		if device == nil {
+			klog.ErrorS(nil, "nil device returned without error", "index", i)
+			continue
+		}
+
+		if gpuUUID != "" {
+			uuid, err := device.GetUUID(ctx)
+			if err != nil {
+				klog.V(2).InfoS("failed to get GPU UUID",
+					"index", i, "error", err)
+				continue
+			}
+			if !strings.EqualFold(uuid, gpuUUID) {
+				continue
+			}
+		}
+
+		status := h.collectProcesses(ctx, i, device)
+
+		if status.Error != "" {
+			response.Status = "partial"
+		}
+		response.ProcessCount += len(status.Processes)
+		response.GPUs = append(response.GPUs, status)
+	}
+
+	if gpuUUID != "" && len(response.GPUs) == 0 {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("GPU %s not found on this node", gpuUUID)), nil
+	}
+
+	response.DeviceCount = len(response.GPUs)
+
+	if h.resolver != nil {
+		if err := h.attachOwners(ctx, response.GPUs); err != nil {
+			klog.V(2).InfoS("pod mapping incomplete", "error", err)
+			response.PodMappingError = err.Error()
+		}
+	}
+
+	klog.InfoS("list_gpu_processes completed",
+		"devices", response.DeviceCount, "processes", response.ProcessCount)
+
+	return h.marshalResponse(response)
+}
+
+// collectProcesses gathers device identity and running processes for a
+// single GPU. Process enumeration failures are reported in the Error field
+// so one unsupported device does not fail the whole call.
+func (h *ListGPUProcessesHandler) collectProcesses(
+	ctx context.Context,
+	index int,
+	device nvml.Device,
+) GPUProcessStatus {
+	status := GPUProcessStatus{
+		Index:     index,
+		Processes: make([]GPUProcess, 0),
+	}
+
+	name, err := device.GetName(ctx)
+	if err != nil {
+		klog.V(2).InfoS("failed to get GPU name", "index", index, "error", err)
+		status.Name = "Unknown"
+	} else {
+		status.Name = name
+	}
+
+	uuid, err := device.GetUUID(ctx)
+	if err != nil {
+		klog.V(2).InfoS("failed to get GPU UUID", "index", index, "error", err)
+		status.UUID = "unknown"
+	} else {
+		status.UUID = uuid
+	}
+
+	pciInfo, err := device.GetPCIInfo(ctx)
+	if err != nil {
+		klog.V(2).InfoS("failed to get PCI info", "index", index, "error", err)
+	} else {
+		status.PCIBusID = pciInfo.BusID
+	}
+
+	processes, err := device.GetRunningProcesses(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to get running processes", "index", index)
+		status.Error = fmt.Sprintf("failed to get running processes: %s", err)
+		return status
+	}
+
+	for _, p := range processes {
+		status.UsedMemoryBytes += p.UsedGPUMemory
+		status.Processes = append(status.Processes, GPUProcess{
+			PID:             p.PID,
+			Name:            p.Name,
+			Type:            p.Type,
+			UsedMemoryBytes: p.UsedGPUMemory,
+		})
+	}
+
+	return status
+}
+
+// attachOwners maps each process to its pod and container. Host processes
+// and processes that exited since enumeration are left without a pod.
+// Returns an error if pod metadata could not be joined; cgroup-derived
+// identifiers are attached regardless.
+func (h *ListGPUProcessesHandler) attachOwners(
+	ctx context.Context,
+	gpus []GPUProcessStatus,
+) error {
+	cgroups := make([]*ProcessCgroup, 0)
+	seen := make(map[uint32]bool)
+	for _, gpu := range gpus {
+		for _, p := range gpu.Processes {
+			if seen[p.PID] {
+				continue
+			}
+			seen[p.PID] = true
+
+			cg, err := h.resolver.Resolve(int(p.PID))
+			if err != nil {
+				klog.V(4).InfoS("process not mapped to a pod",
+					"pid", p.PID, "error", err)
+				continue
+			}
+			cgroups = append(cgroups, cg)
+		}
+	}
+
+	owners, err := h.allocations.ResolveProcessOwners(
+		ctx, h.nodeName, cgroups)
+
+	for i := range gpus {
+		for j := range gpus[i].Processes {
+			p := &gpus[i].Processes[j]
+			p.Pod = owners[int(p.PID)]
+		}
+	}
+
+	return err
+}
+
+// marshalResponse marshals the response to JSON and returns as tool result.
+func (h *ListGPUProcessesHandler) marshalResponse(
+	response GPUProcessesResponse,
+) (*mcp.CallToolResult, error) {
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		klog.ErrorS(err, "failed to marshal response")
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to marshal response: %s", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// GetListGPUProcessesTool returns the MCP tool definition for
+// list_gpu_processes.
+func GetListGPUProcessesTool() mcp.Tool {
+	return mcp.NewTool("list_gpu_processes",
+		mcp.WithDescription(
+			"Lists the compute and graphics processes running on each GPU "+
+				"with their PID, process name, and GPU memory usage. Each "+
+				"process is mapped to its Kubernetes pod and container via "+
+				"cgroup inspection. Answers \"who is using this GPU\". "+
+				"Optionally filter to a single GPU by index or UUID.",
+		),
+		mcp.WithNumber("gpu_index",
+			mcp.Description("Only list processes on the GPU at this index"),
+		),
+		mcp.WithString("gpu_uuid",
+			mcp.Description(
+				"Only list processes on the GPU with this UUID "+
+					"(e.g., GPU-12345678-...)",
+			),
+		),
+		withCallBudget(),
+	)
+}
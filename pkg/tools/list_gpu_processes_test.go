@@ -0,0 +1,315 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// callListProcesses invokes the handler and decodes a successful response.
+func callListProcesses(
+	t *testing.T,
+	handler *ListGPUProcessesHandler,
+	args map[string]interface{},
+) GPUProcessesResponse {
+	t.Helper()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.False(t, result.IsError, "unexpected tool error: %v", result.Content)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response GPUProcessesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	return response
+}
+
+func TestListGPUProcessesHandler_AllGPUs(t *testing.T) {
+	handler := NewListGPUProcessesHandler(
+		nvml.NewMock(2, nvml.WithMockProcessesPerDevice(2)), nil, nil, "")
+
+	response := callListProcesses(t, handler, nil)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 2, response.DeviceCount)
+	assert.Equal(t, 4, response.ProcessCount)
+	require.Len(t, response.GPUs, 2)
+
+	gpu := response.GPUs[1]
+	assert.Equal(t, 1, gpu.Index)
+	assert.NotEmpty(t, gpu.UUID)
+	assert.NotEmpty(t, gpu.PCIBusID)
+	require.Len(t, gpu.Processes, 2)
+	assert.Equal(t, uint32(10100), gpu.Processes[0].PID)
+	assert.NotEmpty(t, gpu.Processes[0].Name)
+	assert.Equal(t, nvml.ProcessTypeCompute, gpu.Processes[0].Type)
+	assert.Equal(t,
+		gpu.Processes[0].UsedMemoryBytes+gpu.Processes[1].UsedMemoryBytes,
+		gpu.UsedMemoryBytes)
+}
+
+func TestListGPUProcessesHandler_Filters(t *testing.T) {
+	mock := nvml.NewMock(3, nvml.WithMockDeviceProcesses(2, []nvml.ProcessInfo{
+		{PID: 4242, Name: "vllm", Type: nvml.ProcessTypeCompute,
+			UsedGPUMemory: 1 << 30},
+	}))
+	handler := NewListGPUProcessesHandler(mock, nil, nil, "")
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+	}{
+		{"by index", map[string]interface{}{"gpu_index": float64(2)}},
+		{"by uuid", map[string]interface{}{
+			"gpu_uuid": "gpu-00000002-0000-0000-0000-000000000002",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := callListProcesses(t, handler, tt.args)
+
+			require.Len(t, response.GPUs, 1)
+			assert.Equal(t, 2, response.GPUs[0].Index)
+			require.Len(t, response.GPUs[0].Processes, 1)
+			assert.Equal(t, uint32(4242), response.GPUs[0].Processes[0].PID)
+			assert.Equal(t, "vllm", response.GPUs[0].Processes[0].Name)
+		})
+	}
+}
+
+func TestListGPUProcessesHandler_UUIDQueriesOnlyMatchingGPU(t *testing.T) {
+	counting := &countingProcessesNVML{Interface: nvml.NewMock(3)}
+	handler := NewListGPUProcessesHandler(counting, nil, nil, "")
+
+	response := callListProcesses(t, handler, map[string]interface{}{
+		"gpu_uuid": "GPU-00000001-0000-0000-0000-000000000001",
+	})
+
+	require.Len(t, response.GPUs, 1)
+	assert.Equal(t, 1, response.GPUs[0].Index)
+	assert.Equal(t, []int{1}, counting.queried)
+}
+
+func TestListGPUProcessesHandler_IdleGPUs(t *testing.T) {
+	handler := NewListGPUProcessesHandler(
+		nvml.NewMock(1, nvml.WithMockProcessesPerDevice(0)), nil, nil, "")
+
+	response := callListProcesses(t, handler, nil)
+
+	assert.Equal(t, 0, response.ProcessCount)
+	require.Len(t, response.GPUs, 1)
+	assert.NotNil(t, response.GPUs[0].Processes)
+	assert.Empty(t, response.GPUs[0].Processes)
+}
+
+func TestListGPUProcessesHandler_ProcessQueryFailure(t *testing.T) {
+	handler := NewListGPUProcessesHandler(
+		&mockHealthyNVMLWithProcessError{}, nil, nil, "")
+
+	response := callListProcesses(t, handler, nil)
+
+	assert.Equal(t, "partial", response.Status)
+	require.Len(t, response.GPUs, 1)
+	assert.Contains(t, response.GPUs[0].Error, "insufficient permissions")
+	assert.Equal(t, "Tesla T4", response.GPUs[0].Name)
+}
+
+func TestListGPUProcessesHandler_PodMapping(t *testing.T) {
+	pod := makePodWithGPU("training-job", "ml", "gpu-node-1", 1)
+	pod.UID = types.UID(testPodUID)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "main", ContainerID: "containerd://" + testContainerID},
+	}
+
+	//nolint:staticcheck // NewSimpleClientset used for testing
+	clientset := fake.NewSimpleClientset(&pod)
+
+	procRoot := t.TempDir()
+	writeCgroupFile(t, procRoot, 10000,
+		"0::/kubepods.slice/kubepods-pod"+testPodUIDUnder+".slice/"+
+			"cri-containerd-"+testContainerID+".scope\n")
+	writeCgroupFile(t, procRoot, 20000, "0::/system.slice/Xorg.service\n")
+
+	mock := nvml.NewMock(2, nvml.WithMockDeviceProcesses(1, []nvml.ProcessInfo{
+		{PID: 20000, Name: "Xorg", Type: nvml.ProcessTypeGraphics},
+	}))
+
+	tests := []struct {
+		name        string
+		clientset   kubernetes.Interface
+		nodeName    string
+		wantPodName string
+		wantErr     string
+	}{
+		{
+			name:        "joined with pod allocation",
+			clientset:   clientset,
+			nodeName:    "gpu-node-1",
+			wantPodName: "training-job",
+		},
+		{
+			name:    "cgroup only without K8s client",
+			wantErr: "K8s client not configured",
+		},
+		{
+			name:      "cgroup only without node name",
+			clientset: clientset,
+			wantErr:   "agent node name unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewListGPUProcessesHandler(mock,
+				NewProcessResolver(procRoot), tt.clientset, tt.nodeName)
+
+			response := callListProcesses(t, handler, nil)
+			require.Len(t, response.GPUs, 2)
+			if tt.wantErr != "" {
+				assert.Contains(t, response.PodMappingError, tt.wantErr)
+			} else {
+				assert.Empty(t, response.PodMappingError)
+			}
+
+			owner := response.GPUs[0].Processes[0].Pod
+			require.NotNil(t, owner)
+			assert.Equal(t, testPodUID, owner.PodUID)
+			assert.Equal(t, testContainerID, owner.ContainerID)
+			assert.Equal(t, tt.wantPodName, owner.PodName)
+			if tt.wantPodName != "" {
+				assert.Equal(t, "ml", owner.PodNamespace)
+				assert.Equal(t, "main", owner.ContainerName)
+				assert.Equal(t, int64(1), owner.GPURequest)
+				assert.Equal(t, []string{"GPU-uuid-1"}, owner.AllocatedGPUUUIDs)
+			}
+
+			// Host processes are not mapped to a pod
+			assert.Nil(t, response.GPUs[1].Processes[0].Pod)
+		})
+	}
+}
+
+func TestListGPUProcessesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "negative index",
+			args:    map[string]interface{}{"gpu_index": float64(-1)},
+			wantErr: "invalid gpu_index",
+		},
+		{
+			name:    "index out of range",
+			args:    map[string]interface{}{"gpu_index": float64(8)},
+			wantErr: "node has 2 GPUs",
+		},
+		{
+			name:    "unknown uuid",
+			args:    map[string]interface{}{"gpu_uuid": "GPU-missing"},
+			wantErr: "GPU GPU-missing not found",
+		},
+	}
+
+	handler := NewListGPUProcessesHandler(nvml.NewMock(2), nil, nil, "")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = tt.args
+
+			result, err := handler.Handle(context.Background(), request)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+
+			textContent, ok := mcp.AsTextContent(result.Content[0])
+			require.True(t, ok)
+			assert.Contains(t, textContent.Text, tt.wantErr)
+		})
+	}
+}
+
+func TestGetListGPUProcessesTool(t *testing.T) {
+	tool := GetListGPUProcessesTool()
+
+	assert.Equal(t, "list_gpu_processes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "gpu_index")
+	assert.Contains(t, tool.InputSchema.Properties, "gpu_uuid")
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+}
+
+// mockHealthyNVMLWithProcessError returns a healthy GPU whose process
+// enumeration fails.
+type mockHealthyNVMLWithProcessError struct {
+	mockHealthyNVML
+}
+
+func (m *mockHealthyNVMLWithProcessError) GetDeviceByIndex(
+	ctx context.Context,
+	idx int,
+) (nvml.Device, error) {
+	return &mockDeviceWithProcessError{}, nil
+}
+
+type mockDeviceWithProcessError struct {
+	mockHealthyDevice
+}
+
+func (d *mockDeviceWithProcessError) GetRunningProcesses(
+	ctx context.Context,
+) ([]nvml.ProcessInfo, error) {
+	return nil, errors.New("insufficient permissions")
+}
+
+// countingProcessesNVML records which device indexes had their processes
+// enumerated.
+type countingProcessesNVML struct {
+	nvml.Interface
+	queried []int
+}
+
+func (m *countingProcessesNVML) GetDeviceByIndex(
+	ctx context.Context,
+	idx int,
+) (nvml.Device, error) {
+	device, err := m.Interface.GetDeviceByIndex(ctx, idx)
+	if err != nil {
+		return nil, err
+	}
+	return &countingProcessesDevice{Device: device, index: idx, nvml: m}, nil
+}
+
+type countingProcessesDevice struct {
+	nvml.Device
+	index int
+	nvml  *countingProcessesNVML
+}
+
+func (d *countingProcessesDevice) GetRunningProcesses(
+	ctx context.Context,
+) ([]nvml.ProcessInfo, error) {
+	d.nvml.queried = append(d.nvml.queried, d.index)
+	return d.Device.GetRunningProcesses(ctx)
+}
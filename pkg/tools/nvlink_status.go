@@ -0,0 +1,353 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/klog/v2"
+)
+
+// NVLink error thresholds. CRC errors are corrected by link-level replay,
+// so a low count accumulates on healthy links over their lifetime; counts
+// above these thresholds point to a marginal link or connector.
+const (
+	nvlinkCRCErrorThreshold    uint64 = 100
+	nvlinkReplayErrorThreshold uint64 = 100
+)
+
+// NVLink link and GPU states.
+const (
+	nvlinkStateUp   = "up"
+	nvlinkStateDown = "down"
+
+	nvlinkStatusHealthy  = "healthy"
+	nvlinkStatusDegraded = "degraded"
+	nvlinkStatusDown     = "down"
+	// nvlinkStatusInactive means no GPU on the node has an active link,
+	// i.e. NVLink is not cabled or bridged rather than failed.
+	nvlinkStatusInactive = "inactive"
+	// nvlinkStatusNotSupported means the GPU has no NVLinks.
+	nvlinkStatusNotSupported = "not_supported"
+)
+
+// NVLinkStatusHandler handles the get_nvlink_status tool.
+type NVLinkStatusHandler struct {
+	nvmlClient nvml.Interface
+}
+
+// NewNVLinkStatusHandler creates a new NVLink status handler.
+func NewNVLinkStatusHandler(nvmlClient nvml.Interface) *NVLinkStatusHandler {
+	return &NVLinkStatusHandler{
+		nvmlClient: nvmlClient,
+	}
+}
+
+// NVLinkStatusResponse is the response for get_nvlink_status.
+type NVLinkStatusResponse struct {
+	Status        string            `json:"status"`
+	DeviceCount   int               `json:"device_count"`
+	LinkCount     int               `json:"link_count"`
+	ActiveLinks   int               `json:"active_links"`
+	DegradedLinks int               `json:"degraded_links"`
+	GPUs          []GPUNVLinkStatus `json:"gpus"`
+}
+
+// GPUNVLinkStatus contains the NVLinks of a single GPU.
+type GPUNVLinkStatus struct {
+	Index         int            `json:"index"`
+	Name          string         `json:"name"`
+	UUID          string         `json:"uuid"`
+	PCIBusID      string         `json:"pci_bus_id"`
+	Status        string         `json:"status"`
+	LinkCount     int            `json:"link_count"`
+	ActiveLinks   int            `json:"active_links"`
+	DegradedLinks int            `json:"degraded_links"`
+	Links         []NVLinkStatus `json:"links,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// NVLinkStatus describes the state and error counters of a single link.
+type NVLinkStatus struct {
+	Link           int      `json:"link"`
+	State          string   `json:"state"`
+	Status         string   `json:"status"`
+	Version        uint32   `json:"version,omitempty"`
+	RemoteType     string   `json:"remote_type,omitempty"`
+	RemotePCIBusID string   `json:"remote_pci_bus_id,omitempty"`
+	RemoteUUID     string   `json:"remote_uuid,omitempty"`
+	CRCErrors      uint64   `json:"crc_errors"`
+	ReplayErrors   uint64   `json:"replay_errors"`
+	RecoveryErrors uint64   `json:"recovery_errors"`
+	Issues         []string `json:"issues,omitempty"`
+}
+
+// Handle processes the get_nvlink_status tool request.
+func (h *NVLinkStatusHandler) Handle(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	klog.InfoS("get_nvlink_status invoked")
+
+	if err := ctx.Err(); err != nil {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("operation cancelled: %s", err)), nil
+	}
+
+	degradedOnly := false
+	if v, ok := request.GetArguments()["degraded_only"].(bool); ok {
+		degradedOnly = v
+	}
+
+	count, err := h.nvmlClient.GetDeviceCount(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to get device count")
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to get device count: %s", err)), nil
+	}
+
+	response := NVLinkStatusResponse{
+		Status: "success",
+		GPUs:   make([]GPUNVLinkStatus, 0, count),
+	}
+
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			klog.InfoS("context cancelled during enumeration")
+			return mcp.NewToolResultError(
+				fmt.Sprintf("operation cancelled: %s", err)), nil
+		}
+
+		device, err := h.nvmlClient.GetDeviceByIndex(ctx, i)
+		if err != nil {
+			klog.ErrorS(err, "failed to get device", "index", i)
+			continue
+		}
+		if device == nil {
+			klog.ErrorS(nil, "nil device returned without error", "index", i)
+			continue
+		}
+
+		response.GPUs = append(response.GPUs, h.collectLinks(ctx, i, device))
+	}
+
+	classifyInactiveGPUs(response.GPUs)
+
+	for i := range response.GPUs {
+		gpu := &response.GPUs[i]
+		if gpu.Error != "" {
+			response.Status = "partial"
+		}
+		response.LinkCount += gpu.LinkCount
+		response.ActiveLinks += gpu.ActiveLinks
+		response.DegradedLinks += gpu.DegradedLinks
+
+		if degradedOnly {
+			gpu.Links = degradedLinks(gpu.Links)
+		}
+	}
+	response.DeviceCount = len(response.GPUs)
+
+	klog.InfoS("get_nvlink_status completed",
+		"devices", response.DeviceCount,
+		"links", response.LinkCount,
+		"degradedLinks", response.DegradedLinks)
+
+	return h.marshalResponse(response)
+}
+
+// collectLinks gathers device identity and the status of each NVLink on a
+// single GPU. Link enumeration failures are reported in the Error field so
+// one device does not fail the whole call.
+func (h *NVLinkStatusHandler) collectLinks(
+	ctx context.Context,
+	index int,
+	device nvml.Device,
+) GPUNVLinkStatus {
+	status := GPUNVLinkStatus{Index: index}
+
+	name, err := device.GetName(ctx)
+	if err != nil {
+		klog.V(2).InfoS("failed to get GPU name", "index", index, "error", err)
+		status.Name = "Unknown"
+	} else {
+		status.Name = name
+	}
+
+	uuid, err := device.GetUUID(ctx)
+	if err != nil {
+		klog.V(2).InfoS("failed to get GPU UUID", "index", index, "error", err)
+		status.UUID = "unknown"
+	} else {
+		status.UUID = uuid
+	}
+
+	pciInfo, err := device.GetPCIInfo(ctx)
+	if err != nil {
+		klog.V(2).InfoS("failed to get PCI info", "index", index, "error", err)
+	} else {
+		status.PCIBusID = pciInfo.BusID
+	}
+
+	links, err := device.GetNvLinks(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to get NVLinks", "index", index)
+		status.Status = nvlinkStatusNotSupported
+		status.Error = fmt.Sprintf("failed to get NVLinks: %s", err)
+		return status
+	}
+
+	status.Status = nvlinkStatusHealthy
+	if len(links) == 0 {
+		status.Status = nvlinkStatusNotSupported
+	}
+
+	for _, link := range links {
+		ls := checkNVLink(link)
+		status.LinkCount++
+		if link.Active {
+			status.ActiveLinks++
+		}
+		if ls.Status != nvlinkStatusHealthy {
+			status.DegradedLinks++
+			status.Status = nvlinkStatusDegraded
+		}
+		status.Links = append(status.Links, ls)
+	}
+
+	// All links down is reported as inactive until classifyInactiveGPUs
+	// can compare against the other GPUs on the node
+	if status.LinkCount > 0 && status.ActiveLinks == 0 {
+		status.Status = nvlinkStatusInactive
+		status.DegradedLinks = 0
+		for i := range status.Links {
+			status.Links[i].Status = nvlinkStatusInactive
+		}
+	}
+
+	return status
+}
+
+// checkNVLink converts a link to its reported status and flags down links
+// and error counters above the thresholds.
+func checkNVLink(link nvml.NvLinkInfo) NVLinkStatus {
+	status := NVLinkStatus{
+		Link:           link.Link,
+		State:          nvlinkStateUp,
+		Status:         nvlinkStatusHealthy,
+		Version:        link.Version,
+		RemoteType:     link.RemoteType,
+		RemotePCIBusID: link.RemotePCIBusID,
+		RemoteUUID:     link.RemoteUUID,
+		CRCErrors:      link.CRCErrors,
+		ReplayErrors:   link.ReplayErrors,
+		RecoveryErrors: link.RecoveryErrors,
+	}
+
+	if !link.Active {
+		status.State = nvlinkStateDown
+		status.Status = nvlinkStatusDown
+		status.Issues = append(status.Issues, "link is down")
+	}
+
+	if link.RecoveryErrors > 0 {
+		status.Issues = append(status.Issues, fmt.Sprintf(
+			"link retrained %d times (recovery errors)", link.RecoveryErrors))
+	}
+	if link.CRCErrors > nvlinkCRCErrorThreshold {
+		status.Issues = append(status.Issues, fmt.Sprintf(
+			"%d CRC errors exceed threshold of %d",
+			link.CRCErrors, nvlinkCRCErrorThreshold))
+	}
+	if link.ReplayErrors > nvlinkReplayErrorThreshold {
+		status.Issues = append(status.Issues, fmt.Sprintf(
+			"%d replay errors exceed threshold of %d",
+			link.ReplayErrors, nvlinkReplayErrorThreshold))
+	}
+
+	if link.Active && len(status.Issues) > 0 {
+		status.Status = nvlinkStatusDegraded
+	}
+
+	return status
+}
+
+// classifyInactiveGPUs decides whether GPUs with all links down have
+// failed. If another GPU on the node has active links, the node uses NVLink
+// and the GPU has dropped out of the fabric; otherwise NVLink is simply not
+// connected on this node.
+func classifyInactiveGPUs(gpus []GPUNVLinkStatus) {
+	fabricActive := false
+	for _, gpu := range gpus {
+		if gpu.ActiveLinks > 0 {
+			fabricActive = true
+			break
+		}
+	}
+	if !fabricActive {
+		return
+	}
+
+	for i := range gpus {
+		if gpus[i].Status != nvlinkStatusInactive {
+			continue
+		}
+		gpus[i].Status = nvlinkStatusDegraded
+		gpus[i].DegradedLinks = gpus[i].LinkCount
+		for j := range gpus[i].Links {
+			gpus[i].Links[j].Status = nvlinkStatusDown
+		}
+	}
+}
+
+// degradedLinks returns the links that are down or erroring.
+func degradedLinks(links []NVLinkStatus) []NVLinkStatus {
+	var degraded []NVLinkStatus
+	for _, link := range links {
+		if link.Status == nvlinkStatusDown ||
+			link.Status == nvlinkStatusDegraded {
+			degraded = append(degraded, link)
+		}
+	}
+	return degraded
+}
+
+// marshalResponse marshals the response to JSON and returns as tool result.
+func (h *NVLinkStatusHandler) marshalResponse(
+	response NVLinkStatusResponse,
+) (*mcp.CallToolResult, error) {
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		klog.ErrorS(err, "failed to marshal response")
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to marshal response: %s", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// GetNVLinkStatusTool returns the MCP tool definition for get_nvlink_status.
+func GetNVLinkStatusTool() mcp.Tool {
+	return mcp.NewTool("get_nvlink_status",
+		mcp.WithDescription(
+			"Reports the state of every NVLink on each GPU: up/down, NVLink "+
+				"version, the remote peer (GPU UUID or NVSwitch PCI address), "+
+				"and CRC, replay and recovery error counters. Flags links that "+
+				"are down or erroring. Use when multi-GPU training hangs or "+
+				"slows down, or after NVLink XID 74 errors.",
+		),
+		mcp.WithBoolean("degraded_only",
+			mcp.Description(
+				"Only include links that are down or erroring "+
+					"(default: false)",
+			),
+		),
+		withCacheControl(),
+		withCallBudget(),
+	)
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callNVLinkStatus invokes the handler and decodes a successful response.
+func callNVLinkStatus(
+	t *testing.T,
+	nvmlClient nvml.Interface,
+	args map[string]interface{},
+) NVLinkStatusResponse {
+	t.Helper()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+
+	result, err := NewNVLinkStatusHandler(nvmlClient).Handle(
+		context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.False(t, result.IsError, "unexpected tool error: %v", result.Content)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response NVLinkStatusResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	return response
+}
+
+// allLinksDown returns options taking every mock NVLink of a GPU down.
+func allLinksDown(idx int) []nvml.MockOption {
+	opts := make([]nvml.MockOption, 0, 12)
+	for link := 0; link < 12; link++ {
+		opts = append(opts, nvml.WithMockNvLinkDown(idx, link))
+	}
+	return opts
+}
+
+func TestNVLinkStatusHandler_Healthy(t *testing.T) {
+	response := callNVLinkStatus(t, nvml.NewMock(2), nil)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 2, response.DeviceCount)
+	assert.Equal(t, 24, response.LinkCount)
+	assert.Equal(t, 24, response.ActiveLinks)
+	assert.Equal(t, 0, response.DegradedLinks)
+	require.Len(t, response.GPUs, 2)
+
+	gpu := response.GPUs[0]
+	assert.Equal(t, "healthy", gpu.Status)
+	require.Len(t, gpu.Links, 12)
+	assert.Equal(t, "up", gpu.Links[0].State)
+	assert.Equal(t, "healthy", gpu.Links[0].Status)
+	assert.Equal(t, uint32(3), gpu.Links[0].Version)
+	assert.Equal(t, nvml.NvLinkRemoteGPU, gpu.Links[0].RemoteType)
+	assert.Equal(t, response.GPUs[1].UUID, gpu.Links[0].RemoteUUID)
+	assert.Equal(t, response.GPUs[1].PCIBusID, gpu.Links[0].RemotePCIBusID)
+	assert.Empty(t, gpu.Links[0].Issues)
+}
+
+func TestNVLinkStatusHandler_NotSupported(t *testing.T) {
+	response := callNVLinkStatus(t, nvml.NewMock(1), nil)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 0, response.LinkCount)
+	require.Len(t, response.GPUs, 1)
+	assert.Equal(t, "not_supported", response.GPUs[0].Status)
+	assert.Empty(t, response.GPUs[0].Links)
+}
+
+func TestNVLinkStatusHandler_DegradedLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []nvml.MockOption
+		link        int
+		wantState   string
+		wantStatus  string
+		wantIssue   string
+		wantHealthy bool
+	}{
+		{
+			name:       "link down",
+			opts:       []nvml.MockOption{nvml.WithMockNvLinkDown(0, 3)},
+			link:       3,
+			wantState:  "down",
+			wantStatus: "down",
+			wantIssue:  "link is down",
+		},
+		{
+			name: "recovery errors",
+			opts: []nvml.MockOption{
+				nvml.WithMockNvLinkErrors(0, 5, 0, 0, 1),
+			},
+			link:       5,
+			wantState:  "up",
+			wantStatus: "degraded",
+			wantIssue:  "retrained 1 times",
+		},
+		{
+			name: "CRC errors above threshold",
+			opts: []nvml.MockOption{
+				nvml.WithMockNvLinkErrors(0, 2, 500, 0, 0),
+			},
+			link:       2,
+			wantState:  "up",
+			wantStatus: "degraded",
+			wantIssue:  "500 CRC errors",
+		},
+		{
+			name: "replay errors above threshold",
+			opts: []nvml.MockOption{
+				nvml.WithMockNvLinkErrors(0, 9, 0, 101, 0),
+			},
+			link:       9,
+			wantState:  "up",
+			wantStatus: "degraded",
+			wantIssue:  "101 replay errors",
+		},
+		{
+			name: "counters below threshold",
+			opts: []nvml.MockOption{
+				nvml.WithMockNvLinkErrors(0, 1, 10, 20, 0),
+			},
+			link:        1,
+			wantState:   "up",
+			wantStatus:  "healthy",
+			wantHealthy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := callNVLinkStatus(t, nvml.NewMock(2, tt.opts...), nil)
+
+			gpu := response.GPUs[0]
+			link := gpu.Links[tt.link]
+			assert.Equal(t, tt.wantState, link.State)
+			assert.Equal(t, tt.wantStatus, link.Status)
+
+			if tt.wantHealthy {
+				assert.Equal(t, "healthy", gpu.Status)
+				assert.Equal(t, 0, response.DegradedLinks)
+				assert.Empty(t, link.Issues)
+				return
+			}
+			assert.Equal(t, "degraded", gpu.Status)
+			assert.Equal(t, 1, gpu.DegradedLinks)
+			assert.Equal(t, 1, response.DegradedLinks)
+			require.Len(t, link.Issues, 1)
+			assert.Contains(t, link.Issues[0], tt.wantIssue)
+
+			// The peer GPU is unaffected
+			assert.Equal(t, "healthy", response.GPUs[1].Status)
+		})
+	}
+}
+
+func TestNVLinkStatusHandler_AllLinksDown(t *testing.T) {
+	t.Run("GPU dropped out of an active fabric", func(t *testing.T) {
+		response := callNVLinkStatus(t, nvml.NewMock(2, allLinksDown(1)...), nil)
+
+		gpu := response.GPUs[1]
+		assert.Equal(t, "degraded", gpu.Status)
+		assert.Equal(t, 0, gpu.ActiveLinks)
+		assert.Equal(t, 12, gpu.DegradedLinks)
+		assert.Equal(t, "down", gpu.Links[0].Status)
+		assert.Equal(t, 12, response.DegradedLinks)
+	})
+
+	t.Run("NVLink not connected on node", func(t *testing.T) {
+		opts := append(allLinksDown(0), allLinksDown(1)...)
+		response := callNVLinkStatus(t, nvml.NewMock(2, opts...), nil)
+
+		assert.Equal(t, 0, response.ActiveLinks)
+		assert.Equal(t, 0, response.DegradedLinks)
+		for _, gpu := range response.GPUs {
+			assert.Equal(t, "inactive", gpu.Status)
+			assert.Equal(t, "inactive", gpu.Links[0].Status)
+		}
+	})
+}
+
+func TestNVLinkStatusHandler_DegradedOnly(t *testing.T) {
+	mock := nvml.NewMock(2,
+		nvml.WithMockNvLinkDown(0, 3),
+		nvml.WithMockNvLinkErrors(1, 7, 0, 0, 4),
+	)
+
+	response := callNVLinkStatus(t, mock,
+		map[string]interface{}{"degraded_only": true})
+
+	// Counts still cover all links
+	assert.Equal(t, 24, response.LinkCount)
+	assert.Equal(t, 2, response.DegradedLinks)
+
+	require.Len(t, response.GPUs, 2)
+	require.Len(t, response.GPUs[0].Links, 1)
+	assert.Equal(t, 3, response.GPUs[0].Links[0].Link)
+	require.Len(t, response.GPUs[1].Links, 1)
+	assert.Equal(t, 7, response.GPUs[1].Links[0].Link)
+}
+
+func TestNVLinkStatusHandler_QueryFailure(t *testing.T) {
+	response := callNVLinkStatus(t, &mockHealthyNVMLWithNvLinkError{}, nil)
+
+	assert.Equal(t, "partial", response.Status)
+	require.Len(t, response.GPUs, 1)
+	assert.Contains(t, response.GPUs[0].Error, "NVML_ERROR_UNKNOWN")
+	assert.Equal(t, "Tesla T4", response.GPUs[0].Name)
+}
+
+func TestNVLinkStatusHandler_NoGPUs(t *testing.T) {
+	response := callNVLinkStatus(t, &mockEmptyNVML{}, nil)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 0, response.DeviceCount)
+	assert.Empty(t, response.GPUs)
+}
+
+func TestGetNVLinkStatusTool(t *testing.T) {
+	tool := GetNVLinkStatusTool()
+
+	assert.Equal(t, "get_nvlink_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "degraded_only")
+	assert.Contains(t, tool.InputSchema.Properties, "max_age")
+	assert.Contains(t, tool.InputSchema.Properties, "no_cache")
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+}
+
+// mockHealthyNVMLWithNvLinkError returns a healthy GPU whose NVLink query
+// fails.
+type mockHealthyNVMLWithNvLinkError struct {
+	mockHealthyNVML
+}
+
+func (m *mockHealthyNVMLWithNvLinkError) GetDeviceByIndex(
+	ctx context.Context,
+	idx int,
+) (nvml.Device, error) {
+	return &mockDeviceWithNvLinkError{}, nil
+}
+
+type mockDeviceWithNvLinkError struct {
+	mockHealthyDevice
+}
+
+func (d *mockDeviceWithNvLinkError) GetNvLinks(
+	ctx context.Context,
+) ([]nvml.NvLinkInfo, error) {
+	return nil, errors.New("NVML_ERROR_UNKNOWN")
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,17 +17,21 @@ import (
 	"k8s.io/klog/v2"
 )
 
-const (
-	// nvidiaGPUResource is the resource name for NVIDIA GPUs.
-	nvidiaGPUResource = "nvidia.com/gpu"
-	// gpuDeviceAnnotation is the annotation set by NVIDIA device plugin
-	// containing assigned GPU UUIDs.
-	gpuDeviceAnnotation = "nvidia.com/gpu.device"
-)
+// gpuDeviceAnnotation is the annotation set by NVIDIA device plugin
+// containing assigned GPU UUIDs.
+const gpuDeviceAnnotation = "nvidia.com/gpu.device"
+
+// GPUPodLister lists the pods requesting GPUs on a node. k8s.Client
+// implements it, serving the list from its informer cache once synced.
+type GPUPodLister interface {
+	ListGPUPodsOnNode(ctx context.Context, namespace, nodeName string) (
+		[]corev1.Pod, error)
+}
 
 // PodGPUAllocationHandler handles the get_pod_gpu_allocation tool.
 type PodGPUAllocationHandler struct {
 	clientset kubernetes.Interface
+	gpuPods   GPUPodLister
 }
 
 // NewPodGPUAllocationHandler creates a new pod GPU allocation handler.
@@ -36,6 +41,18 @@ func NewPodGPUAllocationHandler(clientset kubernetes.Interface) *PodGPUAllocatio
 	}
 }
 
+// NewPodGPUAllocationHandlerWithLister creates a pod GPU allocation handler
+// that reads GPU pods from lister instead of listing them from clientset.
+func NewPodGPUAllocationHandlerWithLister(
+	clientset kubernetes.Interface,
+	lister GPUPodLister,
+) *PodGPUAllocationHandler {
+	return &PodGPUAllocationHandler{
+		clientset: clientset,
+		gpuPods:   lister,
+	}
+}
+
 // PodGPUAllocation represents GPU allocation for a pod.
 type PodGPUAllocation struct {
 	Name       string                   `json:"name"`
@@ -51,6 +68,9 @@ type ContainerGPUAllocation struct {
 	GPURequest int64    `json:"gpu_request"`
 	GPULimit   int64    `json:"gpu_limit"`
 	GPUUUIDs   []string `json:"gpu_uuids,omitempty"`
+	// MIGDevices is the number of MIG devices requested per profile
+	// (e.g., {"1g.5gb": 2}).
+	MIGDevices map[string]int64 `json:"mig_devices,omitempty"`
 }
 
 // PodGPUAllocationResponse is the response for get_pod_gpu_allocation.
@@ -65,8 +85,10 @@ type PodGPUAllocationResponse struct {
 
 // AllocationSummary provides summary statistics for GPU allocations.
 type AllocationSummary struct {
-	TotalPods          int   `json:"total_pods"`
-	TotalGPUsAllocated int64 `json:"total_gpus_allocated"`
+	TotalPods                int              `json:"total_pods"`
+	TotalGPUsAllocated       int64            `json:"total_gpus_allocated"`
+	TotalMIGDevicesAllocated int64            `json:"total_mig_devices_allocated"`
+	MIGDevicesByProfile      map[string]int64 `json:"mig_devices_by_profile,omitempty"`
 }
 
 // Handle processes the get_pod_gpu_allocation tool request.
@@ -77,7 +99,7 @@ func (h *PodGPUAllocationHandler) Handle(
 	klog.InfoS("get_pod_gpu_allocation invoked")
 
 	// Guard against nil clientset - this tool requires K8s access
-	if h.clientset == nil {
+	if h.clientset == nil && h.gpuPods == nil {
 		return mcp.NewToolResultError(
 			"K8s client not configured - this tool requires cluster access"), nil
 	}
@@ -105,11 +127,7 @@ func (h *PodGPUAllocationHandler) Handle(
 	klog.V(4).InfoS("querying pods", "node", nodeName, "namespace", namespace)
 
 	// List pods on the specified node
-	listOpts := metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
-	}
-
-	pods, err := h.clientset.CoreV1().Pods(namespace).List(ctx, listOpts)
+	pods, err := h.listPodsOnNode(ctx, namespace, nodeName)
 	if err != nil {
 		klog.ErrorS(err, "failed to list pods",
 			"hint", "Agent may lack RBAC permissions. Apply deployment/rbac/agent-rbac-readonly.yaml")
@@ -134,9 +152,10 @@ func (h *PodGPUAllocationHandler) Handle(
 	// Note: Also filter by nodeName client-side since fake clientset
 	// doesn't support FieldSelector in tests
 	gpuPods := make([]PodGPUAllocation, 0)
-	var totalGPUs int64
+	var totalGPUs, totalMIG int64
+	migByProfile := make(map[string]int64)
 
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
@@ -156,6 +175,10 @@ func (h *PodGPUAllocationHandler) Handle(
 			gpuPods = append(gpuPods, *allocation)
 			for _, container := range allocation.Containers {
 				totalGPUs += container.GPURequest
+				for profile, count := range container.MIGDevices {
+					totalMIG += count
+					migByProfile[profile] += count
+				}
 			}
 		}
 	}
@@ -166,11 +189,16 @@ func (h *PodGPUAllocationHandler) Handle(
 		NodeName: nodeName,
 		Pods:     gpuPods,
 		Summary: AllocationSummary{
-			TotalPods:          len(gpuPods),
-			TotalGPUsAllocated: totalGPUs,
+			TotalPods:                len(gpuPods),
+			TotalGPUsAllocated:       totalGPUs,
+			TotalMIGDevicesAllocated: totalMIG,
 		},
 	}
 
+	if len(migByProfile) > 0 {
+		response.Summary.MIGDevicesByProfile = migByProfile
+	}
+
 	// Marshal to JSON
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -180,11 +208,33 @@ func (h *PodGPUAllocationHandler) Handle(
 	}
 
 	klog.InfoS("get_pod_gpu_allocation completed",
-		"node", nodeName, "pods", len(gpuPods), "gpus", totalGPUs)
+		"node", nodeName, "pods", len(gpuPods), "gpus", totalGPUs,
+		"migDevices", totalMIG)
 
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
 
+// listPodsOnNode lists the pods on a node, from the GPU pod lister when
+// one is configured. An empty namespace matches all namespaces.
+func (h *PodGPUAllocationHandler) listPodsOnNode(
+	ctx context.Context,
+	namespace string,
+	nodeName string,
+) ([]corev1.Pod, error) {
+	if h.gpuPods != nil {
+		return h.gpuPods.ListGPUPodsOnNode(ctx, namespace, nodeName)
+	}
+
+	pods, err := h.clientset.CoreV1().Pods(namespace).List(ctx,
+		metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+		})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
 // extractGPUAllocation extracts GPU allocation info from a pod.
 // Returns nil if the pod has no GPU allocations.
 func (h *PodGPUAllocationHandler) extractGPUAllocation(
@@ -197,20 +247,26 @@ func (h *PodGPUAllocationHandler) extractGPUAllocation(
 		gpuRequest := int64(0)
 		gpuLimit := int64(0)
 
-		if req, ok := container.Resources.Requests[nvidiaGPUResource]; ok {
+		if req, ok := container.Resources.Requests[k8s.GPUResourceName]; ok {
 			gpuRequest = req.Value()
 			hasGPU = true
 		}
-		if lim, ok := container.Resources.Limits[nvidiaGPUResource]; ok {
+		if lim, ok := container.Resources.Limits[k8s.GPUResourceName]; ok {
 			gpuLimit = lim.Value()
 			hasGPU = true
 		}
 
-		if gpuRequest > 0 || gpuLimit > 0 {
+		migDevices := ContainerMIGDevices(&container)
+		if len(migDevices) > 0 {
+			hasGPU = true
+		}
+
+		if gpuRequest > 0 || gpuLimit > 0 || len(migDevices) > 0 {
 			containerAlloc := ContainerGPUAllocation{
 				Name:       container.Name,
 				GPURequest: gpuRequest,
 				GPULimit:   gpuLimit,
+				MIGDevices: migDevices,
 			}
 			containers = append(containers, containerAlloc)
 		}
@@ -233,12 +289,14 @@ func (h *PodGPUAllocationHandler) extractGPUAllocation(
 	// not per-container. For multi-container pods, we cannot determine which
 	// specific GPUs are assigned to which container from the annotation alone.
 	// We assign all UUIDs to the first container with GPU requests as a
-	// best-effort approximation. For accurate per-container GPU mapping,
-	// inspect the container's environment variables (NVIDIA_VISIBLE_DEVICES).
+	// best-effort approximation. The GPUs a container is actually using are
+	// reported by list_gpu_processes, which maps each GPU process to its
+	// container via cgroup inspection (see ResolveProcessOwners).
 	if uuids, ok := pod.Annotations[gpuDeviceAnnotation]; ok && uuids != "" {
 		gpuUUIDs := strings.Split(uuids, ",")
 		for i := range allocation.Containers {
-			if allocation.Containers[i].GPURequest > 0 {
+			if allocation.Containers[i].GPURequest > 0 ||
+				len(allocation.Containers[i].MIGDevices) > 0 {
 				allocation.Containers[i].GPUUUIDs = gpuUUIDs
 				break
 			}
@@ -248,12 +306,169 @@ func (h *PodGPUAllocationHandler) extractGPUAllocation(
 	return allocation
 }
 
+// ContainerMIGDevices returns the MIG devices a container requests keyed by
+// profile. Extended resources must have equal requests and limits, so the
+// limit is used when no request is set.
+func ContainerMIGDevices(container *corev1.Container) map[string]int64 {
+	var devices map[string]int64
+	for _, list := range []corev1.ResourceList{
+		container.Resources.Requests, container.Resources.Limits,
+	} {
+		for name, qty := range list {
+			profile, ok := strings.CutPrefix(string(name), k8s.MIGResourcePrefix)
+			if !ok || qty.Value() <= 0 {
+				continue
+			}
+			if devices == nil {
+				devices = make(map[string]int64)
+			}
+			if _, seen := devices[profile]; !seen {
+				devices[profile] = qty.Value()
+			}
+		}
+	}
+	return devices
+}
+
+// MIGResourceCounts returns the nvidia.com/mig-* resources in a node's
+// capacity or allocatable list keyed by profile, or nil if there are none.
+func MIGResourceCounts(resources corev1.ResourceList) map[string]int64 {
+	var counts map[string]int64
+	for name, qty := range resources {
+		profile, ok := strings.CutPrefix(string(name), k8s.MIGResourcePrefix)
+		if !ok {
+			continue
+		}
+		if counts == nil {
+			counts = make(map[string]int64)
+		}
+		counts[profile] = qty.Value()
+	}
+	return counts
+}
+
+// ProcessOwner joins a process cgroup with the pod and container that own
+// it and the GPU allocation of that container.
+type ProcessOwner struct {
+	PodName       string `json:"pod_name,omitempty"`
+	PodNamespace  string `json:"pod_namespace,omitempty"`
+	PodUID        string `json:"pod_uid"`
+	ContainerName string `json:"container_name,omitempty"`
+	ContainerID   string `json:"container_id,omitempty"`
+	// GPURequest is the container's nvidia.com/gpu request. Zero means the
+	// process uses a GPU its container did not request (e.g., via
+	// NVIDIA_VISIBLE_DEVICES=all).
+	GPURequest int64 `json:"gpu_request"`
+	// MIGDevices is the container's MIG device request per profile.
+	MIGDevices map[string]int64 `json:"mig_devices,omitempty"`
+	// AllocatedGPUUUIDs are the GPUs the device plugin assigned to the pod.
+	AllocatedGPUUUIDs []string `json:"allocated_gpu_uuids,omitempty"`
+}
+
+// ResolveProcessOwners joins resolved process cgroups with the pods
+// running on nodeName. The result is keyed by PID. Processes whose pod is
+// not found on the node are returned with only the cgroup identifiers set.
+func (h *PodGPUAllocationHandler) ResolveProcessOwners(
+	ctx context.Context,
+	nodeName string,
+	cgroups []*ProcessCgroup,
+) (map[int]*ProcessOwner, error) {
+	owners := make(map[int]*ProcessOwner, len(cgroups))
+	for _, cg := range cgroups {
+		owners[cg.PID] = &ProcessOwner{
+			PodUID:      cg.PodUID,
+			ContainerID: cg.ContainerID,
+		}
+	}
+	if len(cgroups) == 0 {
+		return owners, nil
+	}
+
+	if h.clientset == nil {
+		return owners, fmt.Errorf(
+			"K8s client not configured - pod names cannot be resolved")
+	}
+	if nodeName == "" {
+		return owners, fmt.Errorf("agent node name unknown - " +
+			"set NODE_NAME via the downward API to resolve pod names")
+	}
+
+	pods, err := h.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		return owners, fmt.Errorf("failed to list pods on node %s: %w",
+			nodeName, err)
+	}
+
+	podsByUID := make(map[string]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// Client-side node filter (FieldSelector backup for fake clients)
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		podsByUID[string(pod.UID)] = pod
+	}
+
+	for _, cg := range cgroups {
+		pod, ok := podsByUID[cg.PodUID]
+		if !ok {
+			continue
+		}
+
+		owner := owners[cg.PID]
+		owner.PodName = pod.Name
+		owner.PodNamespace = pod.Namespace
+		owner.ContainerName = containerNameForID(pod, cg.ContainerID)
+
+		if allocation := h.extractGPUAllocation(pod); allocation != nil {
+			for _, container := range allocation.Containers {
+				if container.Name == owner.ContainerName {
+					owner.GPURequest = container.GPURequest
+					owner.MIGDevices = container.MIGDevices
+				}
+			}
+		}
+		if uuids, ok := pod.Annotations[gpuDeviceAnnotation]; ok && uuids != "" {
+			owner.AllocatedGPUUUIDs = strings.Split(uuids, ",")
+		}
+	}
+
+	return owners, nil
+}
+
+// containerNameForID returns the name of the pod container with the given
+// runtime ID. Container statuses report IDs as "<runtime>://<id>".
+func containerNameForID(pod *corev1.Pod, containerID string) string {
+	if containerID == "" {
+		return ""
+	}
+
+	statuses := make([]corev1.ContainerStatus, 0,
+		len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		id := status.ContainerID
+		if i := strings.Index(id, "://"); i >= 0 {
+			id = id[i+3:]
+		}
+		if id == containerID {
+			return status.Name
+		}
+	}
+	return ""
+}
+
 // GetPodGPUAllocationTool returns the MCP tool definition.
 func GetPodGPUAllocationTool() mcp.Tool {
 	return mcp.NewTool("get_pod_gpu_allocation",
 		mcp.WithDescription(
 			"Shows GPU allocation for pods on a specific node. "+
-				"Returns pods with nvidia.com/gpu resource requests, "+
+				"Returns pods with nvidia.com/gpu or nvidia.com/mig-* "+
+				"resource requests, "+
 				"including GPU UUIDs assigned to each container via "+
 				"the NVIDIA device plugin annotations.",
 		),
@@ -10,12 +10,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/k8s"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -36,11 +38,11 @@ func makePodWithGPU(name, namespace, nodeName string, gpuCount int64) corev1.Pod
 					Name: "main",
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
-							nvidiaGPUResource: resource.MustParse(
+							k8s.GPUResourceName: resource.MustParse(
 								fmt.Sprintf("%d", gpuCount)),
 						},
 						Limits: corev1.ResourceList{
-							nvidiaGPUResource: resource.MustParse(
+							k8s.GPUResourceName: resource.MustParse(
 								fmt.Sprintf("%d", gpuCount)),
 						},
 					},
@@ -241,6 +243,47 @@ func TestPodGPUAllocationHandler_NamespaceFilter(t *testing.T) {
 	}
 }
 
+// fakeGPUPodLister serves pods from memory and records its arguments.
+type fakeGPUPodLister struct {
+	pods                []corev1.Pod
+	namespace, nodeName string
+}
+
+func (l *fakeGPUPodLister) ListGPUPodsOnNode(
+	ctx context.Context,
+	namespace, nodeName string,
+) ([]corev1.Pod, error) {
+	l.namespace, l.nodeName = namespace, nodeName
+	return l.pods, nil
+}
+
+func TestPodGPUAllocationHandler_WithLister(t *testing.T) {
+	lister := &fakeGPUPodLister{pods: []corev1.Pod{
+		makePodWithGPU("job-1", "ns1", "gpu-node-1", 2),
+	}}
+	handler := NewPodGPUAllocationHandlerWithLister(nil, lister)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"node_name": "gpu-node-1",
+		"namespace": "ns1",
+	}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+	var response PodGPUAllocationResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	assert.Equal(t, "ns1", lister.namespace)
+	assert.Equal(t, "gpu-node-1", lister.nodeName)
+	assert.Equal(t, 1, response.Summary.TotalPods)
+	assert.Equal(t, int64(2), response.Summary.TotalGPUsAllocated)
+}
+
 func TestPodGPUAllocationHandler_GPUUUIDs(t *testing.T) {
 	pod := makePodWithGPU("gpu-job", "ml", "gpu-node-1", 2)
 
@@ -325,10 +368,10 @@ func TestPodGPUAllocationHandler_MultipleContainers(t *testing.T) {
 					Name: "trainer",
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
-							nvidiaGPUResource: resource.MustParse("2"),
+							k8s.GPUResourceName: resource.MustParse("2"),
 						},
 						Limits: corev1.ResourceList{
-							nvidiaGPUResource: resource.MustParse("2"),
+							k8s.GPUResourceName: resource.MustParse("2"),
 						},
 					},
 				},
@@ -344,10 +387,10 @@ func TestPodGPUAllocationHandler_MultipleContainers(t *testing.T) {
 					Name: "validator",
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
-							nvidiaGPUResource: resource.MustParse("1"),
+							k8s.GPUResourceName: resource.MustParse("1"),
 						},
 						Limits: corev1.ResourceList{
-							nvidiaGPUResource: resource.MustParse("1"),
+							k8s.GPUResourceName: resource.MustParse("1"),
 						},
 					},
 				},
@@ -389,6 +432,78 @@ func TestPodGPUAllocationHandler_MultipleContainers(t *testing.T) {
 	assert.Equal(t, int64(3), response.Summary.TotalGPUsAllocated)
 }
 
+func TestPodGPUAllocationHandler_MIGResources(t *testing.T) {
+	// Mixed MIG strategy: containers request nvidia.com/mig-<profile>
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mig-inference",
+			Namespace: "ml",
+			Annotations: map[string]string{
+				gpuDeviceAnnotation: "MIG-aaaa,MIG-bbbb,MIG-cccc",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "gpu-node-1",
+			Containers: []corev1.Container{
+				{
+					Name: "small",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							"nvidia.com/mig-1g.5gb": resource.MustParse("2"),
+						},
+					},
+				},
+				{
+					Name: "large",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							"nvidia.com/mig-3g.20gb": resource.MustParse("1"),
+						},
+						Limits: corev1.ResourceList{
+							"nvidia.com/mig-3g.20gb": resource.MustParse("1"),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	//nolint:staticcheck // NewSimpleClientset used for testing
+	clientset := fake.NewSimpleClientset(&pod)
+	handler := NewPodGPUAllocationHandler(clientset)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"node_name": "gpu-node-1",
+	}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+
+	var response PodGPUAllocationResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	require.Len(t, response.Pods, 1)
+	containers := response.Pods[0].Containers
+	require.Len(t, containers, 2)
+	assert.Equal(t, map[string]int64{"1g.5gb": 2}, containers[0].MIGDevices)
+	assert.Equal(t, int64(0), containers[0].GPURequest)
+	assert.Equal(t, []string{"MIG-aaaa", "MIG-bbbb", "MIG-cccc"},
+		containers[0].GPUUUIDs)
+	assert.Equal(t, map[string]int64{"3g.20gb": 1}, containers[1].MIGDevices)
+
+	assert.Equal(t, int64(0), response.Summary.TotalGPUsAllocated)
+	assert.Equal(t, int64(3), response.Summary.TotalMIGDevicesAllocated)
+	assert.Equal(t, map[string]int64{"1g.5gb": 2, "3g.20gb": 1},
+		response.Summary.MIGDevicesByProfile)
+}
+
 func TestGetPodGPUAllocationTool(t *testing.T) {
 	tool := GetPodGPUAllocationTool()
 
@@ -397,3 +512,45 @@ func TestGetPodGPUAllocationTool(t *testing.T) {
 	assert.Contains(t, tool.Description, "GPU allocation")
 	assert.Contains(t, tool.Description, "nvidia.com/gpu")
 }
+
+func TestPodGPUAllocationHandler_ResolveProcessOwners(t *testing.T) {
+	// Multi-container pod: sidecar without GPUs shares the pod with trainer
+	pod := makePodWithGPU("training-job", "ml", "gpu-node-1", 2)
+	pod.UID = types.UID(testPodUID)
+	pod.Spec.Containers = append(pod.Spec.Containers,
+		corev1.Container{Name: "sidecar"})
+	sidecarID := strings.Repeat("ab", 32)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "main", ContainerID: "containerd://" + testContainerID},
+		{Name: "sidecar", ContainerID: "cri-o://" + sidecarID},
+	}
+	other := makePodWithGPU("remote", "ml", "gpu-node-2", 1)
+	other.UID = types.UID("11111111-2222-3333-4444-555555555555")
+
+	//nolint:staticcheck // NewSimpleClientset used for testing
+	clientset := fake.NewSimpleClientset(&pod, &other)
+	handler := NewPodGPUAllocationHandler(clientset)
+
+	owners, err := handler.ResolveProcessOwners(context.Background(),
+		"gpu-node-1", []*ProcessCgroup{
+			{PID: 1, PodUID: testPodUID, ContainerID: testContainerID},
+			{PID: 2, PodUID: testPodUID, ContainerID: sidecarID},
+			{PID: 3, PodUID: string(other.UID)},
+		})
+	require.NoError(t, err)
+	require.Len(t, owners, 3)
+
+	assert.Equal(t, "training-job", owners[1].PodName)
+	assert.Equal(t, "main", owners[1].ContainerName)
+	assert.Equal(t, int64(2), owners[1].GPURequest)
+	assert.Equal(t, []string{"GPU-uuid-1", "GPU-uuid-2"},
+		owners[1].AllocatedGPUUUIDs)
+
+	// Process in a container that did not request GPUs
+	assert.Equal(t, "sidecar", owners[2].ContainerName)
+	assert.Equal(t, int64(0), owners[2].GPURequest)
+
+	// Pod on another node keeps only the cgroup identifiers
+	assert.Empty(t, owners[3].PodName)
+	assert.Equal(t, string(other.UID), owners[3].PodUID)
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/klog/v2"
+)
+
+// XIDCatalogHandler handles the get_xid_catalog tool.
+type XIDCatalogHandler struct {
+	catalog func() *xid.Catalog
+}
+
+// NewXIDCatalogHandler creates a handler reporting the active XID catalog.
+func NewXIDCatalogHandler() *XIDCatalogHandler {
+	return &XIDCatalogHandler{catalog: xid.ActiveCatalog}
+}
+
+// XIDCatalogResponse is the response for get_xid_catalog.
+type XIDCatalogResponse struct {
+	Status         string            `json:"status"`
+	OverlayVersion string            `json:"overlay_version,omitempty"`
+	OverlaySource  string            `json:"overlay_source,omitempty"`
+	DriverVersion  string            `json:"driver_version,omitempty"`
+	EntriesApplied int               `json:"overlay_entries_applied"`
+	EntriesSkipped int               `json:"overlay_entries_skipped"`
+	XIDs           []xid.CatalogCode `json:"xids"`
+	SXids          []xid.CatalogCode `json:"sxids"`
+}
+
+// Handle processes the get_xid_catalog tool request.
+func (h *XIDCatalogHandler) Handle(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	klog.InfoS("get_xid_catalog invoked")
+
+	if err := ctx.Err(); err != nil {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("operation cancelled: %s", err)), nil
+	}
+
+	args := request.GetArguments()
+	var codes []int
+	if v, ok := args["codes"].([]interface{}); ok {
+		for _, raw := range v {
+			code, ok := raw.(float64)
+			if !ok || code <= 0 || code != float64(int(code)) {
+				return mcp.NewToolResultError(
+					"invalid codes: must be positive integers"), nil
+			}
+			codes = append(codes, int(code))
+		}
+	}
+	overlayOnly := false
+	if v, ok := args["overlay_only"].(bool); ok {
+		overlayOnly = v
+	}
+
+	// drop reports whether a code is filtered out
+	drop := func(code xid.CatalogCode) bool {
+		if len(codes) > 0 && !slices.Contains(codes, code.Code) {
+			return true
+		}
+		return overlayOnly && code.Origin == xid.OriginBuiltin
+	}
+
+	catalog := h.catalog()
+	response := XIDCatalogResponse{
+		Status:         "success",
+		OverlayVersion: catalog.OverlayVersion,
+		OverlaySource:  catalog.OverlaySource,
+		DriverVersion:  catalog.DriverVersion,
+		EntriesApplied: catalog.EntriesApplied,
+		EntriesSkipped: catalog.EntriesSkipped,
+		XIDs:           slices.DeleteFunc(catalog.XIDs(), drop),
+		SXids:          slices.DeleteFunc(catalog.SXids(), drop),
+	}
+
+	klog.InfoS("get_xid_catalog completed",
+		"overlayVersion", response.OverlayVersion,
+		"xids", len(response.XIDs),
+		"sxids", len(response.SXids))
+
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		klog.ErrorS(err, "failed to marshal response")
+		return mcp.NewToolResultError(
+			fmt.Sprintf("failed to marshal response: %s", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// GetXIDCatalogTool returns the MCP tool definition for get_xid_catalog.
+func GetXIDCatalogTool() mcp.Tool {
+	return mcp.NewTool("get_xid_catalog",
+		mcp.WithDescription(
+			"Returns the XID and NVSwitch SXid catalog that "+
+				"analyze_xid_errors classifies errors with: name, severity, "+
+				"category and SRE action of each code, and whether it is "+
+				"built in or set by the operator's overlay catalog "+
+				"(origin builtin, overlay or added). Reports the overlay "+
+				"version and the driver version that selected its entries. "+
+				"Use to check how a code is classified on a node or that a "+
+				"catalog change was rolled out.",
+		),
+		mcp.WithArray("codes",
+			mcp.Description("Only these XID or SXid codes (e.g., [48, 79])"),
+			mcp.WithNumberItems(),
+		),
+		mcp.WithBoolean("overlay_only",
+			mcp.Description(
+				"Only include codes the overlay changed or added "+
+					"(default: false)",
+			),
+		),
+		withNodeTargeting(),
+		withCallBudget(),
+	)
+}
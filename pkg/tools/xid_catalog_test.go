@@ -0,0 +1,143 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCatalogOverlay lowers XID 13 and adds XID 154 for recent drivers.
+var testCatalogOverlay = &xid.CatalogOverlay{
+	Version: "v2",
+	XIDs: []xid.CatalogEntry{
+		{ErrorInfo: xid.ErrorInfo{
+			Code: 13, Severity: "warning", Action: "See runbook GPU-013.",
+		}},
+		{
+			ErrorInfo: xid.ErrorInfo{
+				Code: 154, Name: "GPU Recovery Action Changed",
+				Severity: "critical", Action: "Follow the recovery action.",
+				Category: "hardware",
+			},
+			MinDriverVersion: "570",
+		},
+	},
+}
+
+func TestXIDCatalogHandler_Handle(t *testing.T) {
+	catalog := xid.NewCatalog(testCatalogOverlay, "/etc/xid/catalog.yaml",
+		"575.57.08")
+	handler := &XIDCatalogHandler{catalog: func() *xid.Catalog { return catalog }}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantXIDs  []int
+		wantSXids bool
+	}{
+		{
+			name:      "full catalog",
+			args:      map[string]interface{}{},
+			wantSXids: true,
+		},
+		{
+			name:     "overlay only",
+			args:     map[string]interface{}{"overlay_only": true},
+			wantXIDs: []int{13, 154},
+		},
+		{
+			name: "codes",
+			args: map[string]interface{}{
+				"codes": []interface{}{float64(79), float64(154)},
+			},
+			wantXIDs: []int{79, 154},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = tt.args
+
+			result, err := handler.Handle(context.Background(), request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response XIDCatalogResponse
+			textContent, ok := mcp.AsTextContent(result.Content[0])
+			require.True(t, ok)
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			assert.Equal(t, "success", response.Status)
+			assert.Equal(t, "v2", response.OverlayVersion)
+			assert.Equal(t, "/etc/xid/catalog.yaml", response.OverlaySource)
+			assert.Equal(t, "575.57.08", response.DriverVersion)
+			assert.Equal(t, 2, response.EntriesApplied)
+			assert.Equal(t, tt.wantSXids, len(response.SXids) > 0)
+
+			if tt.wantXIDs == nil {
+				assert.Len(t, response.XIDs, len(xid.ErrorCodes)+1)
+				return
+			}
+			codes := make([]int, 0, len(response.XIDs))
+			for _, code := range response.XIDs {
+				codes = append(codes, code.Code)
+			}
+			assert.Equal(t, tt.wantXIDs, codes)
+		})
+	}
+}
+
+func TestXIDCatalogHandler_Handle_InvalidCodes(t *testing.T) {
+	handler := NewXIDCatalogHandler()
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"codes": []interface{}{"79"},
+	}
+
+	result, err := handler.Handle(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestAnalyzeXIDHandler_enrichEvents_CatalogOverlay(t *testing.T) {
+	t.Cleanup(func() { xid.SetCatalog(nil) })
+	xid.SetCatalog(xid.NewCatalog(testCatalogOverlay, "", "575.57.08"))
+
+	handler := NewAnalyzeXIDHandler(nvml.NewMock(1))
+	enriched, err := handler.enrichEvents(context.Background(), []xid.XIDEvent{
+		{XIDCode: 13, PCIBusID: "0000:01:00.0"},
+		{XIDCode: 154, PCIBusID: "0000:01:00.0"},
+	})
+	require.NoError(t, err)
+	require.Len(t, enriched, 2)
+
+	assert.Equal(t, "warning", enriched[0].Severity)
+	assert.Equal(t, "See runbook GPU-013.", enriched[0].SREAction)
+	assert.Equal(t, "Graphics Exception", enriched[0].Name)
+	assert.Equal(t, "GPU Recovery Action Changed", enriched[1].Name)
+	assert.Equal(t, "critical", enriched[1].Severity)
+}
+
+func TestGetXIDCatalogTool(t *testing.T) {
+	tool := GetXIDCatalogTool()
+
+	assert.Equal(t, "get_xid_catalog", tool.Name)
+	assert.Contains(t, tool.Description, "overlay")
+	for _, arg := range []string{
+		"codes", "overlay_only", "node_name", "node_selector", "gpu_model",
+		"timeout_seconds",
+	} {
+		assert.Contains(t, tool.InputSchema.Properties, arg)
+	}
+	assert.Empty(t, tool.InputSchema.Required)
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxXIDLimit caps the limit argument of analyze_xid_errors.
+const maxXIDLimit = 1000
+
+// XIDFilter selects the XID errors reported by analyze_xid_errors. Zero
+// fields do not filter.
+type XIDFilter struct {
+	// Since drops errors logged before it, and errors without a timestamp
+	Since *time.Time `json:"since,omitempty"`
+	// TimeRange is the time_range argument Since was derived from
+	TimeRange   string `json:"time_range,omitempty"`
+	GPUUUID     string `json:"gpu_uuid,omitempty"`
+	GPUIndex    *int   `json:"gpu_index,omitempty"`
+	MinSeverity string `json:"min_severity,omitempty"`
+	XIDCodes    []int  `json:"xid_codes,omitempty"`
+	// Limit keeps only the most recent errors; 0 keeps all
+	Limit int `json:"limit,omitempty"`
+}
+
+// parseXIDFilter reads the filter arguments of an analyze_xid_errors call.
+// time_range is relative to now.
+func parseXIDFilter(args map[string]interface{}, now time.Time) (XIDFilter, error) {
+	var filter XIDFilter
+
+	since, hasSince := args["since"].(string)
+	timeRange, hasRange := args["time_range"].(string)
+	switch {
+	case hasSince && hasRange:
+		return filter, fmt.Errorf("set either since or time_range, not both")
+	case hasSince:
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(since))
+		if err != nil {
+			return filter, fmt.Errorf(
+				"invalid since %q: must be an RFC 3339 time", since)
+		}
+		filter.Since = &t
+	case hasRange:
+		d, err := parseTimeRange(timeRange)
+		if err != nil {
+			return filter, err
+		}
+		t := now.Add(-d).UTC()
+		filter.Since = &t
+		filter.TimeRange = strings.TrimSpace(timeRange)
+	}
+
+	if v, ok := args["gpu_uuid"].(string); ok {
+		filter.GPUUUID = strings.TrimSpace(v)
+	}
+	if v, ok := args["gpu_index"].(float64); ok {
+		if v < 0 || v != float64(int(v)) {
+			return filter, fmt.Errorf(
+				"invalid gpu_index: must be a non-negative integer")
+		}
+		index := int(v)
+		filter.GPUIndex = &index
+	}
+
+	if v, ok := args["min_severity"].(string); ok {
+		severity := strings.ToLower(strings.TrimSpace(v))
+		if xid.SeverityRank(severity) < 0 {
+			return filter, fmt.Errorf("invalid min_severity %q: must be one of %s",
+				v, strings.Join(xid.Severities, ", "))
+		}
+		filter.MinSeverity = severity
+	}
+
+	if v, ok := args["xid_codes"].([]interface{}); ok {
+		for _, c := range v {
+			code, ok := c.(float64)
+			if !ok || code <= 0 || code != float64(int(code)) {
+				return filter, fmt.Errorf(
+					"invalid xid_codes: must be positive integers")
+			}
+			filter.XIDCodes = append(filter.XIDCodes, int(code))
+		}
+	}
+
+	if v, ok := args["limit"].(float64); ok {
+		if v < 1 || v > maxXIDLimit || v != float64(int(v)) {
+			return filter, fmt.Errorf(
+				"invalid limit: must be an integer between 1 and %d", maxXIDLimit)
+		}
+		filter.Limit = int(v)
+	}
+
+	return filter, nil
+}
+
+// parseTimeRange parses a time range such as "90m", "24h" or "7d".
+func parseTimeRange(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf(
+			"invalid time_range %q: use a duration such as 1h, 24h or 7d", s)
+	}
+	return d, nil
+}
+
+// active reports whether the filter drops any error.
+func (f XIDFilter) active() bool {
+	return f.Since != nil || f.GPUUUID != "" || f.GPUIndex != nil ||
+		f.MinSeverity != "" || len(f.XIDCodes) > 0 || f.Limit > 0
+}
+
+// matchEvent reports whether a parsed event passes the time and code
+// filters, which need no GPU lookup.
+func (f XIDFilter) matchEvent(event xid.XIDEvent) bool {
+	if f.Since != nil && (event.Timestamp.IsZero() || event.Timestamp.Before(*f.Since)) {
+		return false
+	}
+	return len(f.XIDCodes) == 0 || slices.Contains(f.XIDCodes, event.XIDCode)
+}
+
+// matchError reports whether an enriched error passes the GPU and severity
+// filters.
+func (f XIDFilter) matchError(e EnrichedXIDError) bool {
+	if f.GPUUUID != "" && !strings.EqualFold(e.GPUUUID, f.GPUUUID) {
+		return false
+	}
+	if f.GPUIndex != nil && e.GPUIndex != *f.GPUIndex {
+		return false
+	}
+	if f.MinSeverity != "" &&
+		xid.SeverityRank(e.Severity) < xid.SeverityRank(f.MinSeverity) {
+		return false
+	}
+	return true
+}
+
+// withXIDFilters adds the filter arguments of analyze_xid_errors.
+func withXIDFilters() mcp.ToolOption {
+	return withOptions(
+		mcp.WithString("since",
+			mcp.Description(
+				"Only errors logged at or after this RFC 3339 time "+
+					"(e.g., 2026-01-15T10:00:00Z). Errors without a "+
+					"timestamp are dropped. Excludes time_range.",
+			),
+		),
+		mcp.WithString("time_range",
+			mcp.Description(
+				"Only errors logged within this period before now "+
+					"(e.g., 1h, 24h, 7d). Excludes since.",
+			),
+		),
+		mcp.WithString("gpu_uuid",
+			mcp.Description("Only errors on the GPU with this UUID"),
+		),
+		mcp.WithNumber("gpu_index",
+			mcp.Description("Only errors on the GPU at this index"),
+			mcp.Min(0),
+		),
+		mcp.WithString("min_severity",
+			mcp.Description("Only errors of at least this severity"),
+			mcp.Enum(xid.Severities...),
+		),
+		mcp.WithArray("xid_codes",
+			mcp.Description("Only these XID codes (e.g., [48, 79])"),
+			mcp.WithNumberItems(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(
+				"Return at most this many errors, the most recent ones. "+
+					"Counts and summary cover all matching errors.",
+			),
+			mcp.Min(1),
+			mcp.Max(maxXIDLimit),
+		),
+	)
+}
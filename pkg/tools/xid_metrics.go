@@ -0,0 +1,55 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/metrics"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
+	"k8s.io/klog/v2"
+)
+
+// XIDMetricsRecorder exports XID events as Prometheus metrics. Events are
+// mapped to GPUs and classified exactly as analyze_xid_errors reports them,
+// so that alerts and analysis agree.
+type XIDMetricsRecorder struct {
+	handler *AnalyzeXIDHandler
+	now     func() time.Time
+}
+
+// NewXIDMetricsRecorder creates a recorder that looks up GPUs with
+// nvmlClient.
+func NewXIDMetricsRecorder(nvmlClient nvml.Interface) *XIDMetricsRecorder {
+	return &XIDMetricsRecorder{
+		handler: &AnalyzeXIDHandler{nvmlClient: nvmlClient},
+		now:     time.Now,
+	}
+}
+
+// Record counts an XID event in gpu_xid_errors_total and updates its
+// last-seen time, the time of the event or, if unknown, now. SXid and NVRM
+// events have no XID and are not counted. It has the signature of
+// xid.Follower event handlers.
+func (r *XIDMetricsRecorder) Record(event xid.XIDEvent) {
+	if event.Kind != "" && event.Kind != xid.KindXID {
+		return
+	}
+	enriched, err := r.handler.enrichEvents(context.Background(),
+		[]xid.XIDEvent{event})
+	if err != nil || len(enriched) == 0 {
+		klog.ErrorS(err, "failed to classify XID event", "xid", event.XIDCode)
+		return
+	}
+	e := enriched[0]
+
+	at := event.Timestamp
+	if at.IsZero() {
+		at = r.now()
+	}
+	metrics.RecordXIDError(e.GPUUUID, e.PCIBusID, e.XIDCode, e.Severity,
+		e.Category, at)
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/metrics"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/nvml"
+	"github.com/ArangoGutierrez/k8s-gpu-mcp-server/pkg/xid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXIDMetricsRecorder_Record(t *testing.T) {
+	metrics.XIDErrorsTotal.Reset()
+	metrics.XIDLastSeen.Reset()
+
+	nvmlClient := nvml.NewMock(1)
+	device, err := nvmlClient.GetDeviceByIndex(context.Background(), 0)
+	require.NoError(t, err)
+	uuid, err := device.GetUUID(context.Background())
+	require.NoError(t, err)
+
+	now := time.Unix(1768471200, 0)
+	recorder := NewXIDMetricsRecorder(nvmlClient)
+	recorder.now = func() time.Time { return now }
+
+	logged := now.Add(-time.Hour)
+	recorder.Record(xid.XIDEvent{
+		Timestamp: logged, XIDCode: 79, PCIBusID: "0000:01:00.0", GPUIndex: -1,
+	})
+	recorder.Record(xid.XIDEvent{XIDCode: 79, PCIBusID: "0000:01:00.0", GPUIndex: -1})
+	// A GPU NVML does not know about
+	recorder.Record(xid.XIDEvent{XIDCode: 31, PCIBusID: "0000:09:00.0", GPUIndex: -1})
+
+	// The labels match what analyze_xid_errors reports
+	fallen := metrics.XIDErrorsTotal.WithLabelValues(
+		uuid, "0000:01:00.0", "79", "fatal", "hardware")
+	assert.Equal(t, 2.0, testutil.ToFloat64(fallen))
+	assert.Equal(t, float64(now.Unix()), testutil.ToFloat64(
+		metrics.XIDLastSeen.WithLabelValues(
+			uuid, "0000:01:00.0", "79", "fatal", "hardware")),
+		"an event without timestamp was seen now")
+
+	info := xid.LookupOrUnknown(31)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.XIDErrorsTotal.WithLabelValues(
+		"unknown", "0000:09:00.0", "31", info.Severity, info.Category)))
+}
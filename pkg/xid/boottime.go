@@ -0,0 +1,61 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// procStatPath is the kernel statistics file holding the boot time.
+	procStatPath = "/proc/stat"
+
+	// bootIDPath holds a random ID the kernel generates at every boot.
+	bootIDPath = "/proc/sys/kernel/random/boot_id"
+)
+
+// readBootTime returns the wall-clock time the host booted, from the btime
+// line of /proc/stat. Kernel log timestamps are relative to it.
+func readBootTime(path string) (time.Time, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "btime ")
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid btime in %s: %w", path, err)
+		}
+		return time.Unix(seconds, 0), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return time.Time{}, fmt.Errorf("no btime in %s", path)
+}
+
+// readBootID returns the ID of the current boot of the host.
+func readBootID(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read boot ID: %w", err)
+	}
+	id := strings.TrimSpace(string(data))
+	if id == "" {
+		return "", fmt.Errorf("empty boot ID in %s", path)
+	}
+	return id, nil
+}
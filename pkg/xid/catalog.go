@@ -0,0 +1,324 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Origins of the codes in a Catalog.
+const (
+	OriginBuiltin  = "builtin"
+	OriginOverlay  = "overlay"
+	OriginAddition = "added"
+)
+
+// CatalogOverlay changes and extends the built-in XID and SXid tables
+// without a rebuild, e.g. to follow the severities and actions of local
+// runbooks or to add the codes of a newer driver branch.
+type CatalogOverlay struct {
+	// Version identifies the overlay; it is reported with the effective
+	// catalog
+	Version string `json:"version,omitempty"`
+	// XIDs and SXids are applied in order, so a later entry for the same
+	// code overrides the fields an earlier one set
+	XIDs  []CatalogEntry `json:"xids,omitempty"`
+	SXids []CatalogEntry `json:"sxids,omitempty"`
+}
+
+// CatalogEntry overrides the metadata of one code. Fields left empty keep
+// their built-in value; a code missing from the built-in table needs a
+// name, severity, action and category.
+type CatalogEntry struct {
+	ErrorInfo
+	// MinDriverVersion and MaxDriverVersion restrict the entry to a range
+	// of driver versions. Both bounds are inclusive and match every
+	// release they are a prefix of: a maximum of "550" matches 550.54.15.
+	MinDriverVersion string `json:"min_driver_version,omitempty"`
+	MaxDriverVersion string `json:"max_driver_version,omitempty"`
+}
+
+// LoadCatalogOverlay reads a catalog overlay from a YAML or JSON file and
+// validates it. Unknown fields are rejected so typos do not silently do
+// nothing.
+func LoadCatalogOverlay(path string) (*CatalogOverlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XID catalog: %w", err)
+	}
+
+	var overlay CatalogOverlay
+	if err := yaml.UnmarshalStrict(data, &overlay); err != nil {
+		return nil, fmt.Errorf("failed to parse XID catalog %s: %w", path, err)
+	}
+	if err := overlay.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid XID catalog %s: %w", path, err)
+	}
+	return &overlay, nil
+}
+
+// Validate checks every entry of the overlay.
+func (o *CatalogOverlay) Validate() error {
+	for i, entry := range o.XIDs {
+		if err := entry.validate(ErrorCodes); err != nil {
+			return fmt.Errorf("xids[%d]: %w", i, err)
+		}
+	}
+	for i, entry := range o.SXids {
+		if err := entry.validate(SXidCodes); err != nil {
+			return fmt.Errorf("sxids[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validate checks an entry that applies to the codes of builtin.
+func (e *CatalogEntry) validate(builtin map[int]ErrorInfo) error {
+	if e.Code <= 0 {
+		return fmt.Errorf("invalid code %d", e.Code)
+	}
+	if e.Severity != "" && SeverityRank(e.Severity) < 0 {
+		return fmt.Errorf("code %d: invalid severity %q "+
+			"(must be info, warning, critical or fatal)", e.Code, e.Severity)
+	}
+	if _, exists := builtin[e.Code]; !exists {
+		var missing []string
+		for field, value := range map[string]string{
+			"name": e.Name, "severity": e.Severity,
+			"sre_action": e.Action, "category": e.Category,
+		} {
+			if value == "" {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			slices.Sort(missing)
+			return fmt.Errorf("code %d is not built in and needs %s",
+				e.Code, strings.Join(missing, ", "))
+		}
+	}
+
+	var bounds [2][]int
+	for i, bound := range []string{e.MinDriverVersion, e.MaxDriverVersion} {
+		if bound == "" {
+			continue
+		}
+		parsed, err := parseDriverVersion(bound)
+		if err != nil {
+			return fmt.Errorf("code %d: %w", e.Code, err)
+		}
+		bounds[i] = parsed
+	}
+	if bounds[0] != nil && bounds[1] != nil &&
+		compareDriverVersion(bounds[0], bounds[1]) > 0 {
+		return fmt.Errorf("code %d: min_driver_version %s is above "+
+			"max_driver_version %s", e.Code, e.MinDriverVersion,
+			e.MaxDriverVersion)
+	}
+	return nil
+}
+
+// matches reports whether the entry applies to a driver version. Entries
+// with a driver range do not apply when the version is unknown.
+func (e *CatalogEntry) matches(driverVersion []int) bool {
+	if e.MinDriverVersion == "" && e.MaxDriverVersion == "" {
+		return true
+	}
+	if driverVersion == nil {
+		return false
+	}
+	// Bounds were checked by validate
+	if minimum, err := parseDriverVersion(e.MinDriverVersion); err == nil &&
+		compareDriverVersion(driverVersion, minimum) < 0 {
+		return false
+	}
+	if maximum, err := parseDriverVersion(e.MaxDriverVersion); err == nil &&
+		compareDriverVersion(driverVersion, maximum) > 0 {
+		return false
+	}
+	return true
+}
+
+// apply returns info with the fields the entry sets replaced.
+func (e *CatalogEntry) apply(info ErrorInfo) ErrorInfo {
+	info.Code = e.Code
+	info.Name = cmp.Or(e.Name, info.Name)
+	info.Description = cmp.Or(e.Description, info.Description)
+	info.Severity = cmp.Or(e.Severity, info.Severity)
+	info.Action = cmp.Or(e.Action, info.Action)
+	info.Category = cmp.Or(e.Category, info.Category)
+	info.HealthCheck = cmp.Or(e.HealthCheck, info.HealthCheck)
+	return info
+}
+
+// parseDriverVersion splits a driver version such as "550.54.15" into its
+// numeric components.
+func parseDriverVersion(version string) ([]int, error) {
+	fields := strings.Split(version, ".")
+	parsed := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid driver version %q", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+// compareDriverVersion compares a driver version with a bound on the
+// components the bound has, so that the bound matches every release it is
+// a prefix of. Missing components of the version count as 0.
+func compareDriverVersion(version, bound []int) int {
+	for i, b := range bound {
+		var v int
+		if i < len(version) {
+			v = version[i]
+		}
+		if c := cmp.Compare(v, b); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// CatalogCode is a code of the effective catalog and where its metadata
+// comes from: the built-in table, an overlay changing a built-in code, or
+// an overlay adding the code.
+type CatalogCode struct {
+	ErrorInfo
+	Origin string `json:"origin"`
+}
+
+// Catalog is the effective error catalog of a node: the built-in XID and
+// SXid tables with the overlay entries for its driver version applied.
+type Catalog struct {
+	// OverlayVersion and OverlaySource identify the overlay applied, if any
+	OverlayVersion string `json:"overlay_version,omitempty"`
+	OverlaySource  string `json:"overlay_source,omitempty"`
+	// DriverVersion selected the overlay entries
+	DriverVersion string `json:"driver_version,omitempty"`
+	// EntriesApplied and EntriesSkipped count the overlay entries that
+	// did and did not match the driver version
+	EntriesApplied int `json:"overlay_entries_applied"`
+	EntriesSkipped int `json:"overlay_entries_skipped"`
+
+	xids  map[int]CatalogCode
+	sxids map[int]CatalogCode
+}
+
+// NewCatalog merges an overlay, which may be nil, read from source into
+// the built-in tables for a driver version. An unknown driver version,
+// "", skips the overlay entries with a driver range.
+func NewCatalog(overlay *CatalogOverlay, source, driverVersion string) *Catalog {
+	c := &Catalog{
+		DriverVersion: driverVersion,
+		xids:          builtinCodes(ErrorCodes),
+		sxids:         builtinCodes(SXidCodes),
+	}
+	if overlay == nil {
+		return c
+	}
+	c.OverlayVersion = overlay.Version
+	c.OverlaySource = source
+
+	// An unparsable driver version matches no ranged entry
+	version, _ := parseDriverVersion(driverVersion)
+	for _, table := range []struct {
+		entries []CatalogEntry
+		codes   map[int]CatalogCode
+	}{{overlay.XIDs, c.xids}, {overlay.SXids, c.sxids}} {
+		for _, entry := range table.entries {
+			if !entry.matches(version) {
+				c.EntriesSkipped++
+				continue
+			}
+			c.EntriesApplied++
+			code, exists := table.codes[entry.Code]
+			origin := OriginOverlay
+			if !exists || code.Origin == OriginAddition {
+				origin = OriginAddition
+			}
+			table.codes[entry.Code] = CatalogCode{
+				ErrorInfo: entry.apply(code.ErrorInfo),
+				Origin:    origin,
+			}
+		}
+	}
+	return c
+}
+
+// builtinCodes copies a built-in table.
+func builtinCodes(table map[int]ErrorInfo) map[int]CatalogCode {
+	codes := make(map[int]CatalogCode, len(table))
+	for code, info := range table {
+		codes[code] = CatalogCode{ErrorInfo: info, Origin: OriginBuiltin}
+	}
+	return codes
+}
+
+// Lookup returns the ErrorInfo of an XID code, and whether the catalog
+// has it.
+func (c *Catalog) Lookup(code int) (ErrorInfo, bool) {
+	entry, exists := c.xids[code]
+	return entry.ErrorInfo, exists
+}
+
+// LookupSXid returns the ErrorInfo of an SXid code, and whether the
+// catalog has it.
+func (c *Catalog) LookupSXid(code int) (ErrorInfo, bool) {
+	entry, exists := c.sxids[code]
+	return entry.ErrorInfo, exists
+}
+
+// XIDs returns the XID codes of the catalog, sorted by code.
+func (c *Catalog) XIDs() []CatalogCode {
+	return sortedCodes(c.xids)
+}
+
+// SXids returns the SXid codes of the catalog, sorted by code.
+func (c *Catalog) SXids() []CatalogCode {
+	return sortedCodes(c.sxids)
+}
+
+func sortedCodes(codes map[int]CatalogCode) []CatalogCode {
+	sorted := make([]CatalogCode, 0, len(codes))
+	for _, code := range codes {
+		sorted = append(sorted, code)
+	}
+	slices.SortFunc(sorted, func(a, b CatalogCode) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return sorted
+}
+
+var (
+	activeCatalog  atomic.Pointer[Catalog]
+	builtinCatalog = sync.OnceValue(func() *Catalog {
+		return NewCatalog(nil, "", "")
+	})
+)
+
+// SetCatalog makes c the catalog that Lookup, LookupOrUnknown, LookupSXid
+// and Classify consult. nil restores the built-in catalog.
+func SetCatalog(c *Catalog) {
+	activeCatalog.Store(c)
+}
+
+// ActiveCatalog returns the catalog in use.
+func ActiveCatalog() *Catalog {
+	if c := activeCatalog.Load(); c != nil {
+		return c
+	}
+	return builtinCatalog()
+}
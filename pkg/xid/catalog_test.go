@@ -0,0 +1,250 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOverlay = `version: "2026-10-runbooks"
+xids:
+- code: 13
+  severity: warning
+  sre_action: Page the ML platform on-call; see runbook GPU-013.
+- code: 79
+  sre_action: Open a hardware ticket with the node name.
+  max_driver_version: "550"
+- code: 154
+  name: GPU Recovery Action Changed
+  description: Driver reports the recovery action the GPU needs
+  severity: critical
+  sre_action: Follow the recovery action in the message.
+  category: hardware
+  min_driver_version: "570"
+sxids:
+- code: 12028
+  severity: info
+`
+
+func writeOverlay(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "xid-catalog.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadCatalogOverlay(t *testing.T) {
+	overlay, err := LoadCatalogOverlay(writeOverlay(t, testOverlay))
+	require.NoError(t, err)
+
+	assert.Equal(t, "2026-10-runbooks", overlay.Version)
+	require.Len(t, overlay.XIDs, 3)
+	assert.Equal(t, 79, overlay.XIDs[1].Code)
+	assert.Equal(t, "550", overlay.XIDs[1].MaxDriverVersion)
+	require.Len(t, overlay.SXids, 1)
+}
+
+func TestLoadCatalogOverlay_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "xids:\n- code: 13\n  severty: fatal\n",
+			wantErr: "unknown field",
+		},
+		{
+			name:    "invalid code",
+			content: "xids:\n- code: 0\n  severity: fatal\n",
+			wantErr: "xids[0]: invalid code 0",
+		},
+		{
+			name:    "invalid severity",
+			content: "xids:\n- code: 13\n  severity: urgent\n",
+			wantErr: `invalid severity "urgent"`,
+		},
+		{
+			name:    "incomplete new code",
+			content: "sxids:\n- code: 99999\n  severity: fatal\n",
+			wantErr: "sxids[0]: code 99999 is not built in and needs " +
+				"category, name, sre_action",
+		},
+		{
+			name:    "invalid driver version",
+			content: "xids:\n- code: 13\n  min_driver_version: r550\n",
+			wantErr: `invalid driver version "r550"`,
+		},
+		{
+			name: "empty driver range",
+			content: "xids:\n- code: 13\n  min_driver_version: \"560\"\n" +
+				"  max_driver_version: \"550.54\"\n",
+			wantErr: "min_driver_version 560 is above max_driver_version 550.54",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadCatalogOverlay(writeOverlay(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := LoadCatalogOverlay(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestNewCatalog(t *testing.T) {
+	overlay, err := LoadCatalogOverlay(writeOverlay(t, testOverlay))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		driverVersion string
+		wantApplied   int
+		want79Action  bool
+		want154       bool
+	}{
+		{
+			name:          "old driver",
+			driverVersion: "550.54.15",
+			wantApplied:   3,
+			want79Action:  true,
+		},
+		{
+			name:          "new driver",
+			driverVersion: "575.57.08",
+			wantApplied:   3,
+			want154:       true,
+		},
+		{
+			name:          "unknown driver skips ranged entries",
+			driverVersion: "",
+			wantApplied:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCatalog(overlay, "/etc/xid-catalog.yaml", tt.driverVersion)
+			assert.Equal(t, "2026-10-runbooks", c.OverlayVersion)
+			assert.Equal(t, "/etc/xid-catalog.yaml", c.OverlaySource)
+			assert.Equal(t, tt.wantApplied, c.EntriesApplied)
+			assert.Equal(t, 4-tt.wantApplied, c.EntriesSkipped)
+
+			// Fields the overlay does not set keep their built-in value
+			info, exists := c.Lookup(13)
+			require.True(t, exists)
+			assert.Equal(t, "warning", info.Severity)
+			assert.Contains(t, info.Action, "GPU-013")
+			assert.Equal(t, ErrorCodes[13].Name, info.Name)
+
+			info, _ = c.Lookup(79)
+			assert.Equal(t, "fatal", info.Severity)
+			assert.Equal(t, tt.want79Action,
+				info.Action == "Open a hardware ticket with the node name.")
+
+			_, exists = c.Lookup(154)
+			assert.Equal(t, tt.want154, exists)
+
+			info, _ = c.LookupSXid(12028)
+			assert.Equal(t, "info", info.Severity)
+		})
+	}
+
+	// The built-in tables are not modified
+	assert.Equal(t, "critical", ErrorCodes[13].Severity)
+	assert.Equal(t, "warning", SXidCodes[12028].Severity)
+}
+
+func TestCatalog_Origins(t *testing.T) {
+	overlay, err := LoadCatalogOverlay(writeOverlay(t, testOverlay))
+	require.NoError(t, err)
+	c := NewCatalog(overlay, "", "575.57.08")
+
+	origins := make(map[int]string)
+	for _, code := range c.XIDs() {
+		origins[code.Code] = code.Origin
+	}
+	assert.Len(t, origins, len(ErrorCodes)+1)
+	assert.Equal(t, OriginOverlay, origins[13])
+	assert.Equal(t, OriginBuiltin, origins[79])
+	assert.Equal(t, OriginAddition, origins[154])
+	assert.Equal(t, OriginBuiltin, origins[48])
+
+	xids := c.XIDs()
+	for i := 1; i < len(xids); i++ {
+		assert.Less(t, xids[i-1].Code, xids[i].Code, "sorted by code")
+	}
+	assert.Len(t, c.SXids(), len(SXidCodes))
+}
+
+func TestSetCatalog(t *testing.T) {
+	t.Cleanup(func() { SetCatalog(nil) })
+
+	overlay, err := LoadCatalogOverlay(writeOverlay(t, testOverlay))
+	require.NoError(t, err)
+	SetCatalog(NewCatalog(overlay, "", "575.57.08"))
+
+	// Package lookups and classification consult the active catalog
+	info, exists := Lookup(154)
+	require.True(t, exists)
+	assert.Equal(t, "GPU Recovery Action Changed", info.Name)
+	assert.Equal(t, "warning", LookupOrUnknown(13).Severity)
+	assert.Equal(t, "info",
+		Classify(XIDEvent{Kind: KindSXid, XIDCode: 12028}).Severity)
+	assert.Equal(t, "2026-10-runbooks", ActiveCatalog().OverlayVersion)
+
+	SetCatalog(nil)
+	_, exists = Lookup(154)
+	assert.False(t, exists)
+	assert.Equal(t, "critical", LookupOrUnknown(13).Severity)
+	assert.Empty(t, ActiveCatalog().OverlayVersion)
+}
+
+func TestCompareDriverVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		bound   string
+		want    int
+	}{
+		{"550.54.15", "550", 0},
+		{"550.54.15", "550.54.15", 0},
+		{"550.54.15", "550.54.14", 1},
+		{"550.54.15", "550.90", -1},
+		{"535.104.05", "535.104.5", 0},
+		{"575.57.08", "570", 1},
+		{"550", "550.54", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+"_"+tt.bound, func(t *testing.T) {
+			version, err := parseDriverVersion(tt.version)
+			require.NoError(t, err)
+			bound, err := parseDriverVersion(tt.bound)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, compareDriverVersion(version, bound))
+		})
+	}
+}
+
+func TestExampleCatalogOverlays(t *testing.T) {
+	paths, err := filepath.Glob("../../examples/xid-catalogs/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			_, err := LoadCatalogOverlay(path)
+			assert.NoError(t, err)
+		})
+	}
+}
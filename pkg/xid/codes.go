@@ -4,12 +4,16 @@
 // Package xid provides NVIDIA XID error code lookup and classification.
 // XID errors are GPU hardware failures logged by the NVIDIA driver to the
 // kernel ring buffer. They indicate issues like memory corruption, bus
-// failures, or thermal problems.
+// failures, or thermal problems. The package also classifies NVSwitch SXid
+// errors and driver failures logged without an XID number (NVRM events).
 //
 // Reference: https://docs.nvidia.com/deploy/xid-errors/
 package xid
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // ErrorInfo contains metadata about an XID error code.
 type ErrorInfo struct {
@@ -19,11 +23,20 @@ type ErrorInfo struct {
 	Severity    string `json:"severity"` // "info", "warning", "critical", "fatal"
 	Action      string `json:"sre_action"`
 	Category    string `json:"category"` // "hardware", "memory", "thermal", "power", "nvlink"
+	// HealthCheck names the get_gpu_health field that shows the current
+	// state behind the XID, if any.
+	HealthCheck string `json:"health_check,omitempty"`
 }
 
+// eccRepairHealthCheck points memory repair XIDs at the page retirement and
+// row remapping state reported by get_gpu_health.
+const eccRepairHealthCheck = "get_gpu_health: ecc_errors.row_remapping, " +
+	"ecc_errors.retired_pages"
+
 // ErrorCodes maps XID error codes to their metadata.
 // This table includes the most common and critical XIDs observed in
-// production GPU environments.
+// production GPU environments. It is the built-in catalog; lookups go
+// through the active Catalog, which an overlay may change.
 var ErrorCodes = map[int]ErrorInfo{
 	8: {
 		Code:        8,
@@ -99,19 +112,21 @@ var ErrorCodes = map[int]ErrorInfo{
 	},
 	63: {
 		Code:        63,
-		Name:        "Internal Micro-controller Halt",
-		Description: "GPU micro-controller halted unexpectedly",
+		Name:        "ECC Page Retirement or Row Remapping Event",
+		Description: "Driver recorded a page retirement or row remapping after ECC errors; the repair takes effect after the next GPU reset",
 		Severity:    "critical",
-		Action:      "GPU firmware failure. Reset required. If persistent, replace GPU.",
-		Category:    "hardware",
+		Action:      "Reset the GPU at the next opportunity. Check reset_required and remaining spare rows in get_gpu_health.",
+		Category:    "memory",
+		HealthCheck: eccRepairHealthCheck,
 	},
 	64: {
 		Code:        64,
-		Name:        "ECC Page Retirement Pending",
-		Description: "GPU has pages pending retirement due to excessive errors",
-		Severity:    "warning",
-		Action:      "Monitor ECC error rate. Schedule GPU replacement during next maintenance window.",
+		Name:        "ECC Page Retirement or Row Remapping Failure",
+		Description: "Driver failed to record a page retirement or row remapping, e.g. because the memory bank has no spare rows left",
+		Severity:    "critical",
+		Action:      "Drain node and schedule GPU replacement (RMA). Confirm with replace_required in get_gpu_health.",
 		Category:    "memory",
+		HealthCheck: eccRepairHealthCheck,
 	},
 	68: {
 		Code:        68,
@@ -155,35 +170,45 @@ var ErrorCodes = map[int]ErrorInfo{
 	},
 	94: {
 		Code:        94,
-		Name:        "Contained Error",
-		Description: "GPU detected and contained an error - no data corruption",
+		Name:        "Contained ECC Error",
+		Description: "Uncorrectable ECC error was contained to the affected application - no data corruption elsewhere",
 		Severity:    "warning",
-		Action:      "Monitor frequency. Isolated occurrences acceptable. Investigate if frequent.",
-		Category:    "hardware",
+		Action:      "Restart the affected workload. Reset the GPU if get_gpu_health reports a pending row remapping.",
+		Category:    "memory",
+		HealthCheck: eccRepairHealthCheck,
 	},
 	95: {
 		Code:        95,
-		Name:        "Uncontained Error",
-		Description: "GPU error could not be contained - potential data corruption",
+		Name:        "Uncontained ECC Error",
+		Description: "Uncorrectable ECC error could not be contained - potential data corruption across applications",
 		Severity:    "fatal",
-		Action:      "DRAIN NODE IMMEDIATELY. Potential data corruption. GPU must be replaced.",
-		Category:    "hardware",
+		Action:      "DRAIN NODE IMMEDIATELY. Potential data corruption. Reset the GPU; replace it if get_gpu_health reports replace_required.",
+		Category:    "memory",
+		HealthCheck: eccRepairHealthCheck,
 	},
 }
 
-// Lookup returns the ErrorInfo for a given XID code.
+// Severities lists the XID severities from least to most severe.
+var Severities = []string{"info", "warning", "critical", "fatal"}
+
+// SeverityRank returns the position of a severity in Severities, or -1 if
+// it is not one.
+func SeverityRank(severity string) int {
+	return slices.Index(Severities, severity)
+}
+
+// Lookup returns the ErrorInfo for a given XID code in the active catalog.
 // Returns the info and true if the code exists, or a zero value and false
 // if the code is unknown.
 func Lookup(code int) (ErrorInfo, bool) {
-	info, exists := ErrorCodes[code]
-	return info, exists
+	return ActiveCatalog().Lookup(code)
 }
 
 // LookupOrUnknown returns the ErrorInfo for a given XID code.
-// If the code is not in the known error table, it returns a generic
+// If the code is not in the active catalog, it returns a generic
 // ErrorInfo with "unknown" classification.
 func LookupOrUnknown(code int) ErrorInfo {
-	if info, exists := ErrorCodes[code]; exists {
+	if info, exists := Lookup(code); exists {
 		return info
 	}
 	return ErrorInfo{
@@ -195,3 +220,25 @@ func LookupOrUnknown(code int) ErrorInfo {
 		Category:    "unknown",
 	}
 }
+
+// Classify returns the ErrorInfo of a parsed event of any kind. NVRM events
+// have code 0.
+func Classify(event XIDEvent) ErrorInfo {
+	switch event.Kind {
+	case KindSXid:
+		return classifySXid(event)
+	case KindNVRM:
+		if info, exists := LookupNVRM(event.EventID); exists {
+			return info
+		}
+		return ErrorInfo{
+			Name:        "Unknown NVRM Event " + event.EventID,
+			Description: "Driver message not in known NVRM event table",
+			Severity:    "warning",
+			Action:      "Review raw_message and the NVIDIA driver documentation.",
+			Category:    "unknown",
+		}
+	default:
+		return LookupOrUnknown(event.XIDCode)
+	}
+}
@@ -5,6 +5,7 @@ package xid
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -271,3 +272,141 @@ func TestXID79SpecificProperties(t *testing.T) {
 	assert.Equal(t, "hardware", info.Category)
 	assert.Contains(t, info.Action, "DRAIN NODE IMMEDIATELY")
 }
+
+func TestMemoryRepairXIDsLinkHealthCheck(t *testing.T) {
+	// Page retirement and row remapping XIDs point at the memory repair
+	// state reported by get_gpu_health
+	for _, code := range []int{63, 64, 94, 95} {
+		t.Run(fmt.Sprintf("xid_%d", code), func(t *testing.T) {
+			info, exists := Lookup(code)
+			require.True(t, exists)
+
+			assert.Equal(t, "memory", info.Category)
+			assert.Contains(t, info.HealthCheck, "ecc_errors.row_remapping")
+			assert.Contains(t, info.HealthCheck, "ecc_errors.retired_pages")
+		})
+	}
+
+	assert.Empty(t, LookupOrUnknown(9999).HealthCheck)
+}
+
+func TestSeverityRank(t *testing.T) {
+	assert.Less(t, SeverityRank("info"), SeverityRank("warning"))
+	assert.Less(t, SeverityRank("warning"), SeverityRank("critical"))
+	assert.Less(t, SeverityRank("critical"), SeverityRank("fatal"))
+	assert.Equal(t, -1, SeverityRank("severe"))
+
+	// Every known XID has a ranked severity
+	for code, info := range ErrorCodes {
+		assert.GreaterOrEqual(t, SeverityRank(info.Severity), 0, "XID %d", code)
+	}
+}
+
+func TestAllSXidsAndNVRMEventsHaveMetadata(t *testing.T) {
+	check := func(t *testing.T, info ErrorInfo) {
+		assert.NotEmpty(t, info.Name)
+		assert.NotEmpty(t, info.Description)
+		assert.NotEmpty(t, info.Action)
+		assert.Contains(t, []string{"info", "warning", "critical", "fatal"},
+			info.Severity)
+		if info.Severity == "fatal" {
+			assert.Contains(t, info.Action, "DRAIN")
+		}
+	}
+
+	for code, info := range SXidCodes {
+		t.Run(fmt.Sprintf("sxid_%d", code), func(t *testing.T) {
+			assert.Equal(t, code, info.Code, "code field should match map key")
+			assert.Equal(t, "nvswitch", info.Category)
+			check(t, info)
+		})
+	}
+
+	ids := make(map[string]bool)
+	for _, event := range NVRMEvents {
+		t.Run(event.ID, func(t *testing.T) {
+			assert.False(t, ids[event.ID], "NVRM event IDs must be unique")
+			ids[event.ID] = true
+			require.NotNil(t, event.Pattern)
+			assert.Contains(t,
+				[]string{"hardware", "power", "driver"}, event.Info.Category)
+			check(t, event.Info)
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        XIDEvent
+		wantName     string
+		wantSeverity string
+		wantDrain    bool
+	}{
+		{
+			name:         "xid",
+			event:        XIDEvent{XIDCode: 79},
+			wantName:     "GPU Fallen Off Bus",
+			wantSeverity: "fatal",
+			wantDrain:    true,
+		},
+		{
+			name:         "known sxid",
+			event:        XIDEvent{Kind: KindSXid, XIDCode: 12028},
+			wantName:     "Multicast Crumbstore Timeout",
+			wantSeverity: "warning",
+		},
+		{
+			name:         "sxid single bit ECC",
+			event:        XIDEvent{Kind: KindSXid, XIDCode: 19049},
+			wantName:     "NVSwitch Single Bit ECC Error",
+			wantSeverity: "info",
+		},
+		{
+			name:         "known sxid reported fatal",
+			event:        XIDEvent{Kind: KindSXid, XIDCode: 22013, Fatal: true},
+			wantName:     "Minion Link DLREQ Interrupt",
+			wantSeverity: "fatal",
+			wantDrain:    true,
+		},
+		{
+			name:         "unknown non-fatal sxid",
+			event:        XIDEvent{Kind: KindSXid, XIDCode: 99999},
+			wantName:     "Unknown SXid 99999",
+			wantSeverity: "warning",
+		},
+		{
+			name:         "unknown fatal sxid",
+			event:        XIDEvent{Kind: KindSXid, XIDCode: 99999, Fatal: true},
+			wantName:     "Unknown SXid 99999",
+			wantSeverity: "fatal",
+			wantDrain:    true,
+		},
+		{
+			name:         "nvrm",
+			event:        XIDEvent{Kind: KindNVRM, EventID: "rm_init_adapter_failed"},
+			wantName:     "GPU Initialization Failed",
+			wantSeverity: "fatal",
+			wantDrain:    true,
+		},
+		{
+			name:         "unknown nvrm",
+			event:        XIDEvent{Kind: KindNVRM, EventID: "gone"},
+			wantName:     "Unknown NVRM Event gone",
+			wantSeverity: "warning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := Classify(tt.event)
+			assert.Equal(t, tt.wantName, info.Name)
+			assert.Equal(t, tt.wantSeverity, info.Severity)
+			assert.Equal(t, tt.wantDrain, strings.Contains(info.Action, "DRAIN"))
+		})
+	}
+
+	// The catalog entry itself is not modified
+	info, _ := LookupSXid(22013)
+	assert.Equal(t, "warning", info.Severity)
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// followPollInterval is how often a kernel log in a regular file, as
+	// written for simulated nodes, is checked for new records. /dev/kmsg
+	// wakes the follower itself.
+	followPollInterval = time.Second
+
+	// journalPruneInterval is how often expired events are dropped from
+	// the journal file.
+	journalPruneInterval = time.Hour
+)
+
+// Follower tails the kernel log and passes XID events, as they are logged,
+// to a journal, so that they can be analyzed after the kernel ring buffer
+// has rotated them out, and to an optional event handler.
+type Follower struct {
+	parser  *Parser
+	journal *Journal
+	onEvent func(XIDEvent)
+	running atomic.Bool
+	// xid79 holds the GPUs, by gpuBusKey, that logged an XID 79 and whose
+	// NVRM fallen-off-bus message is not recorded again
+	xid79 map[string]bool
+}
+
+// NewFollower creates a follower that reads the kernel log of parser and
+// records its XID events in journal. journal may be nil when the events
+// are only passed to an event handler.
+func NewFollower(parser *Parser, journal *Journal) *Follower {
+	return &Follower{parser: parser, journal: journal, xid79: make(map[string]bool)}
+}
+
+// SetEventHandler sets a function called with every XID event read, also
+// those the journal already holds, e.g. to export metrics. It must be set
+// before Run or Start.
+func (f *Follower) SetEventHandler(fn func(XIDEvent)) {
+	f.onEvent = fn
+}
+
+// Parser returns the parser whose kernel log the follower reads.
+func (f *Follower) Parser() *Parser {
+	return f.parser
+}
+
+// Journal returns the journal the follower records events in, or nil.
+func (f *Follower) Journal() *Journal {
+	return f.journal
+}
+
+// Running reports whether the follower is following the kernel log. It is
+// false before Run or Start, and after it stopped, e.g. on a read error;
+// the journal then misses the events logged since.
+func (f *Follower) Running() bool {
+	return f.running.Load()
+}
+
+// Run records XID events until ctx is cancelled. It starts at the oldest
+// record in the kernel log and skips those the journal already holds,
+// which makes restarts safe.
+func (f *Follower) Run(ctx context.Context) error {
+	file, bootID, boot, err := f.open()
+	if err != nil {
+		return err
+	}
+	return f.follow(ctx, file, bootID, boot)
+}
+
+// Start opens the kernel log and records XID events in the background, as
+// Run does, until ctx is cancelled. Errors opening the kernel log are
+// returned; an error that stops the follower later is logged.
+func (f *Follower) Start(ctx context.Context) error {
+	file, bootID, boot, err := f.open()
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := f.follow(ctx, file, bootID, boot); err != nil {
+			klog.ErrorS(err, "stopped following kernel log for XID events")
+		}
+	}()
+	return nil
+}
+
+// open opens the kernel log and resolves the boot ID, if the follower has
+// a journal, and the boot time the log timestamps count from. On success
+// the follower is running until follow returns.
+func (f *Follower) open() (*os.File, string, time.Time, error) {
+	var bootID string
+	if f.journal != nil {
+		var err error
+		if bootID, err = f.parser.bootID(); err != nil {
+			return nil, "", time.Time{}, err
+		}
+	}
+	boot, err := f.parser.resolveBootTime()
+	if err != nil {
+		klog.V(2).InfoS("boot time unknown, followed XID events have no timestamps",
+			"error", err)
+	}
+
+	path := f.parser.kmsgPath
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, "", time.Time{}, fmt.Errorf("permission denied reading %s "+
+				"(requires CAP_SYSLOG or root): %w", path, err)
+		}
+		return nil, "", time.Time{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	f.running.Store(true)
+	return file, bootID, boot, nil
+}
+
+// follow records the XID events read from the kernel log in file until
+// ctx is cancelled or reading fails.
+func (f *Follower) follow(
+	ctx context.Context,
+	file *os.File,
+	bootID string,
+	boot time.Time,
+) error {
+	defer f.running.Store(false)
+	defer func() { _ = file.Close() }()
+	path := f.parser.kmsgPath
+
+	// Closing the file interrupts a blocked read of /dev/kmsg
+	stop := context.AfterFunc(ctx, func() { _ = file.Close() })
+	defer stop()
+
+	if f.journal != nil {
+		go f.prune(ctx)
+		last, resumed := f.journal.LastSequence(bootID)
+		klog.InfoS("following kernel log for XID events", "source", path,
+			"bootID", bootID, "resumed", resumed, "lastSequence", last)
+	} else {
+		klog.InfoS("following kernel log for XID events", "source", path)
+	}
+
+	reader := bufio.NewReader(file)
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			switch {
+			case errors.Is(err, io.EOF):
+				// A regular file: keep what was read of an unfinished
+				// record and wait for more
+				partial += line
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(followPollInterval):
+				}
+				continue
+			case errors.Is(err, syscall.EPIPE):
+				// The kernel overwrote records before they were read;
+				// reading resumes at the oldest record left
+				klog.V(2).InfoS("kernel log records were lost", "source", path)
+				continue
+			default:
+				return fmt.Errorf("error reading %s: %w", path, err)
+			}
+		}
+		line, partial = partial+line, ""
+		f.record(bootID, boot, strings.TrimSuffix(line, "\n"))
+	}
+}
+
+// record passes the XID event in a kernel log record, if any, to the event
+// handler and the journal.
+func (f *Follower) record(bootID string, boot time.Time, line string) {
+	record, err := parseKmsgRecord(line)
+	if err != nil || !isDriverMessage(record.Message) {
+		return
+	}
+	event := f.parser.parseLine(record.Message)
+	if event == nil {
+		return
+	}
+	switch {
+	case isFallenOffBusXID(event):
+		f.xid79[gpuBusKey(event.PCIBusID)] = true
+	case isFallenOffBusNVRM(event) && f.xid79[gpuBusKey(event.PCIBusID)]:
+		return
+	}
+	if !boot.IsZero() && record.Timestamp > 0 {
+		event.Timestamp = boot.Add(record.Timestamp)
+	}
+
+	if f.onEvent != nil {
+		f.onEvent(*event)
+	}
+	if f.journal == nil {
+		return
+	}
+	added, err := f.journal.Append(bootID, record.Sequence, *event)
+	if err != nil {
+		klog.ErrorS(err, "failed to journal XID event",
+			"xid", event.XIDCode, "pciBusID", event.PCIBusID)
+		return
+	}
+	if added {
+		klog.V(2).InfoS("journaled XID event", "xid", event.XIDCode,
+			"pciBusID", event.PCIBusID, "sequence", record.Sequence)
+	}
+}
+
+// prune drops expired events from the journal periodically until ctx is
+// cancelled.
+func (f *Follower) prune(ctx context.Context) {
+	ticker := time.NewTicker(journalPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.journal.Prune(); err != nil {
+				klog.ErrorS(err, "failed to prune XID journal")
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollower_Run(t *testing.T) {
+	dir := t.TempDir()
+	kmsgPath := filepath.Join(dir, "kmsg")
+	journalPath := filepath.Join(dir, "xid-journal.jsonl")
+	boot := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	require.NoError(t, os.WriteFile(kmsgPath, []byte(
+		"6,0,1000000,-;kernel: unrelated message\n"+
+			"4,1,2000000,-;NVRM: Xid (PCI:0000:00:1E.0): 48, pid=1234, name=python3\n",
+	), 0o644))
+
+	// follow runs a follower until the journal holds want events, as an
+	// agent would until it is restarted
+	follow := func(want int) []XIDEvent {
+		journal, err := OpenJournal(JournalConfig{Path: journalPath})
+		require.NoError(t, err)
+		defer func() { _ = journal.Close() }()
+
+		parser := NewParserWithKmsgPath(kmsgPath)
+		parser.SetBootTime(boot)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- NewFollower(parser, journal).Run(ctx) }()
+
+		require.Eventually(t, func() bool {
+			return len(journal.Events()) >= want
+		}, 5*time.Second, 10*time.Millisecond)
+		// Give the follower time to record anything unexpected
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		require.NoError(t, <-done)
+		return journal.Events()
+	}
+
+	events := follow(1)
+	require.Len(t, events, 1)
+	assert.Equal(t, 48, events[0].XIDCode)
+	assert.Equal(t, 1234, events[0].PID)
+	assert.Equal(t, boot.Add(2*time.Second), events[0].Timestamp)
+
+	// The kernel logs another XID while the agent is down
+	file, err := os.OpenFile(kmsgPath, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = file.WriteString(
+		"4,2,3000000,-;NVRM: Xid (PCI:0000:00:1E.0): 79, pid=1234, name=python3\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// After a restart the first XID is not recorded again
+	events = follow(2)
+	require.Len(t, events, 2)
+	assert.Equal(t, 48, events[0].XIDCode)
+	assert.Equal(t, 79, events[1].XIDCode)
+}
+
+func TestFollower_RunMissingKernelLog(t *testing.T) {
+	dir := t.TempDir()
+	journal, err := OpenJournal(JournalConfig{Path: filepath.Join(dir, "journal")})
+	require.NoError(t, err)
+	defer func() { _ = journal.Close() }()
+
+	follower := NewFollower(NewParserWithKmsgPath(filepath.Join(dir, "missing")), journal)
+	assert.Error(t, follower.Run(context.Background()))
+}
+
+func TestFollower_Start(t *testing.T) {
+	dir := t.TempDir()
+	journal, err := OpenJournal(JournalConfig{Path: filepath.Join(dir, "journal")})
+	require.NoError(t, err)
+	defer func() { _ = journal.Close() }()
+
+	// The kernel log is opened before Start returns
+	follower := NewFollower(NewParserWithKmsgPath(filepath.Join(dir, "missing")), journal)
+	require.Error(t, follower.Start(context.Background()))
+	assert.False(t, follower.Running())
+
+	kmsgPath := filepath.Join(dir, "kmsg")
+	require.NoError(t, os.WriteFile(kmsgPath, nil, 0o644))
+	follower = NewFollower(NewParserWithKmsgPath(kmsgPath), journal)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, follower.Start(ctx))
+	assert.True(t, follower.Running())
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		return !follower.Running()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFollower_RunEventHandler(t *testing.T) {
+	kmsgPath := filepath.Join(t.TempDir(), "kmsg")
+	require.NoError(t, os.WriteFile(kmsgPath, []byte(
+		"4,0,2000000,-;NVRM: Xid (PCI:0000:00:1E.0): 48, pid=1234, name=python3\n",
+	), 0o644))
+
+	// Without a journal, events only go to the handler
+	follower := NewFollower(NewParserWithKmsgPath(kmsgPath), nil)
+	events := make(chan XIDEvent, 10)
+	follower.SetEventHandler(func(event XIDEvent) { events <- event })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- follower.Run(ctx) }()
+
+	select {
+	case event := <-events:
+		assert.Equal(t, 48, event.XIDCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+
+	// Records logged later are followed too
+	file, err := os.OpenFile(kmsgPath, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = file.WriteString("4,1,3000000,-;NVRM: Xid (PCI:0000:00:1E.0): 79\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	select {
+	case event := <-events:
+		assert.Equal(t, 79, event.XIDCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for the new record")
+	}
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestFollower_recordFallenOffBus(t *testing.T) {
+	follower := NewFollower(NewParser(), nil)
+	var events []XIDEvent
+	follower.SetEventHandler(func(event XIDEvent) { events = append(events, event) })
+
+	for _, line := range []string{
+		"4,0,6002000117,-;NVRM: Xid (PCI:0000:3b:00): 79, pid='<unknown>', name=<unknown>, GPU has fallen off the bus.",
+		"4,1,6002000190,-;NVRM: GPU 0000:3b:00.0: GPU has fallen off the bus.",
+		"4,2,6100000000,-;NVRM: GPU 0000:86:00.0: GPU has fallen off the bus.",
+	} {
+		follower.record("", time.Time{}, line)
+	}
+
+	// The NVRM message of the GPU with an XID 79 is not recorded again
+	require.Len(t, events, 2)
+	assert.Equal(t, 79, events[0].XIDCode)
+	assert.Equal(t, "gpu_fallen_off_bus", events[1].EventID)
+	assert.Equal(t, "0000:86:00.0", events[1].PCIBusID)
+}
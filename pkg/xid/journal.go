@@ -0,0 +1,324 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultJournalMaxAge is how long XID events are kept in the journal.
+	DefaultJournalMaxAge = 7 * 24 * time.Hour
+
+	// DefaultJournalMaxSize is the size in bytes the journal file is kept
+	// under.
+	DefaultJournalMaxSize = 10 << 20
+
+	// maxJournalLine bounds a journal line when loading, well above the
+	// size of any kernel log record.
+	maxJournalLine = 64 << 10
+)
+
+// JournalConfig configures an XID journal.
+type JournalConfig struct {
+	// Path is the journal file, created with its directory if missing
+	Path string
+	// MaxAge drops events older than this (default DefaultJournalMaxAge)
+	MaxAge time.Duration
+	// MaxSize is the file size in bytes that triggers dropping the oldest
+	// events (default DefaultJournalMaxSize)
+	MaxSize int64
+}
+
+// JournalEntry is an XID event as stored in the journal, one JSON object
+// per line.
+type JournalEntry struct {
+	// BootID identifies the kernel boot that logged the event; kmsg
+	// sequence numbers restart with every boot
+	BootID string `json:"boot_id"`
+	// Sequence is the kmsg sequence number of the record
+	Sequence uint64 `json:"seq"`
+	// RecordedAt is when the event was added to the journal
+	RecordedAt time.Time `json:"recorded_at"`
+	Event      XIDEvent  `json:"event"`
+}
+
+// Journal is a bounded on-disk log of XID events that outlives both the
+// kernel ring buffer and the agent. Events are deduplicated by boot and
+// kmsg sequence number, so a restarted agent can re-read the ring buffer
+// without recording events twice. It is safe for concurrent use.
+type Journal struct {
+	path    string
+	maxAge  time.Duration
+	maxSize int64
+	now     func() time.Time
+
+	mu      sync.Mutex
+	file    *os.File
+	entries []JournalEntry
+	size    int64
+	// lastSeq is the highest sequence number recorded per boot
+	lastSeq map[string]uint64
+}
+
+// OpenJournal opens the journal at cfg.Path, loading the events it holds
+// and dropping expired ones.
+func OpenJournal(cfg JournalConfig) (*Journal, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("journal path is required")
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = DefaultJournalMaxAge
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultJournalMaxSize
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	j := &Journal{
+		path:    cfg.Path,
+		maxAge:  cfg.MaxAge,
+		maxSize: cfg.MaxSize,
+		now:     time.Now,
+		lastSeq: make(map[string]uint64),
+	}
+	if err := j.load(); err != nil {
+		return nil, err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	// Rewriting also drops expired events and lines that did not parse
+	if err := j.rewriteLocked(j.entries); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// load reads the journal file, skipping malformed lines such as a line cut
+// short by a crash.
+func (j *Journal) load() error {
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open journal %s: %w", j.path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), maxJournalLine)
+	skipped := 0
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			skipped++
+			continue
+		}
+		j.entries = append(j.entries, entry)
+		if entry.Sequence >= j.lastSeq[entry.BootID] {
+			j.lastSeq[entry.BootID] = entry.Sequence
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read journal %s: %w", j.path, err)
+	}
+	if skipped > 0 {
+		klog.InfoS("skipped malformed XID journal entries",
+			"journal", j.path, "skipped", skipped)
+	}
+	return nil
+}
+
+// LastSequence returns the highest kmsg sequence number recorded for a
+// boot; ok is false if none was.
+func (j *Journal) LastSequence(bootID string) (seq uint64, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	seq, ok = j.lastSeq[bootID]
+	return seq, ok
+}
+
+// Append records an event logged by boot bootID with kmsg sequence number
+// seq. It reports false, without error, for events already recorded and
+// for events older than the journal keeps.
+func (j *Journal) Append(bootID string, seq uint64, event XIDEvent) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if last, ok := j.lastSeq[bootID]; ok && seq <= last {
+		return false, nil
+	}
+	entry := JournalEntry{
+		BootID:     bootID,
+		Sequence:   seq,
+		RecordedAt: j.now().UTC(),
+		Event:      event,
+	}
+	if j.expired(entry) {
+		j.lastSeq[bootID] = seq
+		return false, nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode journal entry: %w", err)
+	}
+	line = append(line, '\n')
+	if j.file == nil {
+		return false, fmt.Errorf("journal %s is closed", j.path)
+	}
+	if _, err := j.file.Write(line); err != nil {
+		return false, fmt.Errorf("failed to write journal %s: %w", j.path, err)
+	}
+	j.entries = append(j.entries, entry)
+	j.size += int64(len(line))
+	j.lastSeq[bootID] = seq
+
+	if j.size > j.maxSize {
+		if err := j.rewriteLocked(j.entries); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// Events returns the events in the journal that have not expired, in the
+// order they were logged.
+func (j *Journal) Events() []XIDEvent {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	events := make([]XIDEvent, 0, len(j.entries))
+	for _, entry := range j.entries {
+		if !j.expired(entry) {
+			events = append(events, entry.Event)
+		}
+	}
+	return events
+}
+
+// Prune drops expired events from the journal file.
+func (j *Journal) Prune() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	for _, entry := range j.entries {
+		if j.expired(entry) {
+			return j.rewriteLocked(j.entries)
+		}
+	}
+	return nil
+}
+
+// Close closes the journal file. Later appends fail.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// expired reports whether an entry is older than the journal keeps, by
+// the time of the event or, if unknown, the time it was recorded.
+func (j *Journal) expired(entry JournalEntry) bool {
+	at := entry.Event.Timestamp
+	if at.IsZero() {
+		at = entry.RecordedAt
+	}
+	return j.now().Sub(at) > j.maxAge
+}
+
+// rewriteLocked replaces the journal file with the unexpired entries,
+// dropping the oldest until the file is well under its size limit, and
+// reopens it for appending. The newest entry is never dropped for size, so
+// the file keeps the sequence number to resume the current boot from.
+func (j *Journal) rewriteLocked(entries []JournalEntry) error {
+	lines := make([][]byte, 0, len(entries))
+	kept := make([]JournalEntry, 0, len(entries))
+	var size int64
+	for _, entry := range entries {
+		if j.expired(entry) {
+			continue
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode journal entry: %w", err)
+		}
+		line = append(line, '\n')
+		lines = append(lines, line)
+		kept = append(kept, entry)
+		size += int64(len(line))
+	}
+
+	// Leave room for new events so that not every append rewrites
+	target := j.maxSize * 3 / 4
+	drop := 0
+	for size > target && drop < len(lines)-1 {
+		size -= int64(len(lines[drop]))
+		drop++
+	}
+	if drop > 0 {
+		klog.V(2).InfoS("dropping oldest XID journal entries",
+			"journal", j.path, "dropped", drop)
+	}
+	lines, kept = lines[drop:], kept[drop:]
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to rewrite journal %s: %w", j.path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	writer := bufio.NewWriter(tmp)
+	for _, line := range lines {
+		_, _ = writer.Write(line)
+	}
+	if err := writer.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to rewrite journal %s: %w", j.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to rewrite journal %s: %w", j.path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to rewrite journal %s: %w", j.path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), j.path); err != nil {
+		return fmt.Errorf("failed to rewrite journal %s: %w", j.path, err)
+	}
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal %s: %w", j.path, err)
+	}
+
+	if j.file != nil {
+		_ = j.file.Close()
+	}
+	j.file = file
+	j.entries = kept
+	j.size = size
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_AppendAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "xid-journal.jsonl")
+	journal, err := OpenJournal(JournalConfig{Path: path})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	added, err := journal.Append("boot-1", 7, XIDEvent{Timestamp: now, XIDCode: 48})
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = journal.Append("boot-1", 7, XIDEvent{Timestamp: now, XIDCode: 48})
+	require.NoError(t, err)
+	assert.False(t, added, "same sequence number")
+	added, err = journal.Append("boot-1", 9, XIDEvent{Timestamp: now, XIDCode: 79})
+	require.NoError(t, err)
+	assert.True(t, added)
+	// Sequence numbers restart with every boot
+	added, err = journal.Append("boot-2", 1, XIDEvent{Timestamp: now, XIDCode: 31})
+	require.NoError(t, err)
+	assert.True(t, added)
+	require.NoError(t, journal.Close())
+
+	reopened, err := OpenJournal(JournalConfig{Path: path})
+	require.NoError(t, err)
+	defer func() { _ = reopened.Close() }()
+
+	events := reopened.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, []int{48, 79, 31},
+		[]int{events[0].XIDCode, events[1].XIDCode, events[2].XIDCode})
+	assert.True(t, now.Equal(events[0].Timestamp))
+
+	seq, ok := reopened.LastSequence("boot-1")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(9), seq)
+	_, ok = reopened.LastSequence("boot-3")
+	assert.False(t, ok)
+
+	added, err = reopened.Append("boot-1", 8, XIDEvent{XIDCode: 48})
+	require.NoError(t, err)
+	assert.False(t, added, "recorded before the restart")
+}
+
+func TestJournal_MaxAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xid-journal.jsonl")
+	journal, err := OpenJournal(JournalConfig{Path: path, MaxAge: time.Hour})
+	require.NoError(t, err)
+	defer func() { _ = journal.Close() }()
+
+	now := time.Now()
+	journal.now = func() time.Time { return now }
+
+	added, err := journal.Append("boot-1", 1,
+		XIDEvent{Timestamp: now.Add(-2 * time.Hour), XIDCode: 13})
+	require.NoError(t, err)
+	assert.False(t, added, "already older than the journal keeps")
+
+	_, err = journal.Append("boot-1", 2, XIDEvent{Timestamp: now, XIDCode: 48})
+	require.NoError(t, err)
+	// Without a timestamp, the time it was recorded counts
+	_, err = journal.Append("boot-1", 3, XIDEvent{XIDCode: 79})
+	require.NoError(t, err)
+	assert.Len(t, journal.Events(), 2)
+
+	now = now.Add(90 * time.Minute)
+	assert.Empty(t, journal.Events())
+	require.NoError(t, journal.Prune())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	// Dropping the events does not forget what was recorded
+	added, err = journal.Append("boot-1", 3, XIDEvent{XIDCode: 79})
+	require.NoError(t, err)
+	assert.False(t, added)
+}
+
+func TestJournal_MaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xid-journal.jsonl")
+	journal, err := OpenJournal(JournalConfig{Path: path, MaxSize: 2048})
+	require.NoError(t, err)
+	defer func() { _ = journal.Close() }()
+
+	now := time.Now()
+	for seq := uint64(1); seq <= 50; seq++ {
+		_, err := journal.Append("boot-1", seq, XIDEvent{
+			Timestamp:  now,
+			XIDCode:    48,
+			RawMessage: "NVRM: Xid (PCI:0000:00:1E.0): 48, pid=1234, name=python3",
+		})
+		require.NoError(t, err)
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(2048))
+
+	events := journal.Events()
+	require.NotEmpty(t, events)
+	assert.Less(t, len(events), 50, "oldest events dropped")
+	require.NoError(t, journal.Close())
+
+	// The newest event survives, so a restart resumes after it
+	reopened, err := OpenJournal(JournalConfig{Path: path, MaxSize: 2048})
+	require.NoError(t, err)
+	defer func() { _ = reopened.Close() }()
+	seq, ok := reopened.LastSequence("boot-1")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(50), seq)
+}
+
+func TestJournal_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xid-journal.jsonl")
+	now := time.Now().UTC().Format(time.RFC3339)
+	content := strings.Join([]string{
+		`{"boot_id":"boot-1","seq":4,"recorded_at":"` + now + `","event":{"xid_code":48}}`,
+		`{"boot_id":"boot-1","seq":5,"recor`, // cut short by a crash
+		"",
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	journal, err := OpenJournal(JournalConfig{Path: path})
+	require.NoError(t, err)
+	defer func() { _ = journal.Close() }()
+
+	events := journal.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, 48, events[0].XIDCode)
+
+	// The journal can be appended to again
+	added, err := journal.Append("boot-1", 5, XIDEvent{XIDCode: 79})
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Len(t, journal.Events(), 2)
+}
+
+func TestJournal_RequiresPath(t *testing.T) {
+	_, err := OpenJournal(JournalConfig{})
+	assert.Error(t, err)
+}
@@ -46,8 +46,24 @@ type KmsgRecord struct {
 }
 
 // ReadMessages reads all available messages from /dev/kmsg.
-// Returns messages filtered to only NVRM (NVIDIA driver) entries.
+// Returns messages filtered to only NVRM (NVIDIA driver) and SXid (NVSwitch
+// driver) entries.
 func (r *KmsgReader) ReadMessages(ctx context.Context) ([]string, error) {
+	records, err := r.ReadRecords(ctx)
+	if records == nil {
+		return nil, err
+	}
+	messages := make([]string, 0, len(records))
+	for _, record := range records {
+		messages = append(messages, record.Message)
+	}
+	return messages, err
+}
+
+// ReadRecords reads all available records from /dev/kmsg, keeping their
+// timestamps. Returns records filtered to only NVRM (NVIDIA driver) and
+// SXid (NVSwitch driver) entries.
+func (r *KmsgReader) ReadRecords(ctx context.Context) ([]KmsgRecord, error) {
 	// Check if /dev/kmsg exists and is readable
 	if _, err := os.Stat(r.path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("%s not found: %w", r.path, err)
@@ -80,21 +96,21 @@ func (r *KmsgReader) ReadMessages(ctx context.Context) ([]string, error) {
 
 	// Channel for results from scanner goroutine
 	type scanResult struct {
-		messages []string
-		err      error
+		records []KmsgRecord
+		err     error
 	}
 	resultCh := make(chan scanResult, 1)
 
 	// Read messages in a goroutine so we can cancel via context
 	go func() {
-		var messages []string
+		var records []KmsgRecord
 		scanner := bufio.NewScanner(file)
 
 		for scanner.Scan() {
 			// Check context before processing
 			select {
 			case <-readCtx.Done():
-				resultCh <- scanResult{messages: messages}
+				resultCh <- scanResult{records: records}
 				return
 			default:
 			}
@@ -106,8 +122,8 @@ func (r *KmsgReader) ReadMessages(ctx context.Context) ([]string, error) {
 			}
 
 			// Filter for NVIDIA driver messages only
-			if strings.Contains(record.Message, "NVRM") {
-				messages = append(messages, record.Message)
+			if isDriverMessage(record.Message) {
+				records = append(records, *record)
 			}
 		}
 
@@ -118,14 +134,14 @@ func (r *KmsgReader) ReadMessages(ctx context.Context) ([]string, error) {
 				scanErr = fmt.Errorf("error reading %s: %w", r.path, err)
 			}
 		}
-		resultCh <- scanResult{messages: messages, err: scanErr}
+		resultCh <- scanResult{records: records, err: scanErr}
 	}()
 
 	// Wait for completion or context cancellation
 	select {
 	case result := <-resultCh:
 		// Goroutine completed normally
-		return result.messages, result.err
+		return result.records, result.err
 
 	case <-readCtx.Done():
 		// Timeout or context cancelled
@@ -138,7 +154,7 @@ func (r *KmsgReader) ReadMessages(ctx context.Context) ([]string, error) {
 
 		// Drain the result channel to avoid goroutine leak
 		result := <-resultCh
-		return result.messages, nil
+		return result.records, nil
 	}
 }
 
@@ -293,7 +293,36 @@ func TestParser_ParseKernelLogs_FallbackToDmesg(t *testing.T) {
 	assert.Contains(t, err.Error(), "context cancelled")
 }
 
-func TestParser_parseMessages(t *testing.T) {
+func TestParser_ParseKernelLogs_CustomKmsgPath(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "kmsg")
+	content := `4,1,100,-;kernel: starting up
+3,2,200,-;NVRM: Xid (PCI:0000:01:00.0): 79, pid=1234, name=python3
+`
+	require.NoError(t, os.WriteFile(tmpFile, []byte(content), 0644))
+
+	boot := time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)
+	parser := NewParserWithKmsgPath(tmpFile)
+	parser.SetBootTime(boot)
+
+	events, err := parser.ParseKernelLogs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, 79, events[0].XIDCode)
+	assert.Equal(t, "0000:01:00.0", events[0].PCIBusID)
+	assert.Equal(t, 1234, events[0].PID)
+	assert.Equal(t, boot.Add(200*time.Microsecond), events[0].Timestamp)
+}
+
+func TestParser_ParseKernelLogs_CustomKmsgPathMissing(t *testing.T) {
+	parser := NewParserWithKmsgPath(filepath.Join(t.TempDir(), "missing"))
+
+	// A custom source never falls back to the host's dmesg
+	_, err := parser.ParseKernelLogs(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not readable")
+}
+
+func TestParser_parseRecords(t *testing.T) {
 	parser := NewParser()
 
 	tests := []struct {
@@ -345,7 +374,14 @@ func TestParser_parseMessages(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			events := parser.parseMessages(tt.messages)
+			records := make([]KmsgRecord, 0, len(tt.messages))
+			for i, msg := range tt.messages {
+				records = append(records, KmsgRecord{
+					Timestamp: time.Duration(i+1) * time.Second,
+					Message:   msg,
+				})
+			}
+			events := parser.parseRecords(records)
 
 			assert.Len(t, events, tt.wantCount)
 
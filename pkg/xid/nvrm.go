@@ -0,0 +1,189 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// NVRMEvent describes a failure the NVIDIA driver logs without an XID
+// number, recognized by the text of its NVRM message.
+type NVRMEvent struct {
+	// ID names the event in analyze_xid_errors output
+	ID string
+	// Pattern matches the message; its first submatch, if any, is the PCI
+	// bus ID of the GPU
+	Pattern *regexp.Regexp
+	Info    ErrorInfo
+}
+
+// NVRMEvents lists the NVRM messages without an XID that report a GPU or
+// driver failure. The first matching event wins.
+var NVRMEvents = []NVRMEvent{
+	{
+		ID:      "gpu_fallen_off_bus",
+		Pattern: regexp.MustCompile(`GPU (?:at PCI:)?([0-9a-fA-F:\.]+): GPU has fallen off the bus`),
+		Info: ErrorInfo{
+			Name:        "GPU Fallen Off Bus",
+			Description: "GPU is no longer accessible on PCIe bus, reported without an XID",
+			Severity:    "fatal",
+			Action:      "DRAIN NODE IMMEDIATELY. GPU hardware failure. Check PCIe connection, power and cooling, and replace GPU.",
+			Category:    "hardware",
+		},
+	},
+	{
+		ID:      "rm_init_adapter_failed",
+		Pattern: regexp.MustCompile(`GPU ([0-9a-fA-F:\.]+): RmInitAdapter failed`),
+		Info: ErrorInfo{
+			Name:        "GPU Initialization Failed",
+			Description: "Driver failed to initialize the GPU, which is unusable until it is",
+			Severity:    "fatal",
+			Action:      "DRAIN NODE. Look for an earlier fallen-off-bus or XID 79 on the GPU. Reboot the node; replace the GPU if initialization keeps failing.",
+			Category:    "hardware",
+		},
+	},
+	{
+		ID:      "vbios_copy_failed",
+		Pattern: regexp.MustCompile(`GPU ([0-9a-fA-F:\.]+): Failed to copy vbios to system memory`),
+		Info: ErrorInfo{
+			Name:        "VBIOS Read Failed",
+			Description: "Driver could not read the GPU VBIOS, usually because the GPU is not responding on PCIe",
+			Severity:    "critical",
+			Action:      "Drain node and reboot. Replace the GPU if the error persists.",
+			Category:    "hardware",
+		},
+	},
+	{
+		ID:      "power_cables_missing",
+		Pattern: regexp.MustCompile(`(?:GPU ([0-9a-fA-F:\.]+): )?GPU does not have the necessary power cables connected`),
+		Info: ErrorInfo{
+			Name:        "Power Cables Not Connected",
+			Description: "GPU auxiliary power is missing; the driver does not use the GPU",
+			Severity:    "critical",
+			Action:      "Check the GPU power cabling and the node power supplies.",
+			Category:    "power",
+		},
+	},
+	{
+		ID:      "gpu_not_supported",
+		Pattern: regexp.MustCompile(`The NVIDIA GPU ([0-9a-fA-F:\.]+) \(PCI ID: [0-9a-fA-F:]+\)`),
+		Info: ErrorInfo{
+			Name:        "GPU Not Supported By Driver",
+			Description: "Installed driver release does not support the GPU",
+			Severity:    "critical",
+			Action:      "Install a driver release that supports the GPU model.",
+			Category:    "driver",
+		},
+	},
+	{
+		ID:      "api_mismatch",
+		Pattern: regexp.MustCompile(`API mismatch: the client has the version`),
+		Info: ErrorInfo{
+			Name:        "Driver Version Mismatch",
+			Description: "User-space driver libraries and the kernel module have different versions",
+			Severity:    "critical",
+			Action:      "Align the driver libraries injected into containers with the loaded kernel module, e.g. after a driver upgrade without reboot.",
+			Category:    "driver",
+		},
+	},
+	{
+		ID:      "pci_io_region_invalid",
+		Pattern: regexp.MustCompile(`This PCI I/O region assigned to your NVIDIA device is invalid`),
+		Info: ErrorInfo{
+			Name:        "Invalid PCI I/O Region",
+			Description: "Firmware assigned the GPU an invalid PCI BAR; the driver cannot use the GPU",
+			Severity:    "critical",
+			Action:      "Check BIOS settings for large BAR support (Above 4G decoding) and update the system firmware.",
+			Category:    "hardware",
+		},
+	},
+	{
+		ID:      "gpu_crash_dump",
+		Pattern: regexp.MustCompile(`A GPU crash dump has been created`),
+		Info: ErrorInfo{
+			Name:        "GPU Crash Dump Created",
+			Description: "Driver saved a crash dump after a GPU failure, usually alongside an XID",
+			Severity:    "warning",
+			Action:      "Run nvidia-bug-report.sh on the node before the driver is reloaded to keep the dump for NVIDIA support.",
+			Category:    "driver",
+		},
+	},
+}
+
+// LookupNVRM returns the ErrorInfo of an NVRM event by ID, and whether the
+// ID is known.
+func LookupNVRM(id string) (ErrorInfo, bool) {
+	for _, event := range NVRMEvents {
+		if event.ID == id {
+			return event.Info, true
+		}
+	}
+	return ErrorInfo{}, false
+}
+
+// parseNVRMLine matches a kernel log line against NVRMEvents. Returns nil
+// if no event matches.
+func (p *Parser) parseNVRMLine(line string) *XIDEvent {
+	for _, nvrm := range NVRMEvents {
+		matches := nvrm.Pattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		event := &XIDEvent{
+			Kind:       KindNVRM,
+			EventID:    nvrm.ID,
+			RawMessage: line,
+			GPUIndex:   -1,
+		}
+		if len(matches) > 1 && matches[1] != "" {
+			event.PCIBusID = normalizePCIBusID(matches[1])
+		}
+		p.parseTimestamp(line, event)
+		return event
+	}
+	return nil
+}
+
+// fallenOffBusID is the NVRM event the driver logs next to XID 79 when
+// the GPU still has a bus ID to report.
+const fallenOffBusID = "gpu_fallen_off_bus"
+
+// gpuBusKey returns the PCI bus ID of a GPU without its function number:
+// XID lines log "0000:3B:00" for the GPU that NVRM lines log as
+// "0000:3B:00.0".
+func gpuBusKey(pciBusID string) string {
+	key, _, _ := strings.Cut(pciBusID, ".")
+	return key
+}
+
+// isFallenOffBusXID reports whether event is an XID 79.
+func isFallenOffBusXID(event *XIDEvent) bool {
+	return event.Kind != KindSXid && event.Kind != KindNVRM && event.XIDCode == 79
+}
+
+// isFallenOffBusNVRM reports whether event is the NVRM fallen-off-bus
+// message for a GPU with a bus ID.
+func isFallenOffBusNVRM(event *XIDEvent) bool {
+	return event.Kind == KindNVRM && event.EventID == fallenOffBusID &&
+		event.PCIBusID != ""
+}
+
+// dropFallenOffBusDuplicates removes the NVRM fallen-off-bus events of
+// GPUs that also logged an XID 79, so that one failure is reported once.
+func dropFallenOffBusDuplicates(events []XIDEvent) []XIDEvent {
+	xid79 := make(map[string]bool)
+	for i := range events {
+		if isFallenOffBusXID(&events[i]) {
+			xid79[gpuBusKey(events[i].PCIBusID)] = true
+		}
+	}
+	if len(xid79) == 0 {
+		return events
+	}
+	return slices.DeleteFunc(events, func(event XIDEvent) bool {
+		return isFallenOffBusNVRM(&event) && xid79[gpuBusKey(event.PCIBusID)]
+	})
+}
@@ -15,15 +15,41 @@ import (
 	"k8s.io/klog/v2"
 )
 
-// XIDEvent represents a parsed XID error event from kernel logs.
+// Kinds of events parsed from kernel logs.
+const (
+	// KindXID is a GPU XID error
+	KindXID = "xid"
+	// KindSXid is an NVSwitch SXid error
+	KindSXid = "sxid"
+	// KindNVRM is a driver failure logged without an XID, see NVRMEvents
+	KindNVRM = "nvrm"
+)
+
+// XIDEvent represents a parsed XID error event from kernel logs, or an
+// SXid or NVRM event.
 type XIDEvent struct {
-	Timestamp   time.Time `json:"timestamp"`
-	XIDCode     int       `json:"xid_code"`
-	PCIBusID    string    `json:"pci_bus_id"`
-	GPUIndex    int       `json:"gpu_index"`
-	PID         int       `json:"pid,omitempty"`
-	ProcessName string    `json:"process_name,omitempty"`
-	RawMessage  string    `json:"raw_message"`
+	// Kind is KindXID, KindSXid or KindNVRM; empty means KindXID
+	Kind string `json:"kind,omitempty"`
+	// Timestamp is the wall-clock time of the event, zero when the kernel
+	// timestamp or the boot time is unknown
+	Timestamp time.Time `json:"timestamp"`
+	// XIDCode is the XID or SXid code, zero for NVRM events
+	XIDCode int `json:"xid_code"`
+	// EventID is the ID of an NVRM event in NVRMEvents
+	EventID string `json:"event_id,omitempty"`
+	// PCIBusID is the GPU, or NVSwitch for SXids; empty if not logged
+	PCIBusID string `json:"pci_bus_id"`
+	// Device is the NVSwitch device of an SXid (e.g., nvidia-nvswitch3)
+	Device string `json:"device,omitempty"`
+	// Fatal is set for SXids the driver reports as fatal
+	Fatal       bool   `json:"fatal,omitempty"`
+	GPUIndex    int    `json:"gpu_index"`
+	PID         int    `json:"pid,omitempty"`
+	ProcessName string `json:"process_name,omitempty"`
+	RawMessage  string `json:"raw_message"`
+
+	// sinceBoot is the kernel timestamp of the event, zero when unknown
+	sinceBoot time.Duration
 }
 
 // Parser extracts XID error events from kernel dmesg output.
@@ -32,6 +58,10 @@ type Parser struct {
 	// NVRM: Xid (PCI:0000:00:1E.0): 48, pid='1234', name=python3
 	xidRegex *regexp.Regexp
 
+	// sxidRegex matches NVSwitch lines like:
+	// nvidia-nvswitch3: SXid (PCI:0000:c5:00.0): 12028, Non-fatal, Link 46 ...
+	sxidRegex *regexp.Regexp
+
 	// pidRegex extracts PID from XID line
 	pidRegex *regexp.Regexp
 
@@ -40,41 +70,121 @@ type Parser struct {
 
 	// timestampRegex extracts kernel timestamp [seconds.microseconds]
 	timestampRegex *regexp.Regexp
+
+	// kmsgPath is the kernel message buffer read by ParseKernelLogs
+	kmsgPath string
+
+	// bootTime is the wall-clock time of kernel timestamp zero; when zero
+	// it is read from /proc/stat
+	bootTime time.Time
 }
 
 // NewParser creates a new XID parser with compiled regex patterns.
 func NewParser() *Parser {
+	return NewParserWithKmsgPath(DefaultKmsgPath)
+}
+
+// NewParserWithKmsgPath creates a parser that reads kernel logs from a
+// file in /dev/kmsg format at the given path (for testing and simulated
+// nodes). Unlike the default parser it does not fall back to dmesg.
+func NewParserWithKmsgPath(path string) *Parser {
 	return &Parser{
-		xidRegex:         regexp.MustCompile(`Xid \(PCI:([0-9a-fA-F:\.]+)\):\s*(\d+)`),
+		xidRegex: regexp.MustCompile(`Xid \(PCI:([0-9a-fA-F:\.]+)\):\s*(\d+)`),
+		sxidRegex: regexp.MustCompile(
+			`(?:(nvidia-nvswitch\d+): )?SXid \(PCI:([0-9a-fA-F:\.]+)\):\s*(\d+),\s*((?i:fatal|non-fatal)),`),
 		pidRegex:         regexp.MustCompile(`pid[=']+(\d+)`),
 		processNameRegex: regexp.MustCompile(`name[=']+([^',\s]+)`),
-		timestampRegex:   regexp.MustCompile(`^\[\s*(\d+\.\d+)\]`),
+		timestampRegex:   regexp.MustCompile(`^(?:<\d+>)?\[\s*(\d+\.\d+)\]`),
+		kmsgPath:         path,
 	}
 }
 
-// ParseKernelLogs reads XID events from kernel logs.
-// Prefers /dev/kmsg when available, falls back to dmesg command.
+// SetBootTime sets the wall-clock time that kernel timestamps count from,
+// for kernel logs not written by the host kernel such as those of simulated
+// nodes. By default the host boot time is used.
+func (p *Parser) SetBootTime(t time.Time) {
+	p.bootTime = t
+}
+
+// ParseKernelLogs reads XID events from kernel logs, with wall-clock
+// timestamps. Prefers /dev/kmsg when available, falls back to dmesg command.
 func (p *Parser) ParseKernelLogs(ctx context.Context) ([]XIDEvent, error) {
+	events, err := p.readKernelLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.stampEvents(events)
+	return events, nil
+}
+
+// stampEvents sets the wall-clock timestamp of events from their kernel
+// timestamp and the boot time.
+func (p *Parser) stampEvents(events []XIDEvent) {
+	if len(events) == 0 {
+		return
+	}
+	boot, err := p.resolveBootTime()
+	if err != nil {
+		klog.V(2).InfoS("boot time unknown, XID events have no timestamps",
+			"error", err)
+		return
+	}
+	for i := range events {
+		if events[i].sinceBoot > 0 {
+			events[i].Timestamp = boot.Add(events[i].sinceBoot)
+		}
+	}
+}
+
+// resolveBootTime returns the wall-clock time kernel timestamps count from.
+func (p *Parser) resolveBootTime() (time.Time, error) {
+	if !p.bootTime.IsZero() {
+		return p.bootTime, nil
+	}
+	return readBootTime(procStatPath)
+}
+
+// bootID identifies the boot whose kernel log the parser reads. A custom
+// kernel log is identified by its path and boot time, as its sequence
+// numbers restart whenever it is recreated.
+func (p *Parser) bootID() (string, error) {
+	if p.kmsgPath != DefaultKmsgPath {
+		if p.bootTime.IsZero() {
+			return p.kmsgPath, nil
+		}
+		return fmt.Sprintf("%s@%d", p.kmsgPath, p.bootTime.UnixNano()), nil
+	}
+	return readBootID(bootIDPath)
+}
+
+// readKernelLogs reads XID events from /dev/kmsg or dmesg.
+func (p *Parser) readKernelLogs(ctx context.Context) ([]XIDEvent, error) {
 	// Check context before expensive operation
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("context cancelled: %w", err)
 	}
 
 	// Try /dev/kmsg first (works in distroless containers)
-	kmsgReader := NewKmsgReader()
+	kmsgReader := NewKmsgReaderWithPath(p.kmsgPath)
 	kmsgAvailable := kmsgReader.IsAvailable()
 
 	if kmsgAvailable {
-		klog.V(4).InfoS("reading kernel logs from /dev/kmsg")
-		messages, err := kmsgReader.ReadMessages(ctx)
+		klog.V(4).InfoS("reading kernel logs", "source", p.kmsgPath)
+		records, err := kmsgReader.ReadRecords(ctx)
 		if err == nil {
 			klog.V(4).InfoS("read kernel messages",
-				"count", len(messages), "source", "/dev/kmsg")
-			return p.parseMessages(messages), nil
+				"count", len(records), "source", p.kmsgPath)
+			return p.parseRecords(records), nil
+		}
+		// dmesg would show the host's logs rather than the custom source
+		if p.kmsgPath != DefaultKmsgPath {
+			return nil, err
 		}
 		// Log warning and fall back to dmesg
 		klog.V(2).InfoS("failed to read /dev/kmsg, falling back to dmesg",
 			"error", err)
+	} else if p.kmsgPath != DefaultKmsgPath {
+		return nil, fmt.Errorf("kernel log %s is not readable", p.kmsgPath)
 	} else {
 		klog.V(4).InfoS("/dev/kmsg not available, using dmesg")
 	}
@@ -95,18 +205,16 @@ func (p *Parser) ParseKernelLogs(ctx context.Context) ([]XIDEvent, error) {
 	return events, nil
 }
 
-// parseMessages extracts XID events from a slice of kernel log messages.
-func (p *Parser) parseMessages(messages []string) []XIDEvent {
+// parseRecords extracts XID, SXid and NVRM events from kernel log records.
+func (p *Parser) parseRecords(records []KmsgRecord) []XIDEvent {
 	var events []XIDEvent
-	for _, msg := range messages {
-		if !strings.Contains(msg, "Xid") {
-			continue
-		}
-		if event := p.parseXIDLine(msg); event != nil {
+	for _, record := range records {
+		if event := p.parseLine(record.Message); event != nil {
+			event.sinceBoot = record.Timestamp
 			events = append(events, *event)
 		}
 	}
-	return events
+	return dropFallenOffBusDuplicates(events)
 }
 
 // ParseDmesg executes dmesg and parses XID error events.
@@ -145,29 +253,47 @@ func (p *Parser) ParseDmesg(ctx context.Context) ([]XIDEvent, error) {
 	return p.parseDmesgOutput(string(output)), nil
 }
 
-// parseDmesgOutput extracts XID events from dmesg text output.
+// parseDmesgOutput extracts XID, SXid and NVRM events from dmesg text
+// output.
 func (p *Parser) parseDmesgOutput(output string) []XIDEvent {
 	var events []XIDEvent
 
 	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		// Skip lines that don't contain NVIDIA driver messages
-		if !strings.Contains(line, "NVRM") {
+		if !isDriverMessage(line) {
 			continue
 		}
 
-		// Skip lines that don't contain XID errors
-		if !strings.Contains(line, "Xid") {
-			continue
+		if event := p.parseLine(line); event != nil {
+			events = append(events, *event)
 		}
+	}
 
-		// Try to parse this line as an XID event
+	return dropFallenOffBusDuplicates(events)
+}
+
+// isDriverMessage reports whether a kernel log message comes from the
+// NVIDIA GPU driver (NVRM) or the NVSwitch driver (SXid).
+func isDriverMessage(message string) bool {
+	return strings.Contains(message, "NVRM") || strings.Contains(message, "SXid")
+}
+
+// parseLine extracts an XID, SXid or NVRM event from a kernel log line.
+// Returns nil if the line reports none.
+func (p *Parser) parseLine(line string) *XIDEvent {
+	switch {
+	case strings.Contains(line, "SXid"):
+		return p.parseSXidLine(line)
+	case strings.Contains(line, "Xid"):
 		if event := p.parseXIDLine(line); event != nil {
-			events = append(events, *event)
+			return event
 		}
 	}
-
-	return events
+	if strings.Contains(line, "NVRM") {
+		return p.parseNVRMLine(line)
+	}
+	return nil
 }
 
 // parseXIDLine extracts XID event details from a single dmesg line.
@@ -186,22 +312,14 @@ func (p *Parser) parseXIDLine(line string) *XIDEvent {
 	}
 
 	event := &XIDEvent{
+		Kind:       KindXID,
 		XIDCode:    xidCode,
 		PCIBusID:   normalizePCIBusID(pciBusID),
 		RawMessage: line,
 		GPUIndex:   -1, // Will be filled by tool handler
 	}
 
-	// Extract timestamp if present
-	// Format: [seconds.microseconds] from boot
-	if tsMatches := p.timestampRegex.FindStringSubmatch(line); len(tsMatches) >= 2 {
-		if seconds, err := strconv.ParseFloat(tsMatches[1], 64); err == nil {
-			// Convert seconds since boot to approximate timestamp
-			// Note: This is relative to system boot time, not absolute time
-			// For production use, would need to get boot time from /proc/uptime
-			event.Timestamp = time.Unix(int64(seconds), 0)
-		}
-	}
+	p.parseTimestamp(line, event)
 
 	// Extract PID if present
 	if pidMatches := p.pidRegex.FindStringSubmatch(line); len(pidMatches) >= 2 {
@@ -216,6 +334,17 @@ func (p *Parser) parseXIDLine(line string) *XIDEvent {
 	return event
 }
 
+// parseTimestamp sets the kernel timestamp of an event from a dmesg line,
+// if present. Format: [seconds.microseconds] from boot, converted to
+// wall-clock time by ParseKernelLogs.
+func (p *Parser) parseTimestamp(line string, event *XIDEvent) {
+	if tsMatches := p.timestampRegex.FindStringSubmatch(line); len(tsMatches) >= 2 {
+		if sinceBoot, err := time.ParseDuration(tsMatches[1] + "s"); err == nil {
+			event.sinceBoot = sinceBoot
+		}
+	}
+}
+
 // parseInt safely parses an integer string, returning 0 on error.
 // This is suitable for optional fields like PID where 0 is an acceptable
 // default (PIDs start at 1, so 0 is never a valid PID).
@@ -5,6 +5,8 @@ package xid
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -404,24 +406,27 @@ func TestParser_parseXIDLine_TimestampExtraction(t *testing.T) {
 	parser := NewParser()
 
 	tests := []struct {
-		name         string
-		line         string
-		wantZeroTime bool
+		name          string
+		line          string
+		wantSinceBoot time.Duration
 	}{
 		{
-			name:         "with_timestamp",
-			line:         `[  100.123456] NVRM: Xid (PCI:0000:00:1E.0): 48`,
-			wantZeroTime: false,
+			name:          "with_timestamp",
+			line:          `[  100.123456] NVRM: Xid (PCI:0000:00:1E.0): 48`,
+			wantSinceBoot: 100*time.Second + 123456*time.Microsecond,
 		},
 		{
-			name:         "without_timestamp",
-			line:         `NVRM: Xid (PCI:0000:00:1E.0): 48`,
-			wantZeroTime: true,
+			name:          "dmesg_raw_priority",
+			line:          `<4>[  100.123456] NVRM: Xid (PCI:0000:00:1E.0): 48`,
+			wantSinceBoot: 100*time.Second + 123456*time.Microsecond,
 		},
 		{
-			name:         "malformed_timestamp",
-			line:         `[ invalid ] NVRM: Xid (PCI:0000:00:1E.0): 48`,
-			wantZeroTime: true,
+			name: "without_timestamp",
+			line: `NVRM: Xid (PCI:0000:00:1E.0): 48`,
+		},
+		{
+			name: "malformed_timestamp",
+			line: `[ invalid ] NVRM: Xid (PCI:0000:00:1E.0): 48`,
 		},
 	}
 
@@ -430,13 +435,67 @@ func TestParser_parseXIDLine_TimestampExtraction(t *testing.T) {
 			event := parser.parseXIDLine(tt.line)
 			require.NotNil(t, event)
 
-			if tt.wantZeroTime {
-				assert.True(t, event.Timestamp.IsZero(),
-					"expected zero timestamp")
-			} else {
-				assert.False(t, event.Timestamp.IsZero(),
-					"expected non-zero timestamp")
+			assert.Equal(t, tt.wantSinceBoot, event.sinceBoot)
+			// Wall-clock time needs the boot time
+			assert.True(t, event.Timestamp.IsZero())
+		})
+	}
+}
+
+func TestParser_stampEvents(t *testing.T) {
+	boot := time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)
+	parser := NewParser()
+	parser.SetBootTime(boot)
+
+	events := []XIDEvent{
+		{XIDCode: 48, sinceBoot: 90 * time.Minute},
+		{XIDCode: 79},
+	}
+	parser.stampEvents(events)
+
+	assert.Equal(t, boot.Add(90*time.Minute), events[0].Timestamp)
+	assert.True(t, events[1].Timestamp.IsZero(), "no kernel timestamp")
+}
+
+func TestReadBootTime(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name:    "btime",
+			content: "cpu  1 2 3\nintr 12345\nbtime 1768464000\nprocesses 42\n",
+			want:    time.Unix(1768464000, 0),
+		},
+		{
+			name:    "missing",
+			content: "cpu  1 2 3\n",
+			wantErr: "no btime",
+		},
+		{
+			name:    "invalid",
+			content: "btime soon\n",
+			wantErr: "invalid btime",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			got, err := readBootTime(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
 			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got))
 		})
 	}
 }
@@ -469,3 +528,163 @@ func TestParser_parseDmesgOutput_OnlyNVRMMessages(t *testing.T) {
 	assert.Equal(t, 48, events[0].XIDCode)
 	assert.Equal(t, 79, events[1].XIDCode)
 }
+
+// mockDmesgHGX is a failure on an HGX node as the kernel logs it: NVSwitch
+// SXids with their Severity and Data follow-up lines, and driver failures
+// that carry no XID number.
+const mockDmesgHGX = `[ 5120.114301] nvidia-nvswitch3: SXid (PCI:0000:c5:00.0): 12028, Non-fatal, Link 46 MC TS crumbstore MCTO (First)
+[ 5120.114306] nvidia-nvswitch3: SXid (PCI:0000:c5:00.0): 12028, Severity 0 Engine instance 46 Sub-engine instance 00
+[ 5120.114310] nvidia-nvswitch3: SXid (PCI:0000:c5:00.0): 12028, Data {0x00000001, 0x00000001, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000}
+[ 6001.271904] nvidia-nvswitch0: SXid (PCI:0000:05:00.0): 20034, Fatal, Link 30 LTSSM Fault Up
+[ 6001.271911] nvidia-nvswitch0: SXid (PCI:0000:05:00.0): 20034, Severity 1 Engine instance 30 Sub-engine instance 00
+[ 6002.000117] NVRM: Xid (PCI:0000:3b:00): 79, pid='<unknown>', name=<unknown>, GPU has fallen off the bus.
+[ 6002.000190] NVRM: GPU 0000:3b:00.0: GPU has fallen off the bus.
+[ 6002.000213] NVRM: A GPU crash dump has been created. If possible, please run
+[ 6002.000214] NVRM: nvidia-bug-report.sh as root to collect this data before
+[ 6002.000215] NVRM: the NVIDIA kernel module is unloaded.
+[ 6100.532108] NVRM: GPU 0000:86:00.0: RmInitAdapter failed! (0x22:0x56:762)
+[ 6100.532395] NVRM: GPU 0000:86:00.0: rm_init_adapter failed, device minor number 5
+[ 6200.100000] NVRM: API mismatch: the client has the version 535.104.05, but
+[ 6200.100001] NVRM: this kernel module has the version 535.86.10.  Please
+[ 6300.000000] NVRM: The NVIDIA GPU 0000:af:00.0 (PCI ID: 10de:2330)
+[ 6300.000001] NVRM: installed in this system is not supported by the
+[ 6300.000002] NVRM: NVIDIA 470.223.02 driver release.
+[ 6400.000000] NVRM: GPU 0000:1b:00.0: GPU does not have the necessary power cables connected.
+[ 6500.000000] NVRM: loading NVIDIA UNIX x86_64 Kernel Module  535.104.05`
+
+func TestParser_parseDmesgOutput_SXidAndNVRM(t *testing.T) {
+	events := NewParser().parseDmesgOutput(mockDmesgHGX)
+
+	type want struct {
+		kind    string
+		code    int
+		eventID string
+		pciBus  string
+	}
+	got := make([]want, 0, len(events))
+	for _, e := range events {
+		got = append(got, want{e.Kind, e.XIDCode, e.EventID, e.PCIBusID})
+	}
+	assert.Equal(t, []want{
+		{KindSXid, 12028, "", "0000:C5:00.0"},
+		{KindSXid, 20034, "", "0000:05:00.0"},
+		{KindXID, 79, "", "0000:3B:00"},
+		{KindNVRM, 0, "gpu_crash_dump", ""},
+		{KindNVRM, 0, "rm_init_adapter_failed", "0000:86:00.0"},
+		{KindNVRM, 0, "api_mismatch", ""},
+		{KindNVRM, 0, "gpu_not_supported", "0000:AF:00.0"},
+		{KindNVRM, 0, "power_cables_missing", "0000:1B:00.0"},
+	}, got)
+
+	// SXid details come from the first line of each error
+	assert.Equal(t, "nvidia-nvswitch3", events[0].Device)
+	assert.False(t, events[0].Fatal)
+	assert.Equal(t, "nvidia-nvswitch0", events[1].Device)
+	assert.True(t, events[1].Fatal)
+	assert.Equal(t, 6001271904*time.Microsecond, events[1].sinceBoot)
+	assert.Equal(t, 6002000213*time.Microsecond, events[3].sinceBoot)
+
+	// The GPU that fell off the bus is one fatal event, its XID 79
+	var fallenOffBus int
+	for _, e := range events {
+		if e.PCIBusID == "0000:3B:00" || e.PCIBusID == "0000:3B:00.0" {
+			assert.Equal(t, "fatal", Classify(e).Severity)
+			fallenOffBus++
+		}
+	}
+	assert.Equal(t, 1, fallenOffBus)
+}
+
+func TestDropFallenOffBusDuplicates(t *testing.T) {
+	nvrm := func(bus string) XIDEvent {
+		return XIDEvent{Kind: KindNVRM, EventID: "gpu_fallen_off_bus", PCIBusID: bus}
+	}
+
+	events := dropFallenOffBusDuplicates([]XIDEvent{
+		nvrm("0000:3B:00.0"),
+		{Kind: KindXID, XIDCode: 79, PCIBusID: "0000:3B:00"},
+		// Another GPU fell off the bus without an XID
+		nvrm("0000:86:00.0"),
+		// SXid 79 is not an XID 79
+		{Kind: KindSXid, XIDCode: 79, PCIBusID: "0000:AF:00.0"},
+		nvrm("0000:AF:00.0"),
+	})
+
+	require.Len(t, events, 4)
+	assert.Equal(t, KindXID, events[0].Kind)
+	assert.Equal(t, "0000:86:00.0", events[1].PCIBusID)
+	assert.Equal(t, KindSXid, events[2].Kind)
+	assert.Equal(t, "0000:AF:00.0", events[3].PCIBusID)
+}
+
+func TestParser_parseLine_SXidFatalFlag(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		name      string
+		line      string
+		wantNil   bool
+		wantFatal bool
+		wantDev   string
+	}{
+		{
+			name:      "fatal",
+			line:      "nvidia-nvswitch2: SXid (PCI:0000:87:00.0): 24007, Fatal, Link 47 sourcetrack TCEN0 crumbstore ECC DBE Error",
+			wantFatal: true,
+			wantDev:   "nvidia-nvswitch2",
+		},
+		{
+			name: "non-fatal",
+			line: "nvidia-nvswitch1: SXid (PCI:0000:06:00.0): 22013, Non-fatal, Minion Link 20 DLREQ interrupt",
+			// device given
+			wantDev: "nvidia-nvswitch1",
+		},
+		{
+			name:    "data line",
+			line:    "nvidia-nvswitch2: SXid (PCI:0000:87:00.0): 24007, Data {0x00000000, 0x00000000}",
+			wantNil: true,
+		},
+		{
+			name:    "malformed",
+			line:    "nvidia-nvswitch2: SXid (PCI:0000:87:00.0): ECC, Fatal,",
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := parser.parseLine(tt.line)
+			if tt.wantNil {
+				assert.Nil(t, event)
+				return
+			}
+			require.NotNil(t, event)
+			assert.Equal(t, KindSXid, event.Kind)
+			assert.Equal(t, tt.wantFatal, event.Fatal)
+			assert.Equal(t, tt.wantDev, event.Device)
+			assert.Equal(t, -1, event.GPUIndex)
+		})
+	}
+}
+
+func TestParser_parseRecords_SXid(t *testing.T) {
+	records := []KmsgRecord{
+		{
+			Sequence:  1,
+			Timestamp: 5 * time.Second,
+			Message:   "nvidia-nvswitch0: SXid (PCI:0000:05:00.0): 20034, Fatal, Link 30 LTSSM Fault Up",
+		},
+		{
+			Sequence:  2,
+			Timestamp: 6 * time.Second,
+			Message:   "NVRM: GPU 0000:3b:00.0: GPU has fallen off the bus.",
+		},
+	}
+
+	events := NewParser().parseRecords(records)
+	require.Len(t, events, 2)
+	assert.Equal(t, KindSXid, events[0].Kind)
+	assert.Equal(t, 5*time.Second, events[0].sinceBoot)
+	assert.Equal(t, "gpu_fallen_off_bus", events[1].EventID)
+	assert.Equal(t, 6*time.Second, events[1].sinceBoot)
+}
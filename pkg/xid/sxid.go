@@ -0,0 +1,136 @@
+// Copyright 2026 k8s-gpu-mcp-server contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package xid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// nvswitchSBE describes the single bit ECC errors NVSwitches correct by
+// themselves.
+var nvswitchSBE = ErrorInfo{
+	Name:        "NVSwitch Single Bit ECC Error",
+	Description: "Single bit ECC error in NVSwitch memory, corrected by hardware",
+	Severity:    "info",
+	Action:      "No action needed. Monitor the rate; a growing rate may precede uncorrectable errors.",
+	Category:    "nvswitch",
+}
+
+// SXidCodes maps NVSwitch SXid error codes, logged on HGX systems by the
+// nvidia-nvswitch driver, to their metadata. SXids not in the table are
+// classified by the fatal or non-fatal flag of the log line.
+//
+// Reference: https://docs.nvidia.com/datacenter/tesla/fabric-manager-user-guide/
+var SXidCodes = map[int]ErrorInfo{
+	11004: {
+		Code:        11004,
+		Name:        "Ingress Invalid ACL",
+		Description: "NVSwitch port received a request that its access control list does not allow",
+		Severity:    "warning",
+		Action:      "Check the Fabric Manager partition configuration. Only a misconfigured partition causes this error.",
+		Category:    "nvswitch",
+	},
+	12028: {
+		Code:        12028,
+		Name:        "Multicast Crumbstore Timeout",
+		Description: "NVSwitch multicast request timed out waiting for responses",
+		Severity:    "warning",
+		Action:      "Monitor frequency. Check Fabric Manager logs and the NVLink status of the GPUs on the node.",
+		Category:    "nvswitch",
+	},
+	20034: {
+		Code:        20034,
+		Name:        "NVLink LTSSM Fault",
+		Description: "NVLink link training state machine fault on an NVSwitch port - the link went down",
+		Severity:    "fatal",
+		Action:      "DRAIN NODE IMMEDIATELY. Reset the GPUs and NVSwitches (reboot) and check the NVLink connection. Replace the baseboard if it recurs.",
+		Category:    "nvswitch",
+	},
+	22013: {
+		Code:        22013,
+		Name:        "Minion Link DLREQ Interrupt",
+		Description: "NVLink MINION controller reported a link state change request",
+		Severity:    "warning",
+		Action:      "Usually transient. Check get_nvlink_status for link errors if it repeats.",
+		Category:    "nvswitch",
+	},
+	24007: {
+		Code:        24007,
+		Name:        "Sourcetrack Crumbstore ECC DBE",
+		Description: "Uncorrectable ECC error in NVSwitch request tracking memory",
+		Severity:    "fatal",
+		Action:      "DRAIN NODE IMMEDIATELY. Reset the GPUs and NVSwitches (reboot). Replace the baseboard if it recurs.",
+		Category:    "nvswitch",
+	},
+}
+
+func init() {
+	for _, code := range []int{
+		11012, 11021, 11022, 11023, 12021, 12023, 15008, 15011, 19049, 19055,
+		19057, 19059, 19062, 19065, 19068, 19071, 23001, 24001, 24002, 24003,
+	} {
+		info := nvswitchSBE
+		info.Code = code
+		SXidCodes[code] = info
+	}
+}
+
+// LookupSXid returns the ErrorInfo for an SXid code, and whether the code
+// is in the active catalog.
+func LookupSXid(code int) (ErrorInfo, bool) {
+	return ActiveCatalog().LookupSXid(code)
+}
+
+// classifySXid returns the ErrorInfo of an SXid event. Unknown SXids, and
+// known ones the driver reports as fatal, take their severity from the log
+// line.
+func classifySXid(event XIDEvent) ErrorInfo {
+	info, exists := LookupSXid(event.XIDCode)
+	if !exists {
+		info = ErrorInfo{
+			Code:        event.XIDCode,
+			Name:        fmt.Sprintf("Unknown SXid %d", event.XIDCode),
+			Description: "NVSwitch error not in known SXid table - see raw_message for the driver's description",
+			Severity:    "warning",
+			Action:      "Check Fabric Manager logs and the NVSwitch SXid documentation.",
+			Category:    "nvswitch",
+		}
+	}
+	if event.Fatal && info.Severity != "fatal" {
+		info.Severity = "fatal"
+		info.Action = "DRAIN NODE. The driver reports a fatal NVSwitch error; " +
+			"reset the GPUs and NVSwitches (reboot). " + info.Action
+	}
+	return info
+}
+
+// parseSXidLine extracts an NVSwitch SXid event from a kernel log line such
+// as "nvidia-nvswitch3: SXid (PCI:0000:c5:00.0): 12028, Non-fatal, Link 46
+// MC TS crumbstore MCTO (First)". The "Severity" and "Data" lines the driver
+// logs after it carry no fatal flag and are not events. Returns nil if the
+// line cannot be parsed.
+func (p *Parser) parseSXidLine(line string) *XIDEvent {
+	matches := p.sxidRegex.FindStringSubmatch(line)
+	if len(matches) < 5 {
+		return nil
+	}
+	code, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return nil
+	}
+
+	event := &XIDEvent{
+		Kind:       KindSXid,
+		XIDCode:    code,
+		PCIBusID:   normalizePCIBusID(matches[2]),
+		Device:     matches[1],
+		Fatal:      strings.EqualFold(matches[4], "fatal"),
+		RawMessage: line,
+		GPUIndex:   -1,
+	}
+	p.parseTimestamp(line, event)
+	return event
+}